@@ -7,13 +7,20 @@ import (
 	"os/signal"
 	"time"
 
+	// Embed the time zone database so user time zones resolve in images
+	// without tzdata, such as the Alpine runtime image.
+	_ "time/tzdata"
+
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/sherwin-77/golang-todos/configs"
 	"github.com/sherwin-77/golang-todos/internal/builder"
 	"github.com/sherwin-77/golang-todos/internal/http/handler"
+	"github.com/sherwin-77/golang-todos/internal/worker"
 	"github.com/sherwin-77/golang-todos/pkg/caches"
 	"github.com/sherwin-77/golang-todos/pkg/database"
+	"github.com/sherwin-77/golang-todos/pkg/notifier"
 	"github.com/sherwin-77/golang-todos/pkg/server"
+	"github.com/sherwin-77/golang-todos/pkg/storage"
 )
 
 func main() {
@@ -24,7 +31,18 @@ func main() {
 		panic(err)
 	}
 
-	cache := caches.NewCache(caches.InitRedis(config.Redis))
+	redisClient := caches.InitRedis(config.Redis)
+	cache := caches.NewCache(redisClient)
+
+	blobStore, err := storage.NewBlobStore(config.Storage, config.Key)
+	if err != nil {
+		panic(err)
+	}
+
+	mailer, err := notifier.NewNotifier(config.Auth.Notifier, config)
+	if err != nil {
+		panic(err)
+	}
 
 	echoServer := server.NewServer()
 	echoServer.Use(middleware.LoggerWithConfig(configs.GetEchoLoggerConfig()))
@@ -33,10 +51,16 @@ func main() {
 	echoServer.HTTPErrorHandler = handler.HTTPErrorHandler
 
 	group := echoServer.Group("/api")
-	builder.BuildV1Routes(config, db, cache, group)
+	builder.BuildV1Routes(config, db, cache, blobStore, mailer, group)
+
+	scheduler, err := builder.BuildScheduler(config, db, redisClient, blobStore)
+	if err != nil {
+		panic(err)
+	}
 
 	runServer(echoServer, config)
-	waitForShutdown(echoServer)
+	scheduler.Start()
+	waitForShutdown(echoServer, scheduler)
 }
 
 func runServer(s *server.Server, config *configs.Config) {
@@ -47,7 +71,7 @@ func runServer(s *server.Server, config *configs.Config) {
 	}()
 }
 
-func waitForShutdown(s *server.Server) {
+func waitForShutdown(s *server.Server, scheduler *worker.Scheduler) {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt)
 
@@ -61,4 +85,8 @@ func waitForShutdown(s *server.Server) {
 			s.Logger.Fatal(err)
 		}
 	}()
+
+	if err := scheduler.Stop(ctx); err != nil {
+		s.Logger.Error(err)
+	}
 }
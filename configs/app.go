@@ -3,18 +3,50 @@ package configs
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Env       string
-	Key       string
-	JWTSecret string
-	Name      string
-	Port      string
-	Postgres  PostgresConfig
-	Redis     RedisConfig
+	Env          string
+	Key          string
+	JWTSecret    string
+	Name         string
+	Port         string
+	Auth         AuthConfig
+	Postgres     PostgresConfig
+	Redis        RedisConfig
+	Reminder     ReminderConfig
+	Trash        TrashConfig
+	SMTP         SMTPConfig
+	FileNotifier FileNotifierConfig
+	Webhook      WebhookConfig
+	Storage      StorageConfig
+	Upload       UploadConfig
+}
+
+// Email verification modes. Unverified users can log in but not create todos
+// in soft mode, and cannot log in at all in strict mode.
+const (
+	EmailVerificationSoft   = "soft"
+	EmailVerificationStrict = "strict"
+)
+
+type AuthConfig struct {
+	AccessTokenTTL          time.Duration
+	RefreshTokenTTL         time.Duration
+	Notifier                string
+	PasswordResetTTL        time.Duration
+	PasswordResetURL        string
+	EmailVerification       string
+	EmailVerificationTTL    time.Duration
+	EmailVerificationURL    string
+	EmailVerificationResend time.Duration
+	MFAIssuer               string
+	MFATokenTTL             time.Duration
 }
 
 type PostgresConfig struct {
@@ -32,6 +64,66 @@ type RedisConfig struct {
 	DB       int
 }
 
+type ReminderConfig struct {
+	Enabled     bool
+	Notifier    string
+	Interval    time.Duration
+	LockTTL     time.Duration
+	BatchSize   int
+	MaxAttempts int
+	Backoff     time.Duration
+}
+
+type TrashConfig struct {
+	PurgeEnabled  bool
+	Retention     time.Duration
+	PurgeInterval time.Duration
+}
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+type FileNotifierConfig struct {
+	Path string
+}
+
+type WebhookConfig struct {
+	URL     string
+	Secret  string
+	Timeout time.Duration
+}
+
+type StorageConfig struct {
+	Driver string
+	Local  LocalStorageConfig
+	S3     S3Config
+}
+
+type LocalStorageConfig struct {
+	Path string
+	URL  string
+}
+
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	PathStyle bool
+}
+
+type UploadConfig struct {
+	MaxSize      int64
+	AllowedTypes []string
+	URLExpiry    time.Duration
+}
+
 func GetConfig() *Config {
 	config := &Config{
 		Env:       os.Getenv("ENV"),
@@ -39,6 +131,19 @@ func GetConfig() *Config {
 		JWTSecret: os.Getenv("JWT_SECRET"),
 		Name:      os.Getenv("APP_NAME"),
 		Port:      os.Getenv("APP_PORT"),
+		Auth: AuthConfig{
+			AccessTokenTTL:          getEnvDuration("ACCESS_TOKEN_TTL", 15*time.Minute),
+			RefreshTokenTTL:         getEnvDuration("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+			Notifier:                getEnv("AUTH_NOTIFIER", "log"),
+			PasswordResetTTL:        getEnvDuration("PASSWORD_RESET_TTL", time.Hour),
+			PasswordResetURL:        getEnv("PASSWORD_RESET_URL", "http://localhost:3000/reset-password"),
+			EmailVerification:       getEnv("EMAIL_VERIFICATION", EmailVerificationSoft),
+			EmailVerificationTTL:    getEnvDuration("EMAIL_VERIFICATION_TTL", 48*time.Hour),
+			EmailVerificationURL:    getEnv("EMAIL_VERIFICATION_URL", "http://localhost:8080/api/v1/verify-email"),
+			EmailVerificationResend: getEnvDuration("EMAIL_VERIFICATION_RESEND_INTERVAL", time.Minute),
+			MFAIssuer:               getEnv("MFA_ISSUER", "Golang Todos"),
+			MFATokenTTL:             getEnvDuration("MFA_TOKEN_TTL", 5*time.Minute),
+		},
 		Postgres: PostgresConfig{
 			Host:     os.Getenv("POSTGRES_HOST"),
 			Port:     os.Getenv("POSTGRES_PORT"),
@@ -52,6 +157,55 @@ func GetConfig() *Config {
 			Password: os.Getenv("REDIS_PASSWORD"),
 			DB:       0,
 		},
+		Reminder: ReminderConfig{
+			Enabled:     getEnvBool("REMINDER_ENABLED", true),
+			Notifier:    getEnv("REMINDER_NOTIFIER", "log"),
+			Interval:    getEnvDuration("REMINDER_INTERVAL", 30*time.Second),
+			LockTTL:     getEnvDuration("REMINDER_LOCK_TTL", 5*time.Minute),
+			BatchSize:   getEnvInt("REMINDER_BATCH_SIZE", 100),
+			MaxAttempts: getEnvInt("REMINDER_MAX_ATTEMPTS", 5),
+			Backoff:     getEnvDuration("REMINDER_BACKOFF", time.Second),
+		},
+		Trash: TrashConfig{
+			PurgeEnabled:  getEnvBool("TRASH_PURGE_ENABLED", true),
+			Retention:     getEnvDuration("TRASH_RETENTION", 30*24*time.Hour),
+			PurgeInterval: getEnvDuration("TRASH_PURGE_INTERVAL", time.Hour),
+		},
+		SMTP: SMTPConfig{
+			Host:     os.Getenv("SMTP_HOST"),
+			Port:     getEnv("SMTP_PORT", "1025"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     getEnv("SMTP_FROM", "no-reply@localhost"),
+		},
+		FileNotifier: FileNotifierConfig{
+			Path: getEnv("FILE_NOTIFIER_PATH", "storage/mail.log"),
+		},
+		Webhook: WebhookConfig{
+			URL:     os.Getenv("WEBHOOK_URL"),
+			Secret:  os.Getenv("WEBHOOK_SECRET"),
+			Timeout: getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		},
+		Storage: StorageConfig{
+			Driver: getEnv("STORAGE_DRIVER", "local"),
+			Local: LocalStorageConfig{
+				Path: getEnv("STORAGE_LOCAL_PATH", "storage"),
+				URL:  getEnv("STORAGE_LOCAL_URL", "http://localhost:8080/api/v1/blobs"),
+			},
+			S3: S3Config{
+				Endpoint:  os.Getenv("S3_ENDPOINT"),
+				Region:    getEnv("S3_REGION", "us-east-1"),
+				Bucket:    os.Getenv("S3_BUCKET"),
+				AccessKey: os.Getenv("S3_ACCESS_KEY"),
+				SecretKey: os.Getenv("S3_SECRET_KEY"),
+				PathStyle: getEnvBool("S3_PATH_STYLE", true),
+			},
+		},
+		Upload: UploadConfig{
+			MaxSize:      int64(getEnvInt("UPLOAD_MAX_SIZE", 10<<20)),
+			AllowedTypes: getEnvList("UPLOAD_ALLOWED_TYPES", []string{"image/png", "image/jpeg", "image/gif", "image/webp", "application/pdf", "text/plain"}),
+			URLExpiry:    getEnvDuration("UPLOAD_URL_EXPIRY", 15*time.Minute),
+		},
 	}
 
 	// Fallback to APP_KEY if JWT_SECRET is not set
@@ -70,3 +224,53 @@ func LoadConfig() *Config {
 
 	return GetConfig()
 }
+
+func getEnv(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	return fallback
+}
+
+func getEnvList(key string, fallback []string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	if len(values) == 0 {
+		return fallback
+	}
+
+	return values
+}
+
+func getEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
@@ -1,6 +1,9 @@
 package configs
 
-import "github.com/go-playground/validator/v10"
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/sherwin-77/golang-todos/pkg/patch"
+)
 
 type AppValidator struct {
 	validator *validator.Validate
@@ -11,7 +14,36 @@ func (av *AppValidator) Validate(i interface{}) error {
 }
 
 func NewAppValidator() *AppValidator {
+	v := validator.New()
+	v.RegisterCustomTypeFunc(patch.ValidationValue, patch.Types...)
+
 	return &AppValidator{
-		validator: validator.New(),
+		validator: v,
+	}
+}
+
+// ValidationMessage describes a failed validation rule in words.
+func ValidationMessage(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return fieldErr.Field() + " is required"
+	case "required_without":
+		return fieldErr.Field() + " is required when " + fieldErr.Param() + " is missing"
+	case "email":
+		return fieldErr.Field() + " is not a valid email"
+	case "gte":
+		return fieldErr.Field() + " must be greater than or equal to " + fieldErr.Param()
+	case "lte":
+		return fieldErr.Field() + " must be less than or equal to " + fieldErr.Param()
+	case "max":
+		return fieldErr.Field() + " must be at most " + fieldErr.Param() + " characters"
+	case "uuid":
+		return fieldErr.Field() + " is not a valid UUID"
+	case "oneof":
+		return fieldErr.Field() + " must be one of " + fieldErr.Param()
+	case "timezone":
+		return fieldErr.Field() + " is not a valid time zone"
+	default:
+		return fieldErr.Field() + " is not valid"
 	}
 }
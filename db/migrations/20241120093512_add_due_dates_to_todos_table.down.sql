@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS todos_user_id_due_at_index;
+
+ALTER TABLE todos
+    DROP COLUMN IF EXISTS due_at,
+    DROP COLUMN IF EXISTS remind_at;
@@ -0,0 +1,5 @@
+ALTER TABLE todos
+    ADD COLUMN due_at TIMESTAMP(6) WITH TIME ZONE,
+    ADD COLUMN remind_at TIMESTAMP(6) WITH TIME ZONE;
+
+CREATE INDEX todos_user_id_due_at_index ON todos (user_id, due_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS todos_pending_reminders_index;
+
+ALTER TABLE todos DROP COLUMN IF EXISTS reminded_at;
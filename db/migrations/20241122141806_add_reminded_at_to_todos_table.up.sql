@@ -0,0 +1,3 @@
+ALTER TABLE todos ADD COLUMN reminded_at TIMESTAMP(6) WITH TIME ZONE;
+
+CREATE INDEX todos_pending_reminders_index ON todos (remind_at) WHERE reminded_at IS NULL AND is_completed = FALSE;
@@ -0,0 +1,11 @@
+CREATE TABLE tags (
+    id UUID PRIMARY KEY NOT NULL,
+    user_id UUID NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    color VARCHAR(7) NOT NULL,
+    created_at TIMESTAMP(6) WITH TIME ZONE,
+    updated_at TIMESTAMP(6) WITH TIME ZONE,
+
+    CONSTRAINT tags_user_id_name_unique UNIQUE (user_id, name),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
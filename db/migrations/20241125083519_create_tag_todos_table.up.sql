@@ -0,0 +1,10 @@
+CREATE TABLE tag_todos (
+    tag_id UUID NOT NULL,
+    todo_id UUID NOT NULL,
+
+    PRIMARY KEY (tag_id, todo_id),
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE,
+    FOREIGN KEY (todo_id) REFERENCES todos(id) ON DELETE CASCADE
+);
+
+CREATE INDEX tag_todos_todo_id_index ON tag_todos (todo_id);
@@ -0,0 +1,13 @@
+CREATE TABLE projects (
+    id UUID PRIMARY KEY NOT NULL,
+    user_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description VARCHAR(255),
+    archived_at TIMESTAMP(6) WITH TIME ZONE,
+    created_at TIMESTAMP(6) WITH TIME ZONE,
+    updated_at TIMESTAMP(6) WITH TIME ZONE,
+
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE INDEX projects_user_id_index ON projects (user_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS todos_project_id_index;
+
+ALTER TABLE todos DROP COLUMN IF EXISTS project_id;
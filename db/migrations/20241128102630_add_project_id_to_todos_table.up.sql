@@ -0,0 +1,3 @@
+ALTER TABLE todos ADD COLUMN project_id UUID REFERENCES projects(id) ON DELETE SET NULL;
+
+CREATE INDEX todos_project_id_index ON todos (project_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS todos_parent_id_position_index;
+
+ALTER TABLE todos DROP COLUMN IF EXISTS position;
+ALTER TABLE todos DROP COLUMN IF EXISTS parent_id;
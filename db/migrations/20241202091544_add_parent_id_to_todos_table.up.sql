@@ -0,0 +1,4 @@
+ALTER TABLE todos ADD COLUMN parent_id UUID REFERENCES todos(id) ON DELETE CASCADE;
+ALTER TABLE todos ADD COLUMN position INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX todos_parent_id_position_index ON todos (parent_id, position);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS todos_recurrence_id_occurrence_index;
+
+ALTER TABLE todos DROP COLUMN IF EXISTS occurrence;
+ALTER TABLE todos DROP COLUMN IF EXISTS recurrence_id;
+ALTER TABLE todos DROP COLUMN IF EXISTS recurrence;
@@ -0,0 +1,5 @@
+ALTER TABLE todos ADD COLUMN recurrence VARCHAR(255);
+ALTER TABLE todos ADD COLUMN recurrence_id UUID;
+ALTER TABLE todos ADD COLUMN occurrence INTEGER NOT NULL DEFAULT 1;
+
+CREATE UNIQUE INDEX todos_recurrence_id_occurrence_index ON todos (recurrence_id, occurrence);
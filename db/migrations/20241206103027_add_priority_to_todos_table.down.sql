@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS todos_user_id_position_index;
+
+ALTER TABLE todos ALTER COLUMN position TYPE INTEGER USING ROUND(position)::INTEGER;
+ALTER TABLE todos DROP COLUMN IF EXISTS priority;
@@ -0,0 +1,13 @@
+ALTER TABLE todos ADD COLUMN priority SMALLINT NOT NULL DEFAULT 0;
+ALTER TABLE todos ALTER COLUMN position TYPE DOUBLE PRECISION;
+
+-- Spread existing top-level todos by creation order so they can be reordered.
+UPDATE todos SET position = ranked.row_number
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY created_at, id) AS row_number
+    FROM todos
+    WHERE parent_id IS NULL
+) ranked
+WHERE todos.id = ranked.id;
+
+CREATE INDEX todos_user_id_position_index ON todos (user_id, position);
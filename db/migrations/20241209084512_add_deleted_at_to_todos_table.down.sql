@@ -0,0 +1,8 @@
+DELETE FROM todos WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS todos_recurrence_id_occurrence_index;
+CREATE UNIQUE INDEX todos_recurrence_id_occurrence_index ON todos (recurrence_id, occurrence);
+
+DROP INDEX IF EXISTS todos_deleted_at_index;
+
+ALTER TABLE todos DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,7 @@
+ALTER TABLE todos ADD COLUMN deleted_at TIMESTAMP(6) WITH TIME ZONE;
+
+CREATE INDEX todos_deleted_at_index ON todos (deleted_at);
+
+-- Trashed occurrences must not block the next occurrence of a series.
+DROP INDEX IF EXISTS todos_recurrence_id_occurrence_index;
+CREATE UNIQUE INDEX todos_recurrence_id_occurrence_index ON todos (recurrence_id, occurrence) WHERE deleted_at IS NULL;
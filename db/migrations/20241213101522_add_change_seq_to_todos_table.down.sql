@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS todos_user_id_change_seq_index;
+
+DROP TRIGGER IF EXISTS todos_change_seq_trigger ON todos;
+DROP FUNCTION IF EXISTS todos_bump_change_seq();
+
+ALTER TABLE todos DROP COLUMN IF EXISTS created_seq;
+ALTER TABLE todos DROP COLUMN IF EXISTS change_seq;
+
+DROP SEQUENCE IF EXISTS todos_change_seq;
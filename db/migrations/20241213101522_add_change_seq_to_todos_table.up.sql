@@ -0,0 +1,20 @@
+CREATE SEQUENCE todos_change_seq;
+
+ALTER TABLE todos ADD COLUMN change_seq BIGINT NOT NULL DEFAULT nextval('todos_change_seq');
+ALTER TABLE todos ADD COLUMN created_seq BIGINT NOT NULL DEFAULT 0;
+UPDATE todos SET created_seq = change_seq;
+
+-- Every write, bulk updates and soft deletes included, moves the todo to the head of the change feed.
+CREATE FUNCTION todos_bump_change_seq() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.change_seq := nextval('todos_change_seq');
+    IF TG_OP = 'INSERT' THEN
+        NEW.created_seq := NEW.change_seq;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER todos_change_seq_trigger BEFORE INSERT OR UPDATE ON todos FOR EACH ROW EXECUTE FUNCTION todos_bump_change_seq();
+
+CREATE INDEX todos_user_id_change_seq_index ON todos (user_id, change_seq);
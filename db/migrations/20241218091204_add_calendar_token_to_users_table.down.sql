@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS users_calendar_token_hash_index;
+
+ALTER TABLE users DROP COLUMN IF EXISTS calendar_token_hash;
@@ -0,0 +1,16 @@
+CREATE TABLE project_members (
+    project_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    role VARCHAR(10) NOT NULL,
+    invited_by UUID NOT NULL,
+    accepted_at TIMESTAMP(6) WITH TIME ZONE,
+    created_at TIMESTAMP(6) WITH TIME ZONE,
+    updated_at TIMESTAMP(6) WITH TIME ZONE,
+
+    PRIMARY KEY (project_id, user_id),
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (invited_by) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX project_members_user_id_index ON project_members (user_id);
@@ -0,0 +1,3 @@
+ALTER TABLE todos ADD COLUMN assignee_id UUID REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX todos_assignee_id_index ON todos (assignee_id);
@@ -0,0 +1,17 @@
+CREATE TABLE notifications (
+    id UUID PRIMARY KEY NOT NULL,
+    user_id UUID NOT NULL,
+    actor_id UUID,
+    todo_id UUID,
+    type VARCHAR(50) NOT NULL,
+    message TEXT NOT NULL,
+    read_at TIMESTAMP(6) WITH TIME ZONE,
+    created_at TIMESTAMP(6) WITH TIME ZONE,
+    updated_at TIMESTAMP(6) WITH TIME ZONE,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL,
+    FOREIGN KEY (todo_id) REFERENCES todos(id) ON DELETE CASCADE
+);
+
+CREATE INDEX notifications_user_id_index ON notifications (user_id, created_at);
@@ -0,0 +1,18 @@
+CREATE TABLE attachments (
+    id UUID PRIMARY KEY NOT NULL,
+    todo_id UUID,
+    user_id UUID,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    storage_key VARCHAR(255) NOT NULL UNIQUE,
+    created_at TIMESTAMP(6) WITH TIME ZONE,
+    updated_at TIMESTAMP(6) WITH TIME ZONE,
+
+    -- Purging a todo leaves its attachments behind with no todo, so the purge
+    -- job can remove their blobs before deleting them.
+    FOREIGN KEY (todo_id) REFERENCES todos(id) ON DELETE SET NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX attachments_todo_id_index ON attachments (todo_id);
@@ -0,0 +1,4 @@
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP(6) WITH TIME ZONE;
+
+-- Users registered before verification existed keep their access.
+UPDATE users SET email_verified_at = created_at;
@@ -0,0 +1,11 @@
+CREATE TABLE recovery_codes (
+    id UUID PRIMARY KEY NOT NULL,
+    user_id UUID NOT NULL,
+    code_hash VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP(6) WITH TIME ZONE,
+    updated_at TIMESTAMP(6) WITH TIME ZONE,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX recovery_codes_user_id_code_hash_index ON recovery_codes (user_id, code_hash);
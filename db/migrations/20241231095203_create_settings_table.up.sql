@@ -0,0 +1,6 @@
+CREATE TABLE settings (
+    key VARCHAR(100) PRIMARY KEY NOT NULL,
+    value TEXT NOT NULL,
+    created_at TIMESTAMP(6) WITH TIME ZONE,
+    updated_at TIMESTAMP(6) WITH TIME ZONE
+);
@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS todos_user_id_change_xid_change_seq_index;
+CREATE INDEX todos_user_id_change_seq_index ON todos (user_id, change_seq);
+
+CREATE OR REPLACE FUNCTION todos_bump_change_seq() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.change_seq := nextval('todos_change_seq');
+    IF TG_OP = 'INSERT' THEN
+        NEW.created_seq := NEW.change_seq;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE todos DROP COLUMN IF EXISTS created_xid;
+ALTER TABLE todos DROP COLUMN IF EXISTS change_xid;
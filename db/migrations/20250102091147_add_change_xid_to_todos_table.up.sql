@@ -0,0 +1,21 @@
+ALTER TABLE todos ADD COLUMN change_xid BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE todos ADD COLUMN created_xid BIGINT NOT NULL DEFAULT 0;
+
+-- change_seq is drawn when a row is written, not when its transaction commits, so a
+-- higher sequence can become visible before a lower one. Recording the writing
+-- transaction lets readers order the feed by it and hold back transactions that
+-- may still be in flight.
+CREATE OR REPLACE FUNCTION todos_bump_change_seq() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.change_seq := nextval('todos_change_seq');
+    NEW.change_xid := pg_current_xact_id()::text::bigint;
+    IF TG_OP = 'INSERT' THEN
+        NEW.created_seq := NEW.change_seq;
+        NEW.created_xid := NEW.change_xid;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS todos_user_id_change_seq_index;
+CREATE INDEX todos_user_id_change_xid_change_seq_index ON todos (user_id, change_xid, change_seq);
@@ -0,0 +1,4 @@
+-- Existing users keep the empty version their outstanding tokens carry until
+-- their sessions are next revoked. New users start with a random one.
+ALTER TABLE users ADD COLUMN token_version VARCHAR(36) NOT NULL DEFAULT '';
+ALTER TABLE users ALTER COLUMN token_version SET DEFAULT gen_random_uuid()::text;
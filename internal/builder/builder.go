@@ -9,30 +9,58 @@ import (
 	"github.com/sherwin-77/golang-todos/internal/repository"
 	"github.com/sherwin-77/golang-todos/internal/service"
 	"github.com/sherwin-77/golang-todos/pkg/caches"
+	"github.com/sherwin-77/golang-todos/pkg/notifier"
+	"github.com/sherwin-77/golang-todos/pkg/storage"
 	"github.com/sherwin-77/golang-todos/pkg/tokens"
 	"gorm.io/gorm"
 )
 
-func BuildV1Routes(config *configs.Config, db *gorm.DB, cache caches.Cache, group *echo.Group) {
+func BuildV1Routes(config *configs.Config, db *gorm.DB, cache caches.Cache, blobStore storage.BlobStore, mailer notifier.Notifier, group *echo.Group) {
 	g := group.Group("/v1")
 
 	// Initialize middlewares
 	middleware := middlewares.NewMiddleware()
-	authMiddleware := middlewares.NewAuthMiddleware(config, db)
+	denylist := tokens.NewDenylist(cache)
+	authMiddleware := middlewares.NewAuthMiddleware(config, db, denylist)
 
 	// Initialize repositories
 	userRepository := repository.NewUserRepository(db)
 	roleRepository := repository.NewRoleRepository(db)
 	todoRepository := repository.NewTodoRepository(db)
+	tagRepository := repository.NewTagRepository(db)
+	projectRepository := repository.NewProjectRepository(db)
+	notificationRepository := repository.NewNotificationRepository(db)
+	commentRepository := repository.NewCommentRepository(db)
+	attachmentRepository := repository.NewAttachmentRepository(db)
+	refreshTokenRepository := repository.NewRefreshTokenRepository(db)
+	userTokenRepository := repository.NewUserTokenRepository(db)
+	recoveryCodeRepository := repository.NewRecoveryCodeRepository(db)
+	settingRepository := repository.NewSettingRepository(db)
 
 	// Initialize services
 	tokenService := tokens.NewTokenService(config.JWTSecret)
-	userService := service.NewUserService(tokenService, userRepository, roleRepository, cache)
-	todoService := service.NewTodoService(todoRepository, userRepository, cache)
+	userService := service.NewUserService(tokenService, denylist, userRepository, roleRepository, refreshTokenRepository, userTokenRepository, recoveryCodeRepository, settingRepository, cache, mailer, config.Auth)
+	mfaService := service.NewMFAService(userRepository, recoveryCodeRepository, settingRepository, cache, config.Auth)
+	passwordService := service.NewPasswordService(userRepository, userTokenRepository, refreshTokenRepository, cache, mailer, config.Auth)
+	verificationService := service.NewVerificationService(userRepository, roleRepository, userTokenRepository, cache, mailer, config.Auth)
+	todoService := service.NewTodoService(todoRepository, userRepository, tagRepository, projectRepository, notificationRepository, configs.NewAppValidator(), cache)
+	tagService := service.NewTagService(tagRepository, todoRepository, projectRepository, cache)
+	projectService := service.NewProjectService(projectRepository, userRepository, cache)
+	notificationService := service.NewNotificationService(notificationRepository)
+	commentService := service.NewCommentService(commentRepository, todoRepository, userRepository, projectRepository, notificationRepository)
+	attachmentService := service.NewAttachmentService(attachmentRepository, todoRepository, projectRepository, blobStore, config.Upload)
 
 	// Initialize handlers
 	userHandler := handler.NewUserHandler(userService)
+	mfaHandler := handler.NewMFAHandler(mfaService)
+	passwordHandler := handler.NewPasswordHandler(passwordService)
+	verificationHandler := handler.NewVerificationHandler(verificationService)
 	todoHandler := handler.NewTodoHandler(todoService)
+	tagHandler := handler.NewTagHandler(tagService)
+	projectHandler := handler.NewProjectHandler(projectService, todoService)
+	notificationHandler := handler.NewNotificationHandler(notificationService)
+	commentHandler := handler.NewCommentHandler(commentService)
+	attachmentHandler := handler.NewAttachmentHandler(attachmentService)
 
 	// Register routes
 	userRoutes, userMiddlewares := router.UserRoutes(*userHandler, *middleware, *authMiddleware)
@@ -41,12 +69,75 @@ func BuildV1Routes(config *configs.Config, db *gorm.DB, cache caches.Cache, grou
 		g.Add(route.Method, route.Path, route.Handler, m...)
 	}
 
+	mfaRoutes, mfaMiddlewares := router.MFARoutes(*mfaHandler, *middleware, *authMiddleware)
+	for _, route := range mfaRoutes {
+		m := append(mfaMiddlewares, route.Middlewares...)
+		g.Add(route.Method, route.Path, route.Handler, m...)
+	}
+
+	passwordRoutes, passwordMiddlewares := router.PasswordRoutes(*passwordHandler, *middleware, *authMiddleware)
+	for _, route := range passwordRoutes {
+		m := append(passwordMiddlewares, route.Middlewares...)
+		g.Add(route.Method, route.Path, route.Handler, m...)
+	}
+
+	verificationRoutes, verificationMiddlewares := router.VerificationRoutes(*verificationHandler, *middleware, *authMiddleware)
+	for _, route := range verificationRoutes {
+		m := append(verificationMiddlewares, route.Middlewares...)
+		g.Add(route.Method, route.Path, route.Handler, m...)
+	}
+
 	todoRoutes, todoMiddlewares := router.TodoRoutes(*todoHandler, *middleware, *authMiddleware)
 	for _, route := range todoRoutes {
 		m := append(todoMiddlewares, route.Middlewares...)
 		g.Add(route.Method, route.Path, route.Handler, m...)
 	}
 
+	calendarRoutes, calendarMiddlewares := router.CalendarRoutes(*todoHandler, *middleware, *authMiddleware)
+	for _, route := range calendarRoutes {
+		m := append(calendarMiddlewares, route.Middlewares...)
+		g.Add(route.Method, route.Path, route.Handler, m...)
+	}
+
+	tagRoutes, tagMiddlewares := router.TagRoutes(*tagHandler, *middleware, *authMiddleware)
+	for _, route := range tagRoutes {
+		m := append(tagMiddlewares, route.Middlewares...)
+		g.Add(route.Method, route.Path, route.Handler, m...)
+	}
+
+	projectRoutes, projectMiddlewares := router.ProjectRoutes(*projectHandler, *middleware, *authMiddleware)
+	for _, route := range projectRoutes {
+		m := append(projectMiddlewares, route.Middlewares...)
+		g.Add(route.Method, route.Path, route.Handler, m...)
+	}
+
+	commentRoutes, commentMiddlewares := router.CommentRoutes(*commentHandler, *middleware, *authMiddleware)
+	for _, route := range commentRoutes {
+		m := append(commentMiddlewares, route.Middlewares...)
+		g.Add(route.Method, route.Path, route.Handler, m...)
+	}
+
+	attachmentRoutes, attachmentMiddlewares := router.AttachmentRoutes(*attachmentHandler, *middleware, *authMiddleware, config.Upload)
+	for _, route := range attachmentRoutes {
+		m := append(attachmentMiddlewares, route.Middlewares...)
+		g.Add(route.Method, route.Path, route.Handler, m...)
+	}
+
+	// Only the local store needs the application to serve its signed URLs.
+	if localStore, ok := blobStore.(*storage.LocalStore); ok {
+		blobRoutes, blobMiddlewares := router.BlobRoutes(*handler.NewBlobHandler(localStore))
+		for _, route := range blobRoutes {
+			m := append(blobMiddlewares, route.Middlewares...)
+			g.Add(route.Method, route.Path, route.Handler, m...)
+		}
+	}
+
+	notificationRoutes, notificationMiddlewares := router.NotificationRoutes(*notificationHandler, *middleware, *authMiddleware)
+	for _, route := range notificationRoutes {
+		m := append(notificationMiddlewares, route.Middlewares...)
+		g.Add(route.Method, route.Path, route.Handler, m...)
+	}
+
 	adminGroup := g.Group("/admin")
 
 	adminUserRoutes, adminMiddlewares := router.AdminUserRoutes(*userHandler, *middleware, *authMiddleware)
@@ -54,4 +145,10 @@ func BuildV1Routes(config *configs.Config, db *gorm.DB, cache caches.Cache, grou
 		m := append(adminMiddlewares, route.Middlewares...)
 		adminGroup.Add(route.Method, route.Path, route.Handler, m...)
 	}
+
+	adminMFARoutes, adminMFAMiddlewares := router.AdminMFARoutes(*mfaHandler, *middleware, *authMiddleware)
+	for _, route := range adminMFARoutes {
+		m := append(adminMFAMiddlewares, route.Middlewares...)
+		adminGroup.Add(route.Method, route.Path, route.Handler, m...)
+	}
 }
@@ -0,0 +1,35 @@
+package builder
+
+import (
+	"github.com/redis/go-redis/v9"
+	"github.com/sherwin-77/golang-todos/configs"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"github.com/sherwin-77/golang-todos/internal/worker"
+	"github.com/sherwin-77/golang-todos/pkg/caches"
+	"github.com/sherwin-77/golang-todos/pkg/notifier"
+	"github.com/sherwin-77/golang-todos/pkg/storage"
+	"gorm.io/gorm"
+)
+
+func BuildScheduler(config *configs.Config, db *gorm.DB, redisClient *redis.Client, blobStore storage.BlobStore) (*worker.Scheduler, error) {
+	var jobs []worker.Job
+
+	// Initialize repositories
+	todoRepository := repository.NewTodoRepository(db)
+	attachmentRepository := repository.NewAttachmentRepository(db)
+
+	if config.Reminder.Enabled {
+		reminderNotifier, err := notifier.NewNotifier(config.Reminder.Notifier, config)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, worker.NewReminderJob(config.Reminder, todoRepository, caches.NewLocker(redisClient), reminderNotifier))
+	}
+
+	if config.Trash.PurgeEnabled {
+		jobs = append(jobs, worker.NewPurgeJob(config.Trash, todoRepository, attachmentRepository, blobStore))
+	}
+
+	return worker.NewScheduler(jobs...), nil
+}
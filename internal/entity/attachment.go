@@ -0,0 +1,16 @@
+package entity
+
+import "github.com/google/uuid"
+
+// Attachment is a file attached to a todo. The file itself lives in blob
+// storage under StorageKey. TodoID is cleared when the todo is purged, which
+// marks the attachment as an orphan to be cleaned up.
+type Attachment struct {
+	BaseEntity
+	TodoID      *uuid.UUID `json:"todo_id" gorm:"type:uuid"`
+	UserID      *uuid.UUID `json:"user_id" gorm:"type:uuid"`
+	FileName    string     `json:"file_name" gorm:"type:varchar(255);not null"`
+	ContentType string     `json:"content_type" gorm:"type:varchar(255);not null"`
+	Size        int64      `json:"size" gorm:"not null"`
+	StorageKey  string     `json:"-" gorm:"type:varchar(255);not null;uniqueIndex"`
+}
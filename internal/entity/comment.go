@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Comment is a message in the discussion thread of a todo. EditedAt is only
+// set once the author changes the body.
+type Comment struct {
+	BaseEntity
+	TodoID   uuid.UUID    `json:"todo_id" gorm:"type:uuid;not null"`
+	UserID   uuid.UUID    `json:"user_id" gorm:"type:uuid;not null"`
+	Body     string       `json:"body" gorm:"type:text;not null"`
+	EditedAt *time.Time   `json:"edited_at" gorm:"type:timestamp(6) with time zone"`
+	Author   *UserProfile `json:"author,omitempty" gorm:"foreignKey:UserID"`
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	NotificationTodoAssigned   = "todo_assigned"
+	NotificationTodoUnassigned = "todo_unassigned"
+	NotificationCommentMention = "comment_mention"
+)
+
+// Notification tells a user about something another user did, such as
+// assigning them a todo. It goes away with its todo, and ActorID is cleared
+// when the actor is deleted.
+type Notification struct {
+	BaseEntity
+	UserID  uuid.UUID  `json:"user_id" gorm:"type:uuid;not null"`
+	ActorID *uuid.UUID `json:"actor_id" gorm:"type:uuid"`
+	TodoID  *uuid.UUID `json:"todo_id" gorm:"type:uuid"`
+	Type    string     `json:"type" gorm:"type:varchar(50);not null"`
+	Message string     `json:"message" gorm:"type:text;not null"`
+	ReadAt  *time.Time `json:"read_at" gorm:"type:timestamp(6) with time zone"`
+}
@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Priority is stored as a small integer so that sorting by it follows urgency,
+// and is exposed by name in JSON.
+type Priority int16
+
+const (
+	PriorityNone Priority = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+	PriorityUrgent
+)
+
+var priorityNames = []string{"none", "low", "medium", "high", "urgent"}
+
+func ParsePriority(name string) (Priority, error) {
+	if name == "" {
+		return PriorityNone, nil
+	}
+
+	for i, priorityName := range priorityNames {
+		if priorityName == name {
+			return Priority(i), nil
+		}
+	}
+
+	return PriorityNone, fmt.Errorf("unknown priority %q", name)
+}
+
+func (p Priority) String() string {
+	if p < 0 || int(p) >= len(priorityNames) {
+		return priorityNames[PriorityNone]
+	}
+
+	return priorityNames[p]
+}
+
+func (p Priority) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+func (p *Priority) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+
+	priority, err := ParsePriority(name)
+	if err != nil {
+		return err
+	}
+
+	*p = priority
+	return nil
+}
@@ -0,0 +1,15 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Project struct {
+	BaseEntity
+	Name        string     `json:"name" gorm:"type:varchar(255);not null"`
+	Description string     `json:"description" gorm:"type:varchar(255);"`
+	ArchivedAt  *time.Time `json:"archived_at" gorm:"type:timestamp(6) with time zone"`
+	UserID      uuid.UUID  `json:"user_id" gorm:"type:uuid;not null"`
+}
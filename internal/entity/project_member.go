@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProjectRole is what a member may do in a shared project. Each role includes
+// the ones before it: viewers read, editors also change todos, and owners also
+// manage members.
+type ProjectRole string
+
+const (
+	ProjectViewer ProjectRole = "viewer"
+	ProjectEditor ProjectRole = "editor"
+	ProjectOwner  ProjectRole = "owner"
+)
+
+var projectRoleRanks = map[ProjectRole]int{
+	ProjectViewer: 1,
+	ProjectEditor: 2,
+	ProjectOwner:  3,
+}
+
+// Allows reports whether r grants the required role. The empty role grants nothing.
+func (r ProjectRole) Allows(required ProjectRole) bool {
+	return projectRoleRanks[r] > 0 && projectRoleRanks[r] >= projectRoleRanks[required]
+}
+
+// ProjectMember shares a project with a user other than its creator. The
+// membership takes effect once the invited user accepts it.
+type ProjectMember struct {
+	ProjectID  uuid.UUID   `json:"project_id" gorm:"type:uuid;primaryKey"`
+	UserID     uuid.UUID   `json:"user_id" gorm:"type:uuid;primaryKey"`
+	Role       ProjectRole `json:"role" gorm:"type:varchar(10);not null"`
+	InvitedBy  uuid.UUID   `json:"invited_by" gorm:"type:uuid;not null"`
+	AcceptedAt *time.Time  `json:"accepted_at" gorm:"type:timestamp(6) with time zone"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+
+	User    *User    `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	Project *Project `json:"project,omitempty" gorm:"foreignKey:ProjectID"`
+}
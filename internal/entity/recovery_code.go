@@ -0,0 +1,11 @@
+package entity
+
+import "github.com/google/uuid"
+
+// RecoveryCode lets a user with two-factor authentication log in without
+// their authenticator. Only its hash is stored, and it is deleted once used.
+type RecoveryCode struct {
+	BaseEntity
+	UserID   uuid.UUID `json:"user_id" gorm:"type:uuid;not null"`
+	CodeHash string    `json:"-" gorm:"type:varchar(64);not null"`
+}
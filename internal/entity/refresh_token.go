@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken is an opaque token that can be exchanged once for a new access
+// and refresh token. Tokens issued from the same login share a FamilyID, so
+// replaying a used token can revoke every token of that login.
+type RefreshToken struct {
+	BaseEntity
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid;not null"`
+	FamilyID  uuid.UUID  `json:"family_id" gorm:"type:uuid;not null"`
+	TokenHash string     `json:"-" gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"type:timestamp(6) with time zone;not null"`
+	UsedAt    *time.Time `json:"used_at" gorm:"type:timestamp(6) with time zone"`
+	RevokedAt *time.Time `json:"revoked_at" gorm:"type:timestamp(6) with time zone"`
+}
@@ -2,6 +2,7 @@ package entity
 
 type Role struct {
 	BaseEntity
+	Versioned
 	Name      string `json:"name" gorm:"type:varchar(255);not null"`
 	AuthLevel int    `json:"auth_level" gorm:"type:integer;not null"`
 }
@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// SettingMFARequiredLevel is the lowest auth level whose users must use
+// two-factor authentication. Zero, or no setting, requires it of no one.
+const SettingMFARequiredLevel = "mfa_required_level"
+
+// Setting is an application setting that admins can change at runtime.
+type Setting struct {
+	Key       string    `json:"key" gorm:"type:varchar(100);primaryKey"`
+	Value     string    `json:"value" gorm:"type:text;not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
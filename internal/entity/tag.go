@@ -0,0 +1,10 @@
+package entity
+
+import "github.com/google/uuid"
+
+type Tag struct {
+	BaseEntity
+	Name   string    `json:"name" gorm:"type:varchar(50);not null"`
+	Color  string    `json:"color" gorm:"type:varchar(7);not null"`
+	UserID uuid.UUID `json:"user_id" gorm:"type:uuid;not null"`
+}
@@ -1,13 +1,55 @@
 package entity
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
 
 type Todo struct {
 	BaseEntity
-	Title       string    `json:"title" gorm:"type:varchar(255);not null"`
-	Description string    `json:"description" gorm:"type:varchar(255);"`
-	IsCompleted bool      `json:"is_completed" gorm:"type:bool;not null;default:false"`
-	UserID      uuid.UUID `json:"user_id" gorm:"type:uuid;not null"`
+	Versioned
+	Title        string         `json:"title" gorm:"type:varchar(255);not null"`
+	Description  string         `json:"description" gorm:"type:varchar(255);"`
+	IsCompleted  bool           `json:"is_completed" gorm:"type:bool;not null;default:false"`
+	DueAt        *time.Time     `json:"due_at" gorm:"type:timestamp(6) with time zone"`
+	RemindAt     *time.Time     `json:"remind_at" gorm:"type:timestamp(6) with time zone"`
+	RemindedAt   *time.Time     `json:"reminded_at" gorm:"type:timestamp(6) with time zone"`
+	ProjectID    *uuid.UUID     `json:"project_id" gorm:"type:uuid"`
+	ParentID     *uuid.UUID     `json:"parent_id" gorm:"type:uuid"`
+	Priority     Priority       `json:"priority" gorm:"type:smallint;not null;default:0"`
+	Position     float64        `json:"position" gorm:"not null;default:0"`
+	Recurrence   string         `json:"recurrence" gorm:"type:varchar(255)"`
+	RecurrenceID *uuid.UUID     `json:"recurrence_id" gorm:"type:uuid"`
+	Occurrence   int            `json:"occurrence" gorm:"not null;default:1"`
+	UserID       uuid.UUID      `json:"user_id" gorm:"type:uuid;not null"`
+	AssigneeID   *uuid.UUID     `json:"assignee_id" gorm:"type:uuid"`
+	DeletedAt    gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+	ChangeSeq    int64          `json:"-" gorm:"->"`
+	CreatedSeq   int64          `json:"-" gorm:"->"`
+	ChangeXID    int64          `json:"-" gorm:"->;column:change_xid"`
+	CreatedXID   int64          `json:"-" gorm:"->;column:created_xid"`
+
+	User     *User         `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	Assignee *UserProfile  `json:"assignee,omitempty" gorm:"foreignKey:AssigneeID"`
+	Tags     []*Tag        `json:"tags,omitempty" gorm:"many2many:tag_todos;"`
+	Progress *TodoProgress `json:"progress,omitempty" gorm:"-"`
+	Match    *TodoMatch    `json:"match,omitempty" gorm:"-"`
+}
+
+// TodoProgress summarizes the subtasks of a todo, e.g. 3 of 5 completed.
+type TodoProgress struct {
+	Completed int64 `json:"completed"`
+	Total     int64 `json:"total"`
+}
 
-	User *User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+// TodoMatch is how a todo matched a full-text search. Title and Description are
+// HTML snippets: the todo text is escaped and the matched words are wrapped in
+// <mark> tags.
+type TodoMatch struct {
+	TodoID      uuid.UUID `json:"-"`
+	Rank        float64   `json:"rank"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
 }
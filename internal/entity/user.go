@@ -1,10 +1,56 @@
 package entity
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
 type User struct {
 	BaseEntity
+	Versioned
 	Username string `json:"username" gorm:"type:varchar(255);not null"`
 	Email    string `json:"email" gorm:"type:varchar(255);not null;uniqueIndex"`
 	Password string `json:"-"`
+	Timezone string `json:"timezone" gorm:"type:varchar(64);not null;default:UTC"`
+
+	// EmailVerifiedAt is cleared whenever the email changes.
+	EmailVerifiedAt *time.Time `json:"email_verified_at" gorm:"type:timestamp(6) with time zone"`
+
+	// CalendarTokenHash is the SHA-256 of the secret in the user's calendar
+	// feed URL. The secret itself is only shown when it is created.
+	CalendarTokenHash *string `json:"-" gorm:"type:varchar(64);uniqueIndex"`
+
+	// TOTPSecret is set on enrollment, but two-factor authentication is only
+	// in force once TOTPEnabledAt is set by confirming a code. TOTPLastCounter
+	// is the period of the last accepted code, which cannot be used again.
+	TOTPSecret      *string    `json:"-" gorm:"type:varchar(64)"`
+	TOTPEnabledAt   *time.Time `json:"totp_enabled_at" gorm:"type:timestamp(6) with time zone"`
+	TOTPLastCounter int64      `json:"-" gorm:"type:bigint;not null;default:0"`
+
+	// TokenVersion is carried by every token issued to the user. Changing it
+	// revokes them all. It is only written on create so saving a stale user
+	// cannot bring back a revoked version.
+	TokenVersion string `json:"-" gorm:"type:varchar(36);not null;<-:create"`
 
 	Roles []*Role `json:"roles,omitempty" gorm:"many2many:role_users;"`
 }
+
+func (u *User) BeforeCreate(tx *gorm.DB) error {
+	if u.TokenVersion == "" {
+		u.TokenVersion = uuid.NewString()
+	}
+
+	return u.BaseEntity.BeforeCreate(tx)
+}
+
+// UserProfile is the public part of a user, embedded where one user sees another.
+type UserProfile struct {
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username"`
+}
+
+func (UserProfile) TableName() string {
+	return "users"
+}
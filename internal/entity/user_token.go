@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	UserTokenPasswordReset     = "password_reset"
+	UserTokenEmailVerification = "email_verification"
+)
+
+// UserToken is a single-use token mailed to a user, such as a password reset
+// link. Only its hash is stored, and it is deleted once used.
+type UserToken struct {
+	BaseEntity
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;not null"`
+	Purpose   string    `json:"purpose" gorm:"type:varchar(50);not null"`
+	TokenHash string    `json:"-" gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"type:timestamp(6) with time zone;not null"`
+}
@@ -0,0 +1,15 @@
+package entity
+
+// Versioned adds an optimistic concurrency version to an entity. Every write
+// bumps it, so it doubles as the entity tag of the resource.
+type Versioned struct {
+	Version int `json:"version" gorm:"not null;default:1"`
+}
+
+func (v *Versioned) CurrentVersion() int {
+	return v.Version
+}
+
+func (v *Versioned) SetVersion(version int) {
+	v.Version = version
+}
@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"io"
+	"time"
+)
+
+// AttachmentUpload is a file taken from a multipart upload. ContentType is
+// sniffed from the content rather than taken from the client.
+type AttachmentUpload struct {
+	TodoID      string
+	FileName    string
+	ContentType string
+	Size        int64
+	Body        io.Reader
+}
+
+type AttachmentURLResponse struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+type CalendarTokenResponse struct {
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
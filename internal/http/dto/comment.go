@@ -0,0 +1,17 @@
+package dto
+
+type CommentQuery struct {
+	TodoID  string `param:"id" validate:"required,uuid"`
+	Page    int    `query:"page" validate:"omitempty,gte=1"`
+	PerPage int    `query:"per_page" validate:"omitempty,gte=1"`
+}
+
+type CommentRequest struct {
+	TodoID string `param:"id" validate:"required,uuid"`
+	Body   string `json:"body" validate:"required,max=5000"`
+}
+
+type UpdateCommentRequest struct {
+	CommentRequest
+	ID string `param:"comment_id" validate:"required,uuid"`
+}
@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+// MFAChallenge is returned by a login that needs a second factor. The token
+// is exchanged at /login/mfa together with a code. If EnrollmentRequired, the
+// user has to set up two-factor authentication with the token first.
+type MFAChallenge struct {
+	Token              string    `json:"mfa_token"`
+	ExpiresAt          time.Time `json:"expires_at"`
+	EnrollmentRequired bool      `json:"enrollment_required"`
+}
+
+type MFALoginRequest struct {
+	Token string `json:"mfa_token" validate:"required"`
+	Code  string `json:"code" validate:"required"`
+}
+
+// MFACodeRequest carries a TOTP code or, where accepted, a recovery code.
+type MFACodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+// MFAEnrollResponse holds the new secret and the otpauth:// URI to show as a
+// QR code. Two-factor authentication is enabled once a code is confirmed.
+type MFAEnrollResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// RecoveryCodesResponse lists recovery codes. They are only shown once.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// MFAPolicy requires two-factor authentication of every user whose auth level
+// is at least RequiredLevel. Zero requires it of no one.
+type MFAPolicy struct {
+	RequiredLevel int `json:"required_level" validate:"gte=0"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+type NotificationQuery struct {
+	Page    int  `query:"page" validate:"omitempty,gte=1"`
+	PerPage int  `query:"per_page" validate:"omitempty,gte=1"`
+	Unread  bool `query:"unread"`
+}
+
+// MarkNotificationsReadRequest marks the listed notifications as read, or all
+// of them when IDs is empty.
+type MarkNotificationsReadRequest struct {
+	IDs []string `json:"ids" validate:"max=200,dive,uuid"`
+}
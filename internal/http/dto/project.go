@@ -0,0 +1,26 @@
+package dto
+
+type ProjectRequest struct {
+	Name        string `json:"name" validate:"required,max=255"`
+	Description string `json:"description" validate:"max=255"`
+}
+
+type UpdateProjectRequest struct {
+	ProjectRequest
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+type ProjectQuery struct {
+	Archived bool `query:"archived"`
+}
+
+type DeleteProjectRequest struct {
+	ID    string `param:"id" validate:"required,uuid"`
+	Todos string `query:"todos" validate:"omitempty,oneof=inbox delete"`
+}
+
+type InviteMemberRequest struct {
+	ID    string `param:"id" validate:"required,uuid"`
+	Email string `json:"email" validate:"required,email"`
+	Role  string `json:"role" validate:"required,oneof=viewer editor owner"`
+}
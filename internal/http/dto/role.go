@@ -1,13 +1,18 @@
 package dto
 
+import "github.com/sherwin-77/golang-todos/pkg/patch"
+
 type RoleRequest struct {
 	Name      string `json:"name" validate:"required"`
 	AuthLevel int    `json:"auth_level" validate:"required"`
 }
 
+// UpdateRoleRequest is a JSON Merge Patch: omitted fields are left untouched.
 type UpdateRoleRequest struct {
-	RoleRequest
-	ID string `param:"id" validate:"required,uuid"`
+	ID        string              `param:"id" validate:"required,uuid"`
+	Name      patch.Field[string] `json:"name"`
+	AuthLevel patch.Field[int]    `json:"auth_level"`
+	Version   int                 `json:"-"`
 }
 
 type ChangeRoleRequest struct {
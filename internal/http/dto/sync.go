@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+)
+
+type TodoChangesQuery struct {
+	Since string `query:"since"`
+	Limit int    `query:"limit" validate:"omitempty,gte=1,lte=500"`
+}
+
+// TodoChanges is a page of the change feed. Cursor is passed back as since to
+// fetch the next page; HasMore tells the client to do so right away.
+type TodoChanges struct {
+	Created []entity.Todo   `json:"created"`
+	Updated []entity.Todo   `json:"updated"`
+	Deleted []TodoTombstone `json:"deleted"`
+	Cursor  string          `json:"cursor"`
+	HasMore bool            `json:"has_more"`
+}
+
+type TodoTombstone struct {
+	ID        uuid.UUID `json:"id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+const (
+	SyncOpCreate = "create"
+	SyncOpUpdate = "update"
+	SyncOpDelete = "delete"
+)
+
+const (
+	SyncStatusApplied  = "applied"
+	SyncStatusConflict = "conflict"
+	SyncStatusRejected = "rejected"
+)
+
+type SyncTodosRequest struct {
+	Mutations []SyncMutation `json:"mutations" validate:"required,min=1,max=100,dive"`
+}
+
+// SyncMutation is a change made while offline. Creates carry a client generated
+// ID so that replaying them is harmless; updates and deletes carry the version
+// the client last saw.
+type SyncMutation struct {
+	Op      string             `json:"op" validate:"required,oneof=create update delete"`
+	ID      string             `json:"id" validate:"required,uuid"`
+	Version int                `json:"version" validate:"gte=0"`
+	Todo    *TodoRequest       `json:"todo" validate:"required_if=Op create"`
+	Changes *UpdateTodoRequest `json:"changes" validate:"required_if=Op update"`
+}
+
+// SyncResult reports the outcome of one mutation. On a conflict Todo holds the
+// server copy so the client can merge.
+type SyncResult struct {
+	Index   int          `json:"index"`
+	ID      string       `json:"id"`
+	Status  string       `json:"status"`
+	Code    int          `json:"code,omitempty"`
+	Message string       `json:"message,omitempty"`
+	Todo    *entity.Todo `json:"todo,omitempty"`
+}
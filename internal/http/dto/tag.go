@@ -0,0 +1,22 @@
+package dto
+
+type TagRequest struct {
+	Name  string `json:"name" validate:"required,max=50"`
+	Color string `json:"color" validate:"omitempty,hexcolor,len=7"`
+}
+
+type UpdateTagRequest struct {
+	TagRequest
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+type ChangeTagRequest struct {
+	TodoID  string                 `param:"id" validate:"required,uuid"`
+	Items   []ChangeTagRequestItem `json:"items" validate:"required,dive"`
+	Version int                    `json:"-"`
+}
+
+type ChangeTagRequestItem struct {
+	ID     string `json:"id" validate:"required,uuid"`
+	Action string `json:"action" validate:"required,oneof=add remove"`
+}
@@ -1,14 +1,136 @@
 package dto
 
+import (
+	"time"
+
+	"github.com/sherwin-77/golang-todos/pkg/patch"
+)
+
 type TodoRequest struct {
-	Title       string `json:"title" validate:"required"`
-	Description string `json:"description"`
-	IsCompleted bool   `json:"is_completed"`
+	ID          string     `json:"-"`
+	Title       string     `json:"title" validate:"required"`
+	Description string     `json:"description"`
+	IsCompleted bool       `json:"is_completed"`
+	DueAt       *time.Time `json:"due_at"`
+	RemindAt    *time.Time `json:"remind_at"`
+	ProjectID   string     `json:"project_id" validate:"omitempty,uuid"`
+	Recurrence  string     `json:"recurrence" validate:"max=255"`
+	Priority    string     `json:"priority" validate:"omitempty,oneof=none low medium high urgent"`
 }
 
+// UpdateTodoRequest is a JSON Merge Patch: omitted fields are left untouched and
+// null clears them.
 type UpdateTodoRequest struct {
-	ID          string `param:"id" validate:"required,uuid"`
-	Title       string `json:"title"`
+	ID               string                 `param:"id" validate:"required,uuid"`
+	Title            patch.Field[string]    `json:"title"`
+	Description      patch.Field[string]    `json:"description"`
+	IsCompleted      patch.Field[bool]      `json:"is_completed"`
+	DueAt            patch.Field[time.Time] `json:"due_at"`
+	RemindAt         patch.Field[time.Time] `json:"remind_at"`
+	ProjectID        patch.Field[string]    `json:"project_id" validate:"omitempty,uuid"`
+	Recurrence       patch.Field[string]    `json:"recurrence" validate:"omitempty,max=255"`
+	Priority         patch.Field[string]    `json:"priority" validate:"omitempty,oneof=none low medium high urgent"`
+	CompleteSubtasks bool                   `json:"complete_subtasks"`
+	Version          int                    `json:"-"`
+}
+
+const (
+	BulkModeAllOrNothing = "all-or-nothing"
+	BulkModeBestEffort   = "best-effort"
+)
+
+const (
+	BulkStatusApplied  = "applied"
+	BulkStatusRejected = "rejected"
+)
+
+// BulkTodoRequest applies an action to many todos at once. In all-or-nothing
+// mode, the default, one rejected item fails the whole request; in best-effort
+// mode rejected items are reported and the rest still go through.
+type BulkTodoRequest struct {
+	Mode  string                `json:"mode" validate:"omitempty,oneof=all-or-nothing best-effort"`
+	Items []BulkTodoRequestItem `json:"items" validate:"required,min=1,max=200,dive"`
+}
+
+// BulkTodoRequestItem moves a todo to ProjectID, or to the inbox when it is
+// empty, for the move action, and sets Priority for the set_priority action.
+type BulkTodoRequestItem struct {
+	ID        string `json:"id" validate:"required,uuid"`
+	Action    string `json:"action" validate:"required,oneof=complete uncomplete delete move set_priority"`
+	ProjectID string `json:"project_id" validate:"omitempty,uuid"`
+	Priority  string `json:"priority" validate:"required_if=Action set_priority,omitempty,oneof=none low medium high urgent"`
+}
+
+type BulkTodoResult struct {
+	Index   int    `json:"index"`
+	ID      string `json:"id"`
+	Action  string `json:"action"`
+	Status  string `json:"status"`
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type SubtaskRequest struct {
+	TodoID      string `param:"id" validate:"required,uuid"`
+	Title       string `json:"title" validate:"required"`
 	Description string `json:"description"`
 	IsCompleted bool   `json:"is_completed"`
 }
+
+type UpdateSubtaskRequest struct {
+	TodoID      string   `param:"id" validate:"required,uuid"`
+	ID          string   `param:"subtask_id" validate:"required,uuid"`
+	Title       string   `json:"title" validate:"required"`
+	Description string   `json:"description"`
+	IsCompleted bool     `json:"is_completed"`
+	Position    *float64 `json:"position"`
+}
+
+type MoveTodoRequest struct {
+	ID       string `param:"id" validate:"required,uuid"`
+	AfterID  string `json:"after_id" validate:"required_without=BeforeID,omitempty,uuid"`
+	BeforeID string `json:"before_id" validate:"omitempty,uuid"`
+}
+
+type TodoQuery struct {
+	Page          int        `query:"page" validate:"omitempty,gte=1"`
+	PerPage       int        `query:"per_page" validate:"omitempty,gte=1"`
+	IsCompleted   *bool      `query:"is_completed"`
+	CreatedAfter  *time.Time `query:"created_after"`
+	CreatedBefore *time.Time `query:"created_before"`
+	Title         string     `query:"title"`
+	Tag           string     `query:"tag"`
+	ProjectID     string     `query:"project_id" validate:"omitempty,uuid|eq=inbox"`
+	Priority      string     `query:"priority" validate:"omitempty,oneof=none low medium high urgent"`
+	Sort          string     `query:"sort"`
+}
+
+type TodoSearchQuery struct {
+	Q       string `query:"q" validate:"required,max=255"`
+	Page    int    `query:"page" validate:"omitempty,gte=1"`
+	PerPage int    `query:"per_page" validate:"omitempty,gte=1"`
+}
+
+type TrashQuery struct {
+	Page    int `query:"page" validate:"omitempty,gte=1"`
+	PerPage int `query:"per_page" validate:"omitempty,gte=1"`
+}
+
+type TodoDueQuery struct {
+	Page    int `query:"page" validate:"omitempty,gte=1"`
+	PerPage int `query:"per_page" validate:"omitempty,gte=1"`
+	Days    int `query:"days" validate:"omitempty,gte=1,lte=365"`
+}
+
+// AssignTodoRequest assigns a todo to AssigneeID, or unassigns it when empty.
+type AssignTodoRequest struct {
+	ID         string `param:"id" validate:"required,uuid"`
+	AssigneeID string `json:"assignee_id" validate:"omitempty,uuid"`
+	Version    int    `json:"-"`
+}
+
+type AssignedTodoQuery struct {
+	Page        int   `query:"page" validate:"omitempty,gte=1"`
+	PerPage     int   `query:"per_page" validate:"omitempty,gte=1"`
+	IsCompleted *bool `query:"is_completed"`
+}
@@ -0,0 +1,30 @@
+package dto
+
+const (
+	FormatJSON     = "json"
+	FormatCSV      = "csv"
+	FormatMarkdown = "md"
+	FormatTodoTxt  = "todotxt"
+	FormatICS      = "ics"
+)
+
+type ExportQuery struct {
+	Format string `query:"format" validate:"omitempty,oneof=json csv md todotxt ics"`
+}
+
+type ImportQuery struct {
+	Format string `query:"format" validate:"required,oneof=json csv md todotxt ics"`
+}
+
+type ImportResult struct {
+	Imported int              `json:"imported"`
+	Failed   int              `json:"failed"`
+	Errors   []ImportRowError `json:"errors"`
+}
+
+// ImportRowError lists why a row was skipped. Rows are numbered from 1 in the
+// order the todos appear in the file.
+type ImportRowError struct {
+	Row    int      `json:"row"`
+	Errors []string `json:"errors"`
+}
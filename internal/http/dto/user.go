@@ -1,19 +1,58 @@
 package dto
 
+import (
+	"time"
+
+	"github.com/sherwin-77/golang-todos/pkg/patch"
+)
+
 type UserRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Username string `json:"username" validate:"required"`
 	Password string `json:"password" validate:"required"`
+	Timezone string `json:"timezone" validate:"omitempty,timezone"`
 }
 
+// UpdateUserRequest is a JSON Merge Patch: omitted fields are left untouched.
 type UpdateUserRequest struct {
-	ID       string `param:"id" validate:"required,uuid"`
-	Email    string `json:"email" validate:"omitempty,email"`
-	Username string `json:"username"`
-	Password string `json:"password"`
+	ID       string              `param:"id" validate:"required,uuid"`
+	Email    patch.Field[string] `json:"email" validate:"omitempty,email"`
+	Username patch.Field[string] `json:"username"`
+	Password patch.Field[string] `json:"password"`
+	Timezone patch.Field[string] `json:"timezone" validate:"omitempty,timezone"`
+	Version  int                 `json:"-"`
 }
 
 type LoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required"`
 }
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// TokenResponse is returned on login and refresh. The refresh token can be
+// exchanged once at /refresh for a new pair.
+type TokenResponse struct {
+	TokenType             string    `json:"token_type"`
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+// LoginResponse holds the tokens of a completed login, or the challenge of a
+// login that needs a second factor.
+type LoginResponse struct {
+	*TokenResponse
+	MFA *MFAChallenge `json:"mfa,omitempty"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `query:"token" validate:"required"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/service"
+	"github.com/sherwin-77/golang-todos/pkg/response"
+)
+
+type AttachmentHandler struct {
+	attachmentService service.AttachmentService
+}
+
+func NewAttachmentHandler(attachmentService service.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{attachmentService}
+}
+
+func (h *AttachmentHandler) GetAttachments(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	todoID := ctx.Param("id")
+
+	attachments, err := h.attachmentService.GetAttachments(ctx.Request().Context(), todoID, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Success", attachments, nil))
+}
+
+// CreateAttachment expects the file in the "file" field of a multipart form.
+// Size and type have already been checked by the LimitUpload middleware.
+func (h *AttachmentHandler) CreateAttachment(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Missing file")
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	attachment, err := h.attachmentService.CreateAttachment(ctx.Request().Context(), dto.AttachmentUpload{
+		TodoID:      ctx.Param("id"),
+		FileName:    header.Filename,
+		ContentType: ctx.Get("upload_content_type").(string),
+		Size:        header.Size,
+		Body:        file,
+	}, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusCreated, response.NewResponse(http.StatusCreated, "Attachment uploaded successfully", attachment, nil))
+}
+
+func (h *AttachmentHandler) GetAttachmentURL(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+
+	url, err := h.attachmentService.GetAttachmentURL(ctx.Request().Context(), ctx.Param("id"), ctx.Param("attachment_id"), userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Success", url, nil))
+}
+
+func (h *AttachmentHandler) DeleteAttachment(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+
+	if err := h.attachmentService.DeleteAttachment(ctx.Request().Context(), ctx.Param("id"), ctx.Param("attachment_id"), userID); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Attachment deleted successfully", nil, nil))
+}
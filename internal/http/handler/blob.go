@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/pkg/storage"
+)
+
+// BlobHandler serves blobs of the local store through the signed URLs it
+// hands out. Other stores serve their signed URLs themselves.
+type BlobHandler struct {
+	blobStore *storage.LocalStore
+}
+
+func NewBlobHandler(blobStore *storage.LocalStore) *BlobHandler {
+	return &BlobHandler{blobStore}
+}
+
+func (h *BlobHandler) GetBlob(ctx echo.Context) error {
+	key := ctx.Param("*")
+	query := ctx.QueryParams()
+
+	if err := h.blobStore.Verify(key, query); err != nil {
+		return echo.NewHTTPError(http.StatusForbidden, "Invalid or expired link")
+	}
+
+	blob, err := h.blobStore.Open(ctx.Request().Context(), key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+	}
+	if err != nil {
+		return err
+	}
+	defer blob.Close()
+
+	contentType := query.Get("type")
+	if contentType == "" {
+		contentType = echo.MIMEOctetStream
+	}
+	if name := query.Get("name"); name != "" {
+		ctx.Response().Header().Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": name}))
+	}
+	ctx.Response().Header().Set(echo.HeaderXContentTypeOptions, "nosniff")
+
+	return ctx.Stream(http.StatusOK, contentType, blob)
+}
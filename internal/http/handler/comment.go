@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/service"
+	"github.com/sherwin-77/golang-todos/pkg/response"
+)
+
+type CommentHandler struct {
+	commentService service.CommentService
+}
+
+func NewCommentHandler(commentService service.CommentService) *CommentHandler {
+	return &CommentHandler{commentService}
+}
+
+func (h *CommentHandler) GetComments(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.CommentQuery
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	comments, meta, err := h.commentService.GetComments(ctx.Request().Context(), req, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Success", comments, meta))
+}
+
+func (h *CommentHandler) CreateComment(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.CommentRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	comment, err := h.commentService.CreateComment(ctx.Request().Context(), req, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusCreated, response.NewResponse(http.StatusCreated, "Comment created successfully", comment, nil))
+}
+
+func (h *CommentHandler) UpdateComment(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.UpdateCommentRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	comment, err := h.commentService.UpdateComment(ctx.Request().Context(), req, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Comment updated successfully", comment, nil))
+}
+
+func (h *CommentHandler) DeleteComment(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	todoID := ctx.Param("id")
+	commentID := ctx.Param("comment_id")
+	if todoID == "" || commentID == "" {
+		return echo.NewHTTPError(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+	}
+
+	if err := h.commentService.DeleteComment(ctx.Request().Context(), todoID, commentID, userID); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Comment deleted successfully", nil, nil))
+}
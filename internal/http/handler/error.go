@@ -9,6 +9,8 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/configs"
+	"github.com/sherwin-77/golang-todos/internal/repository"
 	"github.com/sherwin-77/golang-todos/pkg/response"
 )
 
@@ -32,6 +34,9 @@ func HTTPErrorHandler(err error, c echo.Context) {
 	if errors.As(err, &he) {
 		code = he.Code
 		message = he.Message
+	} else if errors.Is(err, repository.ErrVersionConflict) {
+		code = http.StatusPreconditionFailed
+		message = "Resource has been modified by another request"
 	} else if errors.As(err, &pgerr) {
 		code = http.StatusUnprocessableEntity
 		if pgerr.Code == "23505" {
@@ -42,23 +47,7 @@ func HTTPErrorHandler(err error, c echo.Context) {
 		}
 	} else if errors.As(err, &ve) {
 		code = http.StatusUnprocessableEntity
-		fieldErr := ve[0]
-		switch fieldErr.Tag() {
-		case "required":
-			message = fieldErr.Field() + " is required"
-		case "email":
-			message = fieldErr.Field() + " is not a valid email"
-		case "gte":
-			message = fieldErr.Field() + " must be greater than or equal to " + fieldErr.Param()
-		case "lte":
-			message = fieldErr.Field() + " must be less than or equal to " + fieldErr.Param()
-		case "uuid":
-			message = fieldErr.Field() + " is not a valid UUID"
-		case "oneof":
-			message = fieldErr.Field() + " must be one of " + fieldErr.Param()
-		default:
-			message = fieldErr.Field() + " is not valid"
-		}
+		message = configs.ValidationMessage(ve[0])
 	} else {
 		code = http.StatusInternalServerError
 		message = err.Error()
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// etag formats a resource version as a strong entity tag.
+func etag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// ifMatchVersion returns the version named by If-Match, or zero when the request
+// is unconditional. Weak or malformed tags can never match, so they fail with 412.
+func ifMatchVersion(ctx echo.Context) (int, error) {
+	header := strings.TrimSpace(ctx.Request().Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	value, err := strconv.Unquote(header)
+	if err != nil {
+		return 0, echo.NewHTTPError(http.StatusPreconditionFailed, "Invalid If-Match header")
+	}
+
+	version, err := strconv.Atoi(value)
+	if err != nil || version < 1 {
+		return 0, echo.NewHTTPError(http.StatusPreconditionFailed, "Invalid If-Match header")
+	}
+
+	return version, nil
+}
+
+// notModified reports whether If-None-Match already names the current version.
+func notModified(ctx echo.Context, version int) bool {
+	header := ctx.Request().Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+
+	current := etag(version)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == current {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/service"
+	"github.com/sherwin-77/golang-todos/pkg/response"
+)
+
+type MFAHandler struct {
+	mfaService service.MFAService
+}
+
+func NewMFAHandler(mfaService service.MFAService) *MFAHandler {
+	return &MFAHandler{mfaService}
+}
+
+func (h *MFAHandler) Enroll(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+
+	enrollment, err := h.mfaService.Enroll(ctx.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Scan the URI with your authenticator app, then confirm a code", enrollment, nil))
+}
+
+func (h *MFAHandler) Confirm(ctx echo.Context) error {
+	var req dto.MFACodeRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	userID := ctx.Get("user_id").(string)
+
+	codes, err := h.mfaService.Confirm(ctx.Request().Context(), req, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Two-Factor Authentication Enabled. Store the recovery codes somewhere safe, they are only shown once", codes, nil))
+}
+
+func (h *MFAHandler) Disable(ctx echo.Context) error {
+	var req dto.MFACodeRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	userID := ctx.Get("user_id").(string)
+
+	if err := h.mfaService.Disable(ctx.Request().Context(), req, userID); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Two-Factor Authentication Disabled", nil, nil))
+}
+
+func (h *MFAHandler) RegenerateRecoveryCodes(ctx echo.Context) error {
+	var req dto.MFACodeRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	userID := ctx.Get("user_id").(string)
+
+	codes, err := h.mfaService.RegenerateRecoveryCodes(ctx.Request().Context(), req, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Recovery Codes Regenerated", codes, nil))
+}
+
+/**
+ * Admin Handlers
+**/
+
+func (h *MFAHandler) GetPolicy(ctx echo.Context) error {
+	policy, err := h.mfaService.GetPolicy(ctx.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Success", policy, nil))
+}
+
+func (h *MFAHandler) UpdatePolicy(ctx echo.Context) error {
+	var req dto.MFAPolicy
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	policy, err := h.mfaService.UpdatePolicy(ctx.Request().Context(), req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "MFA Policy Updated", policy, nil))
+}
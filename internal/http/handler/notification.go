@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/service"
+	"github.com/sherwin-77/golang-todos/pkg/response"
+)
+
+type NotificationHandler struct {
+	notificationService service.NotificationService
+}
+
+func NewNotificationHandler(notificationService service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notificationService}
+}
+
+func (h *NotificationHandler) GetNotifications(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.NotificationQuery
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	notifications, meta, err := h.notificationService.GetNotifications(ctx.Request().Context(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Success", notifications, meta))
+}
+
+func (h *NotificationHandler) MarkNotificationsRead(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.MarkNotificationsReadRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.notificationService.MarkNotificationsRead(ctx.Request().Context(), req, userID); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Notifications marked as read", nil, nil))
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/service"
+	"github.com/sherwin-77/golang-todos/pkg/response"
+)
+
+type PasswordHandler struct {
+	passwordService service.PasswordService
+}
+
+func NewPasswordHandler(passwordService service.PasswordService) *PasswordHandler {
+	return &PasswordHandler{passwordService}
+}
+
+func (h *PasswordHandler) ForgotPassword(ctx echo.Context) error {
+	var req dto.ForgotPasswordRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.passwordService.ForgotPassword(ctx.Request().Context(), req); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusAccepted, response.NewResponse(http.StatusAccepted, "If the email is registered, a reset link has been sent", nil, nil))
+}
+
+func (h *PasswordHandler) ResetPassword(ctx echo.Context) error {
+	var req dto.ResetPasswordRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.passwordService.ResetPassword(ctx.Request().Context(), req); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Password Reset", nil, nil))
+}
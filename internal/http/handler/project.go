@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/service"
+	"github.com/sherwin-77/golang-todos/pkg/response"
+)
+
+type ProjectHandler struct {
+	projectService service.ProjectService
+	todoService    service.TodoService
+}
+
+func NewProjectHandler(projectService service.ProjectService, todoService service.TodoService) *ProjectHandler {
+	return &ProjectHandler{projectService, todoService}
+}
+
+func (h *ProjectHandler) GetProjects(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.ProjectQuery
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	projects, err := h.projectService.GetProjectsByUserID(ctx.Request().Context(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Success", projects, nil))
+}
+
+func (h *ProjectHandler) GetProjectByID(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	projectID := ctx.Param("id")
+	if projectID == "" {
+		return echo.NewHTTPError(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+	}
+
+	project, err := h.projectService.GetProjectByID(ctx.Request().Context(), projectID, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Success", project, nil))
+}
+
+func (h *ProjectHandler) GetProjectTodos(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.TodoQuery
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	req.ProjectID = ctx.Param("id")
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	if _, err := h.projectService.GetProjectByID(ctx.Request().Context(), req.ProjectID, userID); err != nil {
+		return err
+	}
+
+	todos, meta, err := h.todoService.GetTodosByUserID(ctx.Request().Context(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Success", todos, meta))
+}
+
+func (h *ProjectHandler) CreateProject(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.ProjectRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	project, err := h.projectService.CreateProject(ctx.Request().Context(), req, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusCreated, response.NewResponse(http.StatusCreated, "Project created successfully", project, nil))
+}
+
+func (h *ProjectHandler) UpdateProject(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.UpdateProjectRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	project, err := h.projectService.UpdateProject(ctx.Request().Context(), req, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Project updated successfully", project, nil))
+}
+
+func (h *ProjectHandler) ArchiveProject(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	projectID := ctx.Param("id")
+	if projectID == "" {
+		return echo.NewHTTPError(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+	}
+
+	project, err := h.projectService.ArchiveProject(ctx.Request().Context(), projectID, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Project archived successfully", project, nil))
+}
+
+func (h *ProjectHandler) UnarchiveProject(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	projectID := ctx.Param("id")
+	if projectID == "" {
+		return echo.NewHTTPError(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+	}
+
+	project, err := h.projectService.UnarchiveProject(ctx.Request().Context(), projectID, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Project unarchived successfully", project, nil))
+}
+
+func (h *ProjectHandler) DeleteProject(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.DeleteProjectRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.projectService.DeleteProject(ctx.Request().Context(), req, userID); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Project deleted successfully", nil, nil))
+}
+
+func (h *ProjectHandler) GetMembers(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	projectID := ctx.Param("id")
+	if projectID == "" {
+		return echo.NewHTTPError(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+	}
+
+	members, err := h.projectService.GetMembers(ctx.Request().Context(), projectID, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Success", members, nil))
+}
+
+func (h *ProjectHandler) GetInvitations(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+
+	invitations, err := h.projectService.GetInvitations(ctx.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Success", invitations, nil))
+}
+
+func (h *ProjectHandler) InviteMember(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.InviteMemberRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	member, err := h.projectService.InviteMember(ctx.Request().Context(), req, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusCreated, response.NewResponse(http.StatusCreated, "Member invited successfully", member, nil))
+}
+
+func (h *ProjectHandler) AcceptInvitation(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	projectID := ctx.Param("id")
+	if projectID == "" {
+		return echo.NewHTTPError(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+	}
+
+	member, err := h.projectService.AcceptInvitation(ctx.Request().Context(), projectID, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Invitation accepted successfully", member, nil))
+}
+
+func (h *ProjectHandler) RevokeMember(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	projectID := ctx.Param("id")
+	memberID := ctx.Param("user_id")
+	if projectID == "" || memberID == "" {
+		return echo.NewHTTPError(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+	}
+
+	if err := h.projectService.RevokeMember(ctx.Request().Context(), projectID, memberID, userID); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Member removed successfully", nil, nil))
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/service"
+	"github.com/sherwin-77/golang-todos/pkg/response"
+)
+
+type TagHandler struct {
+	tagService service.TagService
+}
+
+func NewTagHandler(tagService service.TagService) *TagHandler {
+	return &TagHandler{tagService}
+}
+
+func (h *TagHandler) GetTags(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+
+	tags, err := h.tagService.GetTagsByUserID(ctx.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Success", tags, nil))
+}
+
+func (h *TagHandler) GetTagByID(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	tagID := ctx.Param("id")
+	if tagID == "" {
+		return echo.NewHTTPError(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+	}
+
+	tag, err := h.tagService.GetTagByID(ctx.Request().Context(), tagID, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Success", tag, nil))
+}
+
+func (h *TagHandler) CreateTag(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.TagRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	tag, err := h.tagService.CreateTag(ctx.Request().Context(), req, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusCreated, response.NewResponse(http.StatusCreated, "Tag created successfully", tag, nil))
+}
+
+func (h *TagHandler) UpdateTag(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.UpdateTagRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	tag, err := h.tagService.UpdateTag(ctx.Request().Context(), req, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Tag updated successfully", tag, nil))
+}
+
+func (h *TagHandler) DeleteTag(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	tagID := ctx.Param("id")
+	if tagID == "" {
+		return echo.NewHTTPError(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+	}
+
+	if err := h.tagService.DeleteTag(ctx.Request().Context(), tagID, userID); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Tag deleted successfully", nil, nil))
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/sherwin-77/golang-todos/internal/service"
 	"github.com/sherwin-77/golang-todos/pkg/response"
 	"net/http"
+	"strings"
 )
 
 type TodoHandler struct {
@@ -18,13 +19,122 @@ func NewTodoHandler(todoService service.TodoService) *TodoHandler {
 
 func (h *TodoHandler) GetTodosByUserID(ctx echo.Context) error {
 	userID := ctx.Get("user_id").(string)
+	var req dto.TodoQuery
 
-	todos, err := h.TodoService.GetTodosByUserID(ctx.Request().Context(), userID)
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	todos, meta, err := h.TodoService.GetTodosByUserID(ctx.Request().Context(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Success", todos, meta))
+}
+
+func (h *TodoHandler) GetOverdueTodos(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.TodoDueQuery
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	todos, meta, err := h.TodoService.GetOverdueTodos(ctx.Request().Context(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Success", todos, meta))
+}
+
+func (h *TodoHandler) GetTodayTodos(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.TodoDueQuery
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	todos, meta, err := h.TodoService.GetTodayTodos(ctx.Request().Context(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Success", todos, meta))
+}
+
+func (h *TodoHandler) GetUpcomingTodos(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.TodoDueQuery
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	todos, meta, err := h.TodoService.GetUpcomingTodos(ctx.Request().Context(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Success", todos, meta))
+}
+
+func (h *TodoHandler) GetAssignedTodos(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.AssignedTodoQuery
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	todos, meta, err := h.TodoService.GetAssignedTodos(ctx.Request().Context(), userID, req)
 	if err != nil {
 		return err
 	}
 
-	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Success", todos, nil))
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Success", todos, meta))
+}
+
+func (h *TodoHandler) SearchTodos(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.TodoSearchQuery
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	todos, meta, err := h.TodoService.SearchTodos(ctx.Request().Context(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Success", todos, meta))
 }
 
 func (h *TodoHandler) GetTodoByID(ctx echo.Context) error {
@@ -39,6 +149,11 @@ func (h *TodoHandler) GetTodoByID(ctx echo.Context) error {
 		return err
 	}
 
+	ctx.Response().Header().Set("ETag", etag(todo.Version))
+	if notModified(ctx, todo.Version) {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
 	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Success", todo, nil))
 }
 
@@ -70,6 +185,12 @@ func (h *TodoHandler) UpdateTodo(ctx echo.Context) error {
 		return err
 	}
 
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		return err
+	}
+	req.Version = version
+
 	if err := ctx.Validate(req); err != nil {
 		return err
 	}
@@ -79,9 +200,59 @@ func (h *TodoHandler) UpdateTodo(ctx echo.Context) error {
 		return err
 	}
 
+	ctx.Response().Header().Set("ETag", etag(todo.Version))
+
 	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Todo updated successfully", todo, nil))
 }
 
+func (h *TodoHandler) MoveTodo(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.MoveTodoRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	todo, err := h.TodoService.MoveTodo(ctx.Request().Context(), req, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Todo moved successfully", todo, nil))
+}
+
+func (h *TodoHandler) AssignTodo(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.AssignTodoRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		return err
+	}
+	req.Version = version
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	todo, err := h.TodoService.AssignTodo(ctx.Request().Context(), req, userID)
+	if err != nil {
+		return err
+	}
+
+	ctx.Response().Header().Set("ETag", etag(todo.Version))
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Todo assigned successfully", todo, nil))
+}
+
 func (h *TodoHandler) DeleteTodo(ctx echo.Context) error {
 	userID := ctx.Get("user_id").(string)
 	todoID := ctx.Param("id")
@@ -89,9 +260,370 @@ func (h *TodoHandler) DeleteTodo(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusNotFound, http.StatusText(http.StatusNotFound))
 	}
 
-	if err := h.TodoService.DeleteTodo(ctx.Request().Context(), todoID, userID); err != nil {
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := h.TodoService.DeleteTodo(ctx.Request().Context(), todoID, userID, version); err != nil {
 		return err
 	}
 
 	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Todo deleted successfully", nil, nil))
 }
+
+func (h *TodoHandler) BulkTodos(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.BulkTodoRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	results, err := h.TodoService.BulkTodos(ctx.Request().Context(), req, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Bulk operation completed", results, nil))
+}
+
+// maxImportSize caps the size of an uploaded import file.
+const maxImportSize = 10 << 20
+
+const calendarContentType = "text/calendar; charset=utf-8"
+
+var exportContentTypes = map[string]string{
+	dto.FormatJSON:     echo.MIMEApplicationJSONCharsetUTF8,
+	dto.FormatCSV:      "text/csv; charset=utf-8",
+	dto.FormatMarkdown: "text/markdown; charset=utf-8",
+	dto.FormatTodoTxt:  echo.MIMETextPlainCharsetUTF8,
+	dto.FormatICS:      calendarContentType,
+}
+
+var exportFilenames = map[string]string{
+	dto.FormatJSON:     "todos.json",
+	dto.FormatCSV:      "todos.csv",
+	dto.FormatMarkdown: "todos.md",
+	dto.FormatTodoTxt:  "todo.txt",
+	dto.FormatICS:      "todos.ics",
+}
+
+func (h *TodoHandler) ExportTodos(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.ExportQuery
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	if req.Format == "" {
+		req.Format = dto.FormatJSON
+	}
+
+	ctx.Response().Header().Set(echo.HeaderContentType, exportContentTypes[req.Format])
+	ctx.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="`+exportFilenames[req.Format]+`"`)
+
+	return h.TodoService.ExportTodos(ctx.Request().Context(), userID, req.Format, ctx.Response())
+}
+
+func (h *TodoHandler) ImportTodos(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.ImportQuery
+
+	// The body is the file itself, so only the query string is bound.
+	if err := (&echo.DefaultBinder{}).BindQueryParams(ctx, &req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	return h.importTodos(ctx, userID, req.Format)
+}
+
+// ImportCalendar creates todos from the VTODO and VEVENT entries of an
+// uploaded .ics file.
+func (h *TodoHandler) ImportCalendar(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+
+	return h.importTodos(ctx, userID, dto.FormatICS)
+}
+
+func (h *TodoHandler) importTodos(ctx echo.Context, userID string, format string) error {
+	body := http.MaxBytesReader(ctx.Response(), ctx.Request().Body, maxImportSize)
+
+	result, err := h.TodoService.ImportTodos(ctx.Request().Context(), userID, format, body)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Import completed", result, nil))
+}
+
+// GetCalendarFeed serves the feed that calendar apps subscribe to. It is
+// authenticated by the token in the URL alone, which may end in ".ics" since
+// some apps look for the extension.
+func (h *TodoHandler) GetCalendarFeed(ctx echo.Context) error {
+	token := strings.TrimSuffix(ctx.Param("token"), ".ics")
+
+	feed, err := h.TodoService.GetCalendarFeed(ctx.Request().Context(), token)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Blob(http.StatusOK, calendarContentType, []byte(feed))
+}
+
+func (h *TodoHandler) CreateCalendarToken(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+
+	token, err := h.TodoService.CreateCalendarToken(ctx.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	// The feed is served next to this route, e.g. /api/v1/calendar/feeds/<token>.ics.
+	feedPath := strings.TrimSuffix(ctx.Path(), "/token") + "/feeds/" + token + ".ics"
+	result := dto.CalendarTokenResponse{
+		Token: token,
+		URL:   ctx.Scheme() + "://" + ctx.Request().Host + feedPath,
+	}
+
+	return ctx.JSON(http.StatusCreated, response.NewResponse(http.StatusCreated, "Calendar token created", result, nil))
+}
+
+func (h *TodoHandler) DeleteCalendarToken(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+
+	if err := h.TodoService.DeleteCalendarToken(ctx.Request().Context(), userID); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Calendar token deleted", nil, nil))
+}
+
+func (h *TodoHandler) GetTodoChanges(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.TodoChangesQuery
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	changes, err := h.TodoService.GetTodoChanges(ctx.Request().Context(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Success", changes, nil))
+}
+
+func (h *TodoHandler) SyncTodos(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.SyncTodosRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	// Updates are validated like a regular update of the mutation's todo.
+	for _, mutation := range req.Mutations {
+		if mutation.Changes != nil {
+			mutation.Changes.ID = mutation.ID
+		}
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	results, err := h.TodoService.SyncTodos(ctx.Request().Context(), req, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Sync completed", results, nil))
+}
+
+func (h *TodoHandler) GetTrash(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.TrashQuery
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	todos, meta, err := h.TodoService.GetTrash(ctx.Request().Context(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Success", todos, meta))
+}
+
+func (h *TodoHandler) RestoreTodo(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	todoID := ctx.Param("id")
+	if todoID == "" {
+		return echo.NewHTTPError(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+	}
+
+	todo, err := h.TodoService.RestoreTodo(ctx.Request().Context(), todoID, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Todo restored successfully", todo, nil))
+}
+
+func (h *TodoHandler) PurgeTodo(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	todoID := ctx.Param("id")
+	if todoID == "" {
+		return echo.NewHTTPError(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+	}
+
+	if err := h.TodoService.PurgeTodo(ctx.Request().Context(), todoID, userID); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Todo permanently deleted", nil, nil))
+}
+
+func (h *TodoHandler) EmptyTrash(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+
+	if err := h.TodoService.EmptyTrash(ctx.Request().Context(), userID); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Trash emptied successfully", nil, nil))
+}
+
+func (h *TodoHandler) GetSubtasks(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	todoID := ctx.Param("id")
+	if todoID == "" {
+		return echo.NewHTTPError(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+	}
+
+	subtasks, err := h.TodoService.GetSubtasks(ctx.Request().Context(), todoID, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Success", subtasks, nil))
+}
+
+func (h *TodoHandler) GetOccurrences(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	todoID := ctx.Param("id")
+	if todoID == "" {
+		return echo.NewHTTPError(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+	}
+
+	occurrences, err := h.TodoService.GetOccurrences(ctx.Request().Context(), todoID, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Success", occurrences, nil))
+}
+
+func (h *TodoHandler) CreateSubtask(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.SubtaskRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	subtask, err := h.TodoService.CreateSubtask(ctx.Request().Context(), req, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusCreated, response.NewResponse(http.StatusCreated, "Subtask created successfully", subtask, nil))
+}
+
+func (h *TodoHandler) UpdateSubtask(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.UpdateSubtaskRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	subtask, err := h.TodoService.UpdateSubtask(ctx.Request().Context(), req, userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Subtask updated successfully", subtask, nil))
+}
+
+func (h *TodoHandler) DeleteSubtask(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	todoID := ctx.Param("id")
+	subtaskID := ctx.Param("subtask_id")
+	if todoID == "" || subtaskID == "" {
+		return echo.NewHTTPError(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+	}
+
+	if err := h.TodoService.DeleteSubtask(ctx.Request().Context(), todoID, subtaskID, userID); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Subtask deleted successfully", nil, nil))
+}
+
+func (h *TodoHandler) ChangeTags(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+	var req dto.ChangeTagRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		return err
+	}
+	req.Version = version
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.TodoService.ChangeTags(ctx.Request().Context(), req, userID); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Tags changed successfully", nil, nil))
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/sherwin-77/golang-todos/internal/http/dto"
 	"github.com/sherwin-77/golang-todos/internal/service"
 	"github.com/sherwin-77/golang-todos/pkg/response"
+	"github.com/sherwin-77/golang-todos/pkg/tokens"
 )
 
 type UserHandler struct {
@@ -42,6 +43,11 @@ func (h *UserHandler) GetUserByID(ctx echo.Context) error {
 		return err
 	}
 
+	ctx.Response().Header().Set("ETag", etag(user.Version))
+	if notModified(ctx, user.Version) {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
 	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Success", user, nil))
 }
 
@@ -61,9 +67,9 @@ func (h *UserHandler) CreateUser(ctx echo.Context) error {
 	if err != nil {
 		return err
 	}
-	msg := "User Created"
+	msg := "User Created. Check your email to verify your address"
 	if isFirstUser {
-		msg += ". Because this is the first user, admin role has been assigned"
+		msg += ". Because this is the first user, admin role will be assigned once the email is verified"
 	}
 	return ctx.JSON(http.StatusCreated, response.NewResponse(http.StatusCreated, msg, user, nil))
 }
@@ -75,6 +81,12 @@ func (h *UserHandler) UpdateUser(ctx echo.Context) error {
 		return err
 	}
 
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		return err
+	}
+	req.Version = version
+
 	if err := ctx.Validate(req); err != nil {
 		return err
 	}
@@ -85,6 +97,7 @@ func (h *UserHandler) UpdateUser(ctx echo.Context) error {
 		return err
 	}
 
+	ctx.Response().Header().Set("ETag", etag(user.Version))
 	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "User Updated", user, nil))
 }
 
@@ -94,7 +107,12 @@ func (h *UserHandler) DeleteUser(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusNotFound, http.StatusText(http.StatusNotFound))
 	}
 
-	if err := h.userService.DeleteUser(ctx.Request().Context(), userID); err != nil {
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := h.userService.DeleteUser(ctx.Request().Context(), userID, version); err != nil {
 		return err
 	}
 
@@ -138,7 +156,31 @@ func (h *UserHandler) Login(ctx echo.Context) error {
 		return err
 	}
 
-	token, err := h.userService.Login(ctx.Request().Context(), req)
+	login, err := h.userService.Login(ctx.Request().Context(), req)
+
+	if err != nil {
+		return err
+	}
+
+	msg := "Login Success"
+	if login.MFA != nil {
+		msg = "Two-Factor Authentication Required"
+	}
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, msg, login, nil))
+}
+
+func (h *UserHandler) LoginMFA(ctx echo.Context) error {
+	var req dto.MFALoginRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	token, err := h.userService.LoginMFA(ctx.Request().Context(), req)
 
 	if err != nil {
 		return err
@@ -147,6 +189,46 @@ func (h *UserHandler) Login(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Login Success", token, nil))
 }
 
+func (h *UserHandler) Refresh(ctx echo.Context) error {
+	var req dto.RefreshRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	token, err := h.userService.Refresh(ctx.Request().Context(), req)
+
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Token Refreshed", token, nil))
+}
+
+func (h *UserHandler) Logout(ctx echo.Context) error {
+	claims := ctx.Get("token_claims").(*tokens.JWTCustomClaims)
+
+	if err := h.userService.Logout(ctx.Request().Context(), claims); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Logout Success", nil, nil))
+}
+
+func (h *UserHandler) LogoutAll(ctx echo.Context) error {
+	userID := ctx.Get("user_id").(string)
+
+	if err := h.userService.LogoutAll(ctx.Request().Context(), userID); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "Logged Out Everywhere", nil, nil))
+}
+
 func (h *UserHandler) EditProfile(ctx echo.Context) error {
 	userID := ctx.Get("user_id").(string)
 	var req dto.UpdateUserRequest
@@ -157,6 +239,12 @@ func (h *UserHandler) EditProfile(ctx echo.Context) error {
 
 	req.ID = userID
 
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		return err
+	}
+	req.Version = version
+
 	if err := ctx.Validate(req); err != nil {
 		return err
 	}
@@ -165,5 +253,11 @@ func (h *UserHandler) EditProfile(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusForbidden, http.StatusText(http.StatusForbidden))
 	}
 
-	return h.UpdateUser(ctx)
+	user, err := h.userService.UpdateUser(ctx.Request().Context(), req)
+	if err != nil {
+		return err
+	}
+
+	ctx.Response().Header().Set("ETag", etag(user.Version))
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, "User Updated", user, nil))
 }
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/service"
+	"github.com/sherwin-77/golang-todos/pkg/response"
+)
+
+type VerificationHandler struct {
+	verificationService service.VerificationService
+}
+
+func NewVerificationHandler(verificationService service.VerificationService) *VerificationHandler {
+	return &VerificationHandler{verificationService}
+}
+
+func (h *VerificationHandler) VerifyEmail(ctx echo.Context) error {
+	var req dto.VerifyEmailRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	isFirstUser, err := h.verificationService.VerifyEmail(ctx.Request().Context(), req)
+	if err != nil {
+		return err
+	}
+
+	msg := "Email Verified"
+	if isFirstUser {
+		msg += ". Because this is the first user, admin role has been assigned"
+	}
+	return ctx.JSON(http.StatusOK, response.NewResponse(http.StatusOK, msg, nil, nil))
+}
+
+func (h *VerificationHandler) ResendVerification(ctx echo.Context) error {
+	var req dto.ResendVerificationRequest
+
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := ctx.Validate(req); err != nil {
+		return err
+	}
+
+	if err := h.verificationService.ResendVerification(ctx.Request().Context(), req); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusAccepted, response.NewResponse(http.StatusAccepted, "If the email is registered and not verified, a verification link has been sent", nil, nil))
+}
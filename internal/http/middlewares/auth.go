@@ -2,24 +2,41 @@ package middlewares
 
 import (
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
 	"github.com/sherwin-77/golang-todos/configs"
+	"github.com/sherwin-77/golang-todos/pkg/tokens"
 	"gorm.io/gorm"
 )
 
 type AuthMiddleware struct {
-	config *configs.Config
-	db     *gorm.DB
+	config   *configs.Config
+	db       *gorm.DB
+	denylist tokens.Denylist
 }
 
-func NewAuthMiddleware(config *configs.Config, db *gorm.DB) *AuthMiddleware {
-	return &AuthMiddleware{config, db}
+func NewAuthMiddleware(config *configs.Config, db *gorm.DB, denylist tokens.Denylist) *AuthMiddleware {
+	return &AuthMiddleware{config, db, denylist}
 }
 
+// Authenticated requires an access token.
 func (m *AuthMiddleware) Authenticated(next echo.HandlerFunc) echo.HandlerFunc {
+	return m.authenticate(next, "")
+}
+
+// MFAEnrollment also accepts the mfa_pending token of a login, so users who
+// must use two-factor authentication can set it up before their first login
+// with it.
+func (m *AuthMiddleware) MFAEnrollment(next echo.HandlerFunc) echo.HandlerFunc {
+	return m.authenticate(next, "", tokens.PurposeMFAPending)
+}
+
+// authenticate requires a token with one of the given purposes, where the
+// empty purpose is an access token.
+func (m *AuthMiddleware) authenticate(next echo.HandlerFunc, purposes ...string) echo.HandlerFunc {
 	return func(c echo.Context) error {
 
 		// Extract the "Authorization" header.
@@ -37,7 +54,7 @@ func (m *AuthMiddleware) Authenticated(next echo.HandlerFunc) echo.HandlerFunc {
 		tokenString := strings.TrimSpace(splitToken[1])
 
 		// Parse the JWT token.
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		token, err := jwt.ParseWithClaims(tokenString, &tokens.JWTCustomClaims{}, func(token *jwt.Token) (interface{}, error) {
 			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 				return nil, echo.NewHTTPError(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
 			}
@@ -48,13 +65,69 @@ func (m *AuthMiddleware) Authenticated(next echo.HandlerFunc) echo.HandlerFunc {
 			return echo.NewHTTPError(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
 		}
 
-		claims, ok := token.Claims.(jwt.MapClaims)
+		claims, ok := token.Claims.(*tokens.JWTCustomClaims)
 		if !ok || !token.Valid {
 			return echo.NewHTTPError(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
 		}
 
-		c.Set("user_id", claims["id"])
+		if !slices.Contains(purposes, claims.Purpose) {
+			return echo.NewHTTPError(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+		}
+
+		// Reject tokens revoked by logout or a password change. When either
+		// store cannot be read the token is refused rather than trusted.
+		revoked, err := m.denylist.IsRevoked(claims)
+		if err != nil {
+			return err
+		}
+		if revoked {
+			return echo.NewHTTPError(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+		}
+
+		if m.db == nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Database connection not available")
+		}
+
+		var versions []string
+		if err := m.db.WithContext(c.Request().Context()).Table("users").
+			Where("id = ?", claims.ID).
+			Pluck("token_version", &versions).Error; err != nil {
+			return err
+		}
 
+		if len(versions) == 0 || versions[0] != claims.Version {
+			return echo.NewHTTPError(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+		}
+
+		c.Set("user_id", claims.ID)
+		c.Set("token_claims", claims)
+
+		return next(c)
+	}
+}
+
+// Verified requires the user to have verified their email address.
+func (m *AuthMiddleware) Verified(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if m.db == nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Database connection not available")
+		}
+
+		userIDData := c.Get("user_id")
+		if userIDData == nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+		}
+
+		var count int64
+		if err := m.db.Table("users").
+			Where("id = ? AND email_verified_at IS NOT NULL", userIDData.(string)).
+			Count(&count).Error; err != nil {
+			return err
+		}
+
+		if count == 0 {
+			return echo.NewHTTPError(http.StatusForbidden, "Email address is not verified")
+		}
 		return next(c)
 	}
 }
@@ -1,12 +1,19 @@
 package middlewares
 
 import (
+	"errors"
+	"io"
+	"mime"
 	"net/http"
+	"slices"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
+// multipartOverhead leaves room for the multipart framing around an upload.
+const multipartOverhead = 1 << 20
+
 type Middleware struct {
 }
 
@@ -27,3 +34,50 @@ func (m *Middleware) ValidateUUID(params []string) echo.MiddlewareFunc {
 		}
 	}
 }
+
+// LimitUpload rejects multipart requests whose file in field is larger than
+// maxSize or is not one of allowedTypes. The type is sniffed from the content
+// rather than trusted from the client, and stored as "upload_content_type".
+func (m *Middleware) LimitUpload(field string, maxSize int64, allowedTypes []string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if req.ContentLength > maxSize+multipartOverhead {
+				return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "File is too large")
+			}
+			req.Body = http.MaxBytesReader(c.Response(), req.Body, maxSize+multipartOverhead)
+
+			header, err := c.FormFile(field)
+			if err != nil {
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "File is too large")
+				}
+				return echo.NewHTTPError(http.StatusBadRequest, "Missing file")
+			}
+			if header.Size > maxSize {
+				return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "File is too large")
+			}
+
+			file, err := header.Open()
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			head := make([]byte, 512)
+			n, err := io.ReadFull(file, head)
+			if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+				return err
+			}
+
+			contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head[:n]))
+			if !slices.Contains(allowedTypes, contentType) {
+				return echo.NewHTTPError(http.StatusUnsupportedMediaType, "Unsupported file type")
+			}
+			c.Set("upload_content_type", contentType)
+
+			return next(c)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/configs"
 	"github.com/sherwin-77/golang-todos/internal/http/handler"
 	"github.com/sherwin-77/golang-todos/internal/http/middlewares"
 	"github.com/sherwin-77/golang-todos/pkg/route"
@@ -23,6 +24,34 @@ func UserRoutes(userHandler handler.UserHandler, middlware middlewares.Middlewar
 			Handler:     userHandler.Login,
 			Middlewares: []echo.MiddlewareFunc{},
 		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/login/mfa",
+			Handler:     userHandler.LoginMFA,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/refresh",
+			Handler:     userHandler.Refresh,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/logout",
+			Handler: userHandler.Logout,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddleware.Authenticated,
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/logout-all",
+			Handler: userHandler.LogoutAll,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddleware.Authenticated,
+			},
+		},
 		{
 			Method:  http.MethodPut,
 			Path:    "/profile",
@@ -38,6 +67,83 @@ func UserRoutes(userHandler handler.UserHandler, middlware middlewares.Middlewar
 	return routes, middlewareFuncs
 }
 
+func PasswordRoutes(passwordHandler handler.PasswordHandler, middleware middlewares.Middleware, authMiddleware middlewares.AuthMiddleware) ([]route.Route, []echo.MiddlewareFunc) {
+	routes := []route.Route{
+		{
+			Method:      http.MethodPost,
+			Path:        "/password/forgot",
+			Handler:     passwordHandler.ForgotPassword,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/password/reset",
+			Handler:     passwordHandler.ResetPassword,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+	}
+
+	return routes, []echo.MiddlewareFunc{}
+}
+
+func MFARoutes(mfaHandler handler.MFAHandler, middleware middlewares.Middleware, authMiddleware middlewares.AuthMiddleware) ([]route.Route, []echo.MiddlewareFunc) {
+	routes := []route.Route{
+		{
+			Method:  http.MethodPost,
+			Path:    "/mfa/enroll",
+			Handler: mfaHandler.Enroll,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddleware.MFAEnrollment,
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/mfa/confirm",
+			Handler: mfaHandler.Confirm,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddleware.MFAEnrollment,
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/mfa/disable",
+			Handler: mfaHandler.Disable,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddleware.Authenticated,
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/mfa/recovery-codes",
+			Handler: mfaHandler.RegenerateRecoveryCodes,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddleware.Authenticated,
+			},
+		},
+	}
+
+	return routes, []echo.MiddlewareFunc{}
+}
+
+func VerificationRoutes(verificationHandler handler.VerificationHandler, middleware middlewares.Middleware, authMiddleware middlewares.AuthMiddleware) ([]route.Route, []echo.MiddlewareFunc) {
+	routes := []route.Route{
+		{
+			Method:      http.MethodGet,
+			Path:        "/verify-email",
+			Handler:     verificationHandler.VerifyEmail,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/verify-email/resend",
+			Handler:     verificationHandler.ResendVerification,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+	}
+
+	return routes, []echo.MiddlewareFunc{}
+}
+
 func TodoRoutes(todoHandler handler.TodoHandler, middleware middlewares.Middleware, authMiddleware middlewares.AuthMiddleware) ([]route.Route, []echo.MiddlewareFunc) {
 	routes := []route.Route{
 		{
@@ -46,6 +152,72 @@ func TodoRoutes(todoHandler handler.TodoHandler, middleware middlewares.Middlewa
 			Handler:     todoHandler.GetTodosByUserID,
 			Middlewares: []echo.MiddlewareFunc{},
 		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/todos/overdue",
+			Handler:     todoHandler.GetOverdueTodos,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/todos/today",
+			Handler:     todoHandler.GetTodayTodos,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/todos/upcoming",
+			Handler:     todoHandler.GetUpcomingTodos,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/todos/assigned-to-me",
+			Handler:     todoHandler.GetAssignedTodos,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/todos/search",
+			Handler:     todoHandler.SearchTodos,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/todos/bulk",
+			Handler: todoHandler.BulkTodos,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddleware.Verified,
+			},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/todos/export",
+			Handler:     todoHandler.ExportTodos,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/todos/import",
+			Handler: todoHandler.ImportTodos,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddleware.Verified,
+			},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/todos/changes",
+			Handler:     todoHandler.GetTodoChanges,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/todos/sync",
+			Handler: todoHandler.SyncTodos,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddleware.Verified,
+			},
+		},
 		{
 			Method:  http.MethodGet,
 			Path:    "/todos/:id",
@@ -55,10 +227,12 @@ func TodoRoutes(todoHandler handler.TodoHandler, middleware middlewares.Middlewa
 			},
 		},
 		{
-			Method:      http.MethodPost,
-			Path:        "/todos",
-			Handler:     todoHandler.CreateTodo,
-			Middlewares: []echo.MiddlewareFunc{},
+			Method:  http.MethodPost,
+			Path:    "/todos",
+			Handler: todoHandler.CreateTodo,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddleware.Verified,
+			},
 		},
 		{
 			Method:  http.MethodPatch,
@@ -68,6 +242,22 @@ func TodoRoutes(todoHandler handler.TodoHandler, middleware middlewares.Middlewa
 				middleware.ValidateUUID([]string{"id"}),
 			},
 		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/todos/:id/move",
+			Handler: todoHandler.MoveTodo,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id"}),
+			},
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/todos/:id/assignee",
+			Handler: todoHandler.AssignTodo,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id"}),
+			},
+		},
 		{
 			Method:  http.MethodDelete,
 			Path:    "/todos/:id",
@@ -76,6 +266,404 @@ func TodoRoutes(todoHandler handler.TodoHandler, middleware middlewares.Middlewa
 				middleware.ValidateUUID([]string{"id"}),
 			},
 		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/todos/trash",
+			Handler:     todoHandler.GetTrash,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "/todos/trash",
+			Handler:     todoHandler.EmptyTrash,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/todos/trash/:id",
+			Handler: todoHandler.PurgeTodo,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id"}),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/todos/:id/restore",
+			Handler: todoHandler.RestoreTodo,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id"}),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/todos/:id/subtasks",
+			Handler: todoHandler.GetSubtasks,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id"}),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/todos/:id/occurrences",
+			Handler: todoHandler.GetOccurrences,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id"}),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/todos/:id/subtasks",
+			Handler: todoHandler.CreateSubtask,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id"}),
+				authMiddleware.Verified,
+			},
+		},
+		{
+			Method:  http.MethodPatch,
+			Path:    "/todos/:id/subtasks/:subtask_id",
+			Handler: todoHandler.UpdateSubtask,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id", "subtask_id"}),
+			},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/todos/:id/subtasks/:subtask_id",
+			Handler: todoHandler.DeleteSubtask,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id", "subtask_id"}),
+			},
+		},
+		{
+			Method:  http.MethodPatch,
+			Path:    "/todos/:id/tags",
+			Handler: todoHandler.ChangeTags,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id"}),
+			},
+		},
+	}
+
+	middlewareFuncs := []echo.MiddlewareFunc{
+		authMiddleware.Authenticated,
+	}
+
+	return routes, middlewareFuncs
+}
+
+func CalendarRoutes(todoHandler handler.TodoHandler, middleware middlewares.Middleware, authMiddleware middlewares.AuthMiddleware) ([]route.Route, []echo.MiddlewareFunc) {
+	routes := []route.Route{
+		{
+			Method:      http.MethodGet,
+			Path:        "/calendar/feeds/:token",
+			Handler:     todoHandler.GetCalendarFeed,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/calendar/token",
+			Handler: todoHandler.CreateCalendarToken,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddleware.Authenticated,
+			},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/calendar/token",
+			Handler: todoHandler.DeleteCalendarToken,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddleware.Authenticated,
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/calendar/import",
+			Handler: todoHandler.ImportCalendar,
+			Middlewares: []echo.MiddlewareFunc{
+				authMiddleware.Authenticated,
+				authMiddleware.Verified,
+			},
+		},
+	}
+
+	var middlewareFuncs []echo.MiddlewareFunc
+
+	return routes, middlewareFuncs
+}
+
+func TagRoutes(tagHandler handler.TagHandler, middleware middlewares.Middleware, authMiddleware middlewares.AuthMiddleware) ([]route.Route, []echo.MiddlewareFunc) {
+	routes := []route.Route{
+		{
+			Method:      http.MethodGet,
+			Path:        "/tags",
+			Handler:     tagHandler.GetTags,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/tags/:id",
+			Handler: tagHandler.GetTagByID,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id"}),
+			},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/tags",
+			Handler:     tagHandler.CreateTag,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:  http.MethodPatch,
+			Path:    "/tags/:id",
+			Handler: tagHandler.UpdateTag,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id"}),
+			},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/tags/:id",
+			Handler: tagHandler.DeleteTag,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id"}),
+			},
+		},
+	}
+
+	middlewareFuncs := []echo.MiddlewareFunc{
+		authMiddleware.Authenticated,
+	}
+
+	return routes, middlewareFuncs
+}
+
+func ProjectRoutes(projectHandler handler.ProjectHandler, middleware middlewares.Middleware, authMiddleware middlewares.AuthMiddleware) ([]route.Route, []echo.MiddlewareFunc) {
+	routes := []route.Route{
+		{
+			Method:      http.MethodGet,
+			Path:        "/projects",
+			Handler:     projectHandler.GetProjects,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/projects/:id",
+			Handler: projectHandler.GetProjectByID,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id"}),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/projects/:id/todos",
+			Handler: projectHandler.GetProjectTodos,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id"}),
+			},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/projects",
+			Handler:     projectHandler.CreateProject,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:  http.MethodPatch,
+			Path:    "/projects/:id",
+			Handler: projectHandler.UpdateProject,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id"}),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/projects/:id/archive",
+			Handler: projectHandler.ArchiveProject,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id"}),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/projects/:id/unarchive",
+			Handler: projectHandler.UnarchiveProject,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id"}),
+			},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/projects/:id",
+			Handler: projectHandler.DeleteProject,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id"}),
+			},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/projects/invitations",
+			Handler:     projectHandler.GetInvitations,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/projects/:id/members",
+			Handler: projectHandler.GetMembers,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id"}),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/projects/:id/members",
+			Handler: projectHandler.InviteMember,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id"}),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/projects/:id/members/accept",
+			Handler: projectHandler.AcceptInvitation,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id"}),
+			},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/projects/:id/members/:user_id",
+			Handler: projectHandler.RevokeMember,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id", "user_id"}),
+			},
+		},
+	}
+
+	middlewareFuncs := []echo.MiddlewareFunc{
+		authMiddleware.Authenticated,
+	}
+
+	return routes, middlewareFuncs
+}
+
+func CommentRoutes(commentHandler handler.CommentHandler, middleware middlewares.Middleware, authMiddleware middlewares.AuthMiddleware) ([]route.Route, []echo.MiddlewareFunc) {
+	routes := []route.Route{
+		{
+			Method:  http.MethodGet,
+			Path:    "/todos/:id/comments",
+			Handler: commentHandler.GetComments,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id"}),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/todos/:id/comments",
+			Handler: commentHandler.CreateComment,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id"}),
+			},
+		},
+		{
+			Method:  http.MethodPatch,
+			Path:    "/todos/:id/comments/:comment_id",
+			Handler: commentHandler.UpdateComment,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id", "comment_id"}),
+			},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/todos/:id/comments/:comment_id",
+			Handler: commentHandler.DeleteComment,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id", "comment_id"}),
+			},
+		},
+	}
+
+	middlewareFuncs := []echo.MiddlewareFunc{
+		authMiddleware.Authenticated,
+	}
+
+	return routes, middlewareFuncs
+}
+
+func AttachmentRoutes(attachmentHandler handler.AttachmentHandler, middleware middlewares.Middleware, authMiddleware middlewares.AuthMiddleware, config configs.UploadConfig) ([]route.Route, []echo.MiddlewareFunc) {
+	routes := []route.Route{
+		{
+			Method:  http.MethodGet,
+			Path:    "/todos/:id/attachments",
+			Handler: attachmentHandler.GetAttachments,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id"}),
+			},
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/todos/:id/attachments",
+			Handler: attachmentHandler.CreateAttachment,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id"}),
+				middleware.LimitUpload("file", config.MaxSize, config.AllowedTypes),
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/todos/:id/attachments/:attachment_id/url",
+			Handler: attachmentHandler.GetAttachmentURL,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id", "attachment_id"}),
+			},
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/todos/:id/attachments/:attachment_id",
+			Handler: attachmentHandler.DeleteAttachment,
+			Middlewares: []echo.MiddlewareFunc{
+				middleware.ValidateUUID([]string{"id", "attachment_id"}),
+			},
+		},
+	}
+
+	middlewareFuncs := []echo.MiddlewareFunc{
+		authMiddleware.Authenticated,
+	}
+
+	return routes, middlewareFuncs
+}
+
+// BlobRoutes serves the local blob store. The signature in the URL stands in
+// for authentication.
+func BlobRoutes(blobHandler handler.BlobHandler) ([]route.Route, []echo.MiddlewareFunc) {
+	routes := []route.Route{
+		{
+			Method:      http.MethodGet,
+			Path:        "/blobs/*",
+			Handler:     blobHandler.GetBlob,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+	}
+
+	return routes, []echo.MiddlewareFunc{}
+}
+
+func NotificationRoutes(notificationHandler handler.NotificationHandler, middleware middlewares.Middleware, authMiddleware middlewares.AuthMiddleware) ([]route.Route, []echo.MiddlewareFunc) {
+	routes := []route.Route{
+		{
+			Method:      http.MethodGet,
+			Path:        "/notifications",
+			Handler:     notificationHandler.GetNotifications,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/notifications/read",
+			Handler:     notificationHandler.MarkNotificationsRead,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
 	}
 
 	middlewareFuncs := []echo.MiddlewareFunc{
@@ -133,3 +721,27 @@ func AdminUserRoutes(userHandler handler.UserHandler, middleware middlewares.Mid
 	return routes, middlewareFuncs
 
 }
+
+func AdminMFARoutes(mfaHandler handler.MFAHandler, middleware middlewares.Middleware, authMiddleware middlewares.AuthMiddleware) ([]route.Route, []echo.MiddlewareFunc) {
+	routes := []route.Route{
+		{
+			Method:      http.MethodGet,
+			Path:        "/settings/mfa",
+			Handler:     mfaHandler.GetPolicy,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/settings/mfa",
+			Handler:     mfaHandler.UpdatePolicy,
+			Middlewares: []echo.MiddlewareFunc{},
+		},
+	}
+
+	middlewareFuncs := []echo.MiddlewareFunc{
+		authMiddleware.Authenticated,
+		authMiddleware.AuthLevel(2),
+	}
+
+	return routes, middlewareFuncs
+}
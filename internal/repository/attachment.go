@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"gorm.io/gorm"
+)
+
+type AttachmentRepository interface {
+	BaseRepository
+	GetAttachmentsByTodoID(ctx context.Context, tx *gorm.DB, todoID string) ([]entity.Attachment, error)
+	GetAttachmentByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Attachment, error)
+	GetOrphanedAttachments(ctx context.Context, tx *gorm.DB, limit int) ([]entity.Attachment, error)
+	CreateAttachment(ctx context.Context, tx *gorm.DB, attachment *entity.Attachment) error
+	DeleteAttachment(ctx context.Context, tx *gorm.DB, attachment *entity.Attachment) error
+}
+
+type attachmentRepository struct {
+	baseRepository
+}
+
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return &attachmentRepository{baseRepository{db}}
+}
+
+func (r *attachmentRepository) GetAttachmentsByTodoID(ctx context.Context, tx *gorm.DB, todoID string) ([]entity.Attachment, error) {
+	var attachments []entity.Attachment
+
+	if err := tx.WithContext(ctx).Where("todo_id = ?", todoID).Order("created_at, id").Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (r *attachmentRepository) GetAttachmentByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Attachment, error) {
+	var attachment entity.Attachment
+
+	if err := tx.WithContext(ctx).Where("id = ?", id).First(&attachment).Error; err != nil {
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+// GetOrphanedAttachments returns attachments whose todo has been purged.
+func (r *attachmentRepository) GetOrphanedAttachments(ctx context.Context, tx *gorm.DB, limit int) ([]entity.Attachment, error) {
+	var attachments []entity.Attachment
+
+	if err := tx.WithContext(ctx).Where("todo_id IS NULL").Order("id").Limit(limit).Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (r *attachmentRepository) CreateAttachment(ctx context.Context, tx *gorm.DB, attachment *entity.Attachment) error {
+	if err := tx.WithContext(ctx).Create(attachment).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *attachmentRepository) DeleteAttachment(ctx context.Context, tx *gorm.DB, attachment *entity.Attachment) error {
+	if err := tx.WithContext(ctx).Delete(attachment).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type AttachmentTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	mock sqlmock.Sqlmock
+	repo repository.AttachmentRepository
+}
+
+func TestAttachmentRepository(t *testing.T) {
+	suite.Run(t, new(AttachmentTestSuite))
+}
+
+func (s *AttachmentTestSuite) SetupSuite() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.FailNow("Failed to create mock db", err.Error())
+	}
+
+	s.db, err = gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		s.FailNow("Failed to open mock db", err)
+	}
+
+	s.mock = mock
+	s.repo = repository.NewAttachmentRepository(s.db)
+}
+
+func (s *AttachmentTestSuite) AfterTest(string, string) {
+	if err := s.mock.ExpectationsWereMet(); err != nil {
+		s.FailNow("Failed to meet expectations", err)
+	}
+}
+
+func (s *AttachmentTestSuite) TestGetAttachmentsByTodoID() {
+	todoID := uuid.NewString()
+
+	s.Run("Get attachments successfully", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "attachments" WHERE todo_id = $1 ORDER BY created_at, id`)).
+			WithArgs(todoID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "todo_id", "file_name"}).
+				AddRow(uuid.NewString(), todoID, "report.pdf"))
+
+		result, err := s.repo.GetAttachmentsByTodoID(context.Background(), s.db, todoID)
+		s.Nil(err)
+		s.Len(result, 1)
+		s.Equal("report.pdf", result[0].FileName)
+	})
+}
+
+func (s *AttachmentTestSuite) TestGetAttachmentByID() {
+	s.Run("Attachment not found", func() {
+		attachmentID := uuid.NewString()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "attachments" WHERE id = $1 ORDER BY "attachments"."id" LIMIT $2`)).
+			WithArgs(attachmentID, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		result, err := s.repo.GetAttachmentByID(context.Background(), s.db, attachmentID)
+		s.ErrorIs(err, gorm.ErrRecordNotFound)
+		s.Nil(result)
+	})
+}
+
+func (s *AttachmentTestSuite) TestGetOrphanedAttachments() {
+	s.Run("Get orphaned attachments successfully", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "attachments" WHERE todo_id IS NULL ORDER BY id LIMIT $1`)).
+			WithArgs(100).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "storage_key"}).
+				AddRow(uuid.NewString(), "attachments/1"))
+
+		result, err := s.repo.GetOrphanedAttachments(context.Background(), s.db, 100)
+		s.Nil(err)
+		s.Len(result, 1)
+		s.Equal("attachments/1", result[0].StorageKey)
+	})
+}
+
+func (s *AttachmentTestSuite) TestCreateAttachment() {
+	s.Run("Failed to create attachment", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "attachments"`)).
+			WillReturnError(gorm.ErrInvalidData)
+		s.mock.ExpectRollback()
+
+		err := s.repo.CreateAttachment(context.Background(), s.db, &entity.Attachment{})
+		s.ErrorIs(err, gorm.ErrInvalidData)
+	})
+}
+
+func (s *AttachmentTestSuite) TestDeleteAttachment() {
+	s.Run("Delete attachment successfully", func() {
+		attachment := &entity.Attachment{}
+		attachment.ID = uuid.Must(uuid.NewV7())
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "attachments" WHERE "attachments"."id" = $1`)).
+			WithArgs(attachment.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectCommit()
+
+		err := s.repo.DeleteAttachment(context.Background(), s.db, attachment)
+		s.Nil(err)
+	})
+}
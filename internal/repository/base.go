@@ -1,9 +1,25 @@
 package repository
 
-import "gorm.io/gorm"
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrVersionConflict is returned by conditional writes when the row changed
+// after it was read.
+var ErrVersionConflict = errors.New("version conflict")
+
+type versioned interface {
+	CurrentVersion() int
+	SetVersion(version int)
+}
 
 type BaseRepository interface {
 	WithTransaction(fn func(tx *gorm.DB) error) error
+	WithSavepoint(tx *gorm.DB, fn func(tx *gorm.DB) error) error
 	SingleTransaction() *gorm.DB
 	BeginTransaction() *gorm.DB
 	Commit(tx *gorm.DB) error
@@ -18,6 +34,12 @@ func (r *baseRepository) WithTransaction(fn func(tx *gorm.DB) error) error {
 	return r.db.Transaction(fn)
 }
 
+// WithSavepoint runs fn under a savepoint of tx. When fn fails only its own
+// writes are rolled back, and tx can carry on.
+func (r *baseRepository) WithSavepoint(tx *gorm.DB, fn func(tx *gorm.DB) error) error {
+	return tx.Transaction(fn)
+}
+
 func (r *baseRepository) SingleTransaction() *gorm.DB {
 	return r.db
 }
@@ -33,3 +55,34 @@ func (r *baseRepository) Commit(tx *gorm.DB) error {
 func (r *baseRepository) Rollback(tx *gorm.DB) {
 	tx.Rollback()
 }
+
+// updateVersioned saves every column of model only if the stored version still
+// matches the one it was read with, and bumps the version.
+func updateVersioned(ctx context.Context, tx *gorm.DB, model versioned) error {
+	version := model.CurrentVersion()
+	model.SetVersion(version + 1)
+
+	result := tx.WithContext(ctx).Model(model).Where("version = ?", version).Select("*").Omit(clause.Associations).Updates(model)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrVersionConflict
+	}
+	if result.Error != nil {
+		model.SetVersion(version)
+		return result.Error
+	}
+
+	return nil
+}
+
+// deleteVersioned deletes model only if the stored version still matches.
+func deleteVersioned(ctx context.Context, tx *gorm.DB, model versioned) error {
+	result := tx.WithContext(ctx).Where("version = ?", model.CurrentVersion()).Delete(model)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+
+	return nil
+}
@@ -85,6 +85,27 @@ func (s *BaseTestSuite) TestWithTransaction() {
 	})
 }
 
+func (s *BaseTestSuite) TestWithSavepoint() {
+	s.Run("Roll back to savepoint and carry on", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec("SAVEPOINT sp").WillReturnResult(sqlmock.NewResult(0, 0))
+		s.mock.ExpectExec("ROLLBACK TO SAVEPOINT sp").WillReturnResult(sqlmock.NewResult(0, 0))
+		s.mock.ExpectExec("SAVEPOINT sp").WillReturnResult(sqlmock.NewResult(0, 0))
+		s.mock.ExpectCommit()
+		err := s.repo.WithTransaction(func(tx *gorm.DB) error {
+			err := s.repo.WithSavepoint(tx, func(tx *gorm.DB) error {
+				return gorm.ErrInvalidData
+			})
+			s.ErrorAs(err, &gorm.ErrInvalidData)
+
+			return s.repo.WithSavepoint(tx, func(tx *gorm.DB) error {
+				return nil
+			})
+		})
+		s.Nil(err)
+	})
+}
+
 func (s *BaseTestSuite) TestControlledTransaction() {
 	s.Run("Failed to start transaction", func() {
 		s.mock.ExpectBegin().WillReturnError(gorm.ErrInvalidTransaction)
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CommentRepository interface {
+	BaseRepository
+	GetCommentsByTodoID(ctx context.Context, tx *gorm.DB, todoID string, limit int, offset int) ([]entity.Comment, error)
+	CountComments(ctx context.Context, tx *gorm.DB, todoID string) (int64, error)
+	GetCommentByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Comment, error)
+	CreateComment(ctx context.Context, tx *gorm.DB, comment *entity.Comment) error
+	UpdateComment(ctx context.Context, tx *gorm.DB, comment *entity.Comment) error
+	DeleteComment(ctx context.Context, tx *gorm.DB, comment *entity.Comment) error
+}
+
+type commentRepository struct {
+	baseRepository
+}
+
+func NewCommentRepository(db *gorm.DB) CommentRepository {
+	return &commentRepository{baseRepository{db}}
+}
+
+func (r *commentRepository) GetCommentsByTodoID(ctx context.Context, tx *gorm.DB, todoID string, limit int, offset int) ([]entity.Comment, error) {
+	var comments []entity.Comment
+
+	if err := tx.WithContext(ctx).Preload("Author").Where("todo_id = ?", todoID).Order("created_at, id").Limit(limit).Offset(offset).Find(&comments).Error; err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func (r *commentRepository) CountComments(ctx context.Context, tx *gorm.DB, todoID string) (int64, error) {
+	var count int64
+
+	if err := tx.WithContext(ctx).Model(&entity.Comment{}).Where("todo_id = ?", todoID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *commentRepository) GetCommentByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Comment, error) {
+	var comment entity.Comment
+
+	if err := tx.WithContext(ctx).Preload("Author").Where("id = ?", id).First(&comment).Error; err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+func (r *commentRepository) CreateComment(ctx context.Context, tx *gorm.DB, comment *entity.Comment) error {
+	if err := tx.WithContext(ctx).Omit(clause.Associations).Create(comment).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *commentRepository) UpdateComment(ctx context.Context, tx *gorm.DB, comment *entity.Comment) error {
+	if err := tx.WithContext(ctx).Omit(clause.Associations).Save(comment).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *commentRepository) DeleteComment(ctx context.Context, tx *gorm.DB, comment *entity.Comment) error {
+	if err := tx.WithContext(ctx).Delete(comment).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type CommentTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	mock sqlmock.Sqlmock
+	repo repository.CommentRepository
+}
+
+func TestCommentRepository(t *testing.T) {
+	suite.Run(t, new(CommentTestSuite))
+}
+
+func (s *CommentTestSuite) SetupSuite() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.FailNow("Failed to create mock db", err.Error())
+	}
+
+	s.db, err = gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		s.FailNow("Failed to open mock db", err)
+	}
+
+	s.mock = mock
+	s.repo = repository.NewCommentRepository(s.db)
+}
+
+func (s *CommentTestSuite) AfterTest(string, string) {
+	if err := s.mock.ExpectationsWereMet(); err != nil {
+		s.FailNow("Failed to meet expectations", err)
+	}
+}
+
+func (s *CommentTestSuite) TestGetCommentsByTodoID() {
+	todoID := uuid.NewString()
+
+	s.Run("Failed to get comments", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "comments" WHERE todo_id = $1 ORDER BY created_at, id LIMIT $2`)).
+			WithArgs(todoID, 10).
+			WillReturnError(gorm.ErrInvalidData)
+
+		result, err := s.repo.GetCommentsByTodoID(context.Background(), s.db, todoID, 10, 0)
+		s.ErrorIs(err, gorm.ErrInvalidData)
+		s.Nil(result)
+	})
+
+	s.Run("Get comments with author successfully", func() {
+		commentID := uuid.NewString()
+		authorID := uuid.NewString()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "comments" WHERE todo_id = $1 ORDER BY created_at, id LIMIT $2 OFFSET $3`)).
+			WithArgs(todoID, 10, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "todo_id", "user_id", "body"}).
+				AddRow(commentID, todoID, authorID, "Looks good"))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE "users"."id" = $1`)).
+			WithArgs(authorID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).
+				AddRow(authorID, "alice"))
+
+		result, err := s.repo.GetCommentsByTodoID(context.Background(), s.db, todoID, 10, 10)
+		s.Nil(err)
+		s.Len(result, 1)
+		s.Equal("alice", result[0].Author.Username)
+	})
+}
+
+func (s *CommentTestSuite) TestCountComments() {
+	todoID := uuid.NewString()
+
+	s.Run("Count comments successfully", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "comments" WHERE todo_id = $1`)).
+			WithArgs(todoID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		result, err := s.repo.CountComments(context.Background(), s.db, todoID)
+		s.Nil(err)
+		s.Equal(int64(3), result)
+	})
+}
+
+func (s *CommentTestSuite) TestGetCommentByID() {
+	s.Run("Comment not found", func() {
+		commentID := uuid.NewString()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "comments" WHERE id = $1 ORDER BY "comments"."id" LIMIT $2`)).
+			WithArgs(commentID, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		result, err := s.repo.GetCommentByID(context.Background(), s.db, commentID)
+		s.ErrorIs(err, gorm.ErrRecordNotFound)
+		s.Nil(result)
+	})
+}
+
+func (s *CommentTestSuite) TestCreateComment() {
+	s.Run("Create comment successfully", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "comments"`)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectCommit()
+
+		err := s.repo.CreateComment(context.Background(), s.db, &entity.Comment{Author: &entity.UserProfile{}})
+		s.Nil(err)
+	})
+}
+
+func (s *CommentTestSuite) TestUpdateComment() {
+	s.Run("Update comment successfully", func() {
+		comment := &entity.Comment{Body: "Edited"}
+		comment.ID = uuid.Must(uuid.NewV7())
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "comments" SET`)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectCommit()
+
+		err := s.repo.UpdateComment(context.Background(), s.db, comment)
+		s.Nil(err)
+	})
+}
+
+func (s *CommentTestSuite) TestDeleteComment() {
+	s.Run("Delete comment successfully", func() {
+		comment := &entity.Comment{}
+		comment.ID = uuid.Must(uuid.NewV7())
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "comments" WHERE "comments"."id" = $1`)).
+			WithArgs(comment.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectCommit()
+
+		err := s.repo.DeleteComment(context.Background(), s.db, comment)
+		s.Nil(err)
+	})
+}
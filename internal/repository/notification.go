@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"gorm.io/gorm"
+)
+
+type NotificationRepository interface {
+	BaseRepository
+	GetNotificationsByUserID(ctx context.Context, tx *gorm.DB, userID string, unread bool, limit int, offset int) ([]entity.Notification, error)
+	CountNotifications(ctx context.Context, tx *gorm.DB, userID string, unread bool) (int64, error)
+	CreateNotifications(ctx context.Context, tx *gorm.DB, notifications []entity.Notification) error
+	MarkRead(ctx context.Context, tx *gorm.DB, userID string, ids []string, readAt time.Time) error
+}
+
+type notificationRepository struct {
+	baseRepository
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{baseRepository{db}}
+}
+
+// notificationScope selects the user's notifications, or only the unread ones.
+func notificationScope(tx *gorm.DB, userID string, unread bool) *gorm.DB {
+	tx = tx.Where("user_id = ?", userID)
+	if unread {
+		tx = tx.Where("read_at IS NULL")
+	}
+	return tx
+}
+
+func (r *notificationRepository) GetNotificationsByUserID(ctx context.Context, tx *gorm.DB, userID string, unread bool, limit int, offset int) ([]entity.Notification, error) {
+	var notifications []entity.Notification
+
+	if err := notificationScope(tx.WithContext(ctx), userID, unread).Order("created_at DESC, id").Limit(limit).Offset(offset).Find(&notifications).Error; err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (r *notificationRepository) CountNotifications(ctx context.Context, tx *gorm.DB, userID string, unread bool) (int64, error) {
+	var count int64
+
+	if err := notificationScope(tx.WithContext(ctx).Model(&entity.Notification{}), userID, unread).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *notificationRepository) CreateNotifications(ctx context.Context, tx *gorm.DB, notifications []entity.Notification) error {
+	if err := tx.WithContext(ctx).Create(&notifications).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// MarkRead marks the given notifications of the user as read, or all of them
+// when ids is empty. Notifications read earlier keep their read time.
+func (r *notificationRepository) MarkRead(ctx context.Context, tx *gorm.DB, userID string, ids []string, readAt time.Time) error {
+	query := tx.WithContext(ctx).Model(&entity.Notification{}).Where("user_id = ? AND read_at IS NULL", userID)
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+
+	if err := query.UpdateColumn("read_at", readAt).Error; err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type NotificationTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	mock sqlmock.Sqlmock
+	repo repository.NotificationRepository
+}
+
+func TestNotificationRepository(t *testing.T) {
+	suite.Run(t, new(NotificationTestSuite))
+}
+
+func (s *NotificationTestSuite) SetupSuite() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.FailNow("Failed to create mock db", err.Error())
+	}
+
+	s.db, err = gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		s.FailNow("Failed to open mock db", err)
+	}
+
+	s.mock = mock
+	s.repo = repository.NewNotificationRepository(s.db)
+}
+
+func (s *NotificationTestSuite) AfterTest(string, string) {
+	if err := s.mock.ExpectationsWereMet(); err != nil {
+		s.FailNow("Failed to meet expectations", err)
+	}
+}
+
+func (s *NotificationTestSuite) TestGetNotificationsByUserID() {
+	userID := uuid.NewString()
+
+	s.Run("Failed to get notifications", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "notifications" WHERE user_id = $1 ORDER BY created_at DESC, id LIMIT $2`)).
+			WithArgs(userID, 10).
+			WillReturnError(gorm.ErrInvalidData)
+
+		result, err := s.repo.GetNotificationsByUserID(context.Background(), s.db, userID, false, 10, 0)
+		s.ErrorIs(err, gorm.ErrInvalidData)
+		s.Nil(result)
+	})
+
+	s.Run("Get unread notifications successfully", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "notifications" WHERE user_id = $1 AND read_at IS NULL ORDER BY created_at DESC, id LIMIT $2 OFFSET $3`)).
+			WithArgs(userID, 10, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "type"}).
+				AddRow(uuid.NewString(), userID, entity.NotificationTodoAssigned))
+
+		result, err := s.repo.GetNotificationsByUserID(context.Background(), s.db, userID, true, 10, 10)
+		s.Nil(err)
+		s.Len(result, 1)
+	})
+}
+
+func (s *NotificationTestSuite) TestCountNotifications() {
+	userID := uuid.NewString()
+
+	s.Run("Count unread notifications successfully", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "notifications" WHERE user_id = $1 AND read_at IS NULL`)).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		result, err := s.repo.CountNotifications(context.Background(), s.db, userID, true)
+		s.Nil(err)
+		s.Equal(int64(3), result)
+	})
+}
+
+func (s *NotificationTestSuite) TestCreateNotifications() {
+	s.Run("Create notifications successfully", func() {
+		notifications := []entity.Notification{
+			{UserID: uuid.New(), Type: entity.NotificationTodoAssigned},
+			{UserID: uuid.New(), Type: entity.NotificationTodoUnassigned},
+		}
+
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "notifications"`)).
+			WillReturnResult(sqlmock.NewResult(1, 2))
+		s.mock.ExpectCommit()
+
+		err := s.repo.CreateNotifications(context.Background(), s.db, notifications)
+		s.Nil(err)
+	})
+}
+
+func (s *NotificationTestSuite) TestMarkRead() {
+	userID := uuid.NewString()
+	readAt := time.Now()
+
+	s.Run("Failed to mark notifications read", func() {
+		id := uuid.NewString()
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "notifications" SET "read_at"=$1 WHERE (user_id = $2 AND read_at IS NULL) AND id IN ($3)`)).
+			WithArgs(readAt, userID, id).
+			WillReturnError(gorm.ErrInvalidData)
+		s.mock.ExpectRollback()
+
+		err := s.repo.MarkRead(context.Background(), s.db, userID, []string{id}, readAt)
+		s.ErrorIs(err, gorm.ErrInvalidData)
+	})
+
+	s.Run("Mark all notifications read successfully", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "notifications" SET "read_at"=$1 WHERE user_id = $2 AND read_at IS NULL`)).
+			WithArgs(readAt, userID).
+			WillReturnResult(sqlmock.NewResult(1, 4))
+		s.mock.ExpectCommit()
+
+		err := s.repo.MarkRead(context.Background(), s.db, userID, nil, readAt)
+		s.Nil(err)
+	})
+}
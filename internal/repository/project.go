@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProjectRepository interface {
+	BaseRepository
+	GetProjectsByUserID(ctx context.Context, tx *gorm.DB, userID string) ([]entity.Project, error)
+	GetProjectByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Project, error)
+	GetProjectTodoIDs(ctx context.Context, tx *gorm.DB, project *entity.Project) ([]string, error)
+	CreateProject(ctx context.Context, tx *gorm.DB, project *entity.Project) error
+	UpdateProject(ctx context.Context, tx *gorm.DB, project *entity.Project) error
+	DeleteProject(ctx context.Context, tx *gorm.DB, project *entity.Project) error
+	MoveTodosToInbox(ctx context.Context, tx *gorm.DB, project *entity.Project) error
+	DeleteProjectTodos(ctx context.Context, tx *gorm.DB, project *entity.Project) error
+	GetMembers(ctx context.Context, tx *gorm.DB, projectID string) ([]entity.ProjectMember, error)
+	GetMember(ctx context.Context, tx *gorm.DB, projectID string, userID string) (*entity.ProjectMember, error)
+	GetMemberUserIDs(ctx context.Context, tx *gorm.DB, projectID string) ([]string, error)
+	GetInvitations(ctx context.Context, tx *gorm.DB, userID string) ([]entity.ProjectMember, error)
+	CreateMember(ctx context.Context, tx *gorm.DB, member *entity.ProjectMember) error
+	UpdateMember(ctx context.Context, tx *gorm.DB, member *entity.ProjectMember) error
+	DeleteMember(ctx context.Context, tx *gorm.DB, member *entity.ProjectMember) error
+}
+
+type projectRepository struct {
+	baseRepository
+}
+
+func NewProjectRepository(db *gorm.DB) ProjectRepository {
+	return &projectRepository{baseRepository{db}}
+}
+
+// GetProjectsByUserID returns the projects the user created or has accepted an invitation to.
+func (r *projectRepository) GetProjectsByUserID(ctx context.Context, tx *gorm.DB, userID string) ([]entity.Project, error) {
+	var projects []entity.Project
+	if err := tx.WithContext(ctx).Order("created_at").Find(&projects, "user_id = ? OR id IN (SELECT project_id FROM project_members WHERE user_id = ? AND accepted_at IS NOT NULL)", userID, userID).Error; err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+func (r *projectRepository) GetProjectByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Project, error) {
+	var project entity.Project
+	if err := tx.WithContext(ctx).First(&project, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (r *projectRepository) GetProjectTodoIDs(ctx context.Context, tx *gorm.DB, project *entity.Project) ([]string, error) {
+	var ids []string
+	if err := tx.WithContext(ctx).Model(&entity.Todo{}).Where("project_id = ?", project.ID).Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *projectRepository) CreateProject(ctx context.Context, tx *gorm.DB, project *entity.Project) error {
+	if err := tx.WithContext(ctx).Create(project).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *projectRepository) UpdateProject(ctx context.Context, tx *gorm.DB, project *entity.Project) error {
+	if err := tx.WithContext(ctx).Save(project).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *projectRepository) DeleteProject(ctx context.Context, tx *gorm.DB, project *entity.Project) error {
+	if err := tx.WithContext(ctx).Delete(project).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *projectRepository) MoveTodosToInbox(ctx context.Context, tx *gorm.DB, project *entity.Project) error {
+	if err := tx.WithContext(ctx).Model(&entity.Todo{}).Where("project_id = ?", project.ID).Updates(map[string]interface{}{
+		"project_id": nil,
+		"version":    gorm.Expr("version + 1"),
+	}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *projectRepository) DeleteProjectTodos(ctx context.Context, tx *gorm.DB, project *entity.Project) error {
+	if err := tx.WithContext(ctx).Where("project_id = ?", project.ID).Delete(&entity.Todo{}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *projectRepository) GetMembers(ctx context.Context, tx *gorm.DB, projectID string) ([]entity.ProjectMember, error) {
+	var members []entity.ProjectMember
+	if err := tx.WithContext(ctx).Preload("User").Order("created_at").Find(&members, "project_id = ?", projectID).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *projectRepository) GetMember(ctx context.Context, tx *gorm.DB, projectID string, userID string) (*entity.ProjectMember, error) {
+	var member entity.ProjectMember
+	if err := tx.WithContext(ctx).First(&member, "project_id = ? AND user_id = ?", projectID, userID).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// GetMemberUserIDs returns the IDs of everyone who can see the project: its
+// creator and the members who accepted their invitation.
+func (r *projectRepository) GetMemberUserIDs(ctx context.Context, tx *gorm.DB, projectID string) ([]string, error) {
+	var ids []string
+	if err := tx.WithContext(ctx).Raw(
+		"SELECT user_id FROM projects WHERE id = ? UNION SELECT user_id FROM project_members WHERE project_id = ? AND accepted_at IS NOT NULL",
+		projectID, projectID,
+	).Scan(&ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// GetInvitations returns the user's pending invitations with their projects.
+func (r *projectRepository) GetInvitations(ctx context.Context, tx *gorm.DB, userID string) ([]entity.ProjectMember, error) {
+	var members []entity.ProjectMember
+	if err := tx.WithContext(ctx).Preload("Project").Order("created_at").Find(&members, "user_id = ? AND accepted_at IS NULL", userID).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *projectRepository) CreateMember(ctx context.Context, tx *gorm.DB, member *entity.ProjectMember) error {
+	if err := tx.WithContext(ctx).Create(member).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *projectRepository) UpdateMember(ctx context.Context, tx *gorm.DB, member *entity.ProjectMember) error {
+	if err := tx.WithContext(ctx).Omit(clause.Associations).Save(member).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *projectRepository) DeleteMember(ctx context.Context, tx *gorm.DB, member *entity.ProjectMember) error {
+	if err := tx.WithContext(ctx).Delete(member).Error; err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,383 @@
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type ProjectTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	mock sqlmock.Sqlmock
+	repo repository.ProjectRepository
+}
+
+func TestProjectRepository(t *testing.T) {
+	suite.Run(t, new(ProjectTestSuite))
+}
+
+func (s *ProjectTestSuite) SetupSuite() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.FailNow("Failed to create mock db", err.Error())
+	}
+
+	s.db, err = gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		s.FailNow("Failed to open mock db", err)
+	}
+
+	s.mock = mock
+	s.repo = repository.NewProjectRepository(s.db)
+}
+
+func (s *ProjectTestSuite) AfterTest(string, string) {
+	if err := s.mock.ExpectationsWereMet(); err != nil {
+		s.FailNow("Failed to meet expectations", err)
+	}
+}
+
+func (s *ProjectTestSuite) TestGetProjectsByUserID() {
+	userID := uuid.NewString()
+
+	s.Run("Failed to get projects", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "projects" WHERE user_id = $1 OR id IN (SELECT project_id FROM project_members WHERE user_id = $2 AND accepted_at IS NOT NULL) ORDER BY created_at`)).
+			WithArgs(userID, userID).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		result, err := s.repo.GetProjectsByUserID(context.Background(), s.db, userID)
+		s.ErrorAs(err, &gorm.ErrRecordNotFound)
+		s.Nil(result)
+	})
+
+	s.Run("Get projects successfully", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "projects" WHERE user_id = $1 OR id IN (SELECT project_id FROM project_members WHERE user_id = $2 AND accepted_at IS NOT NULL) ORDER BY created_at`)).
+			WithArgs(userID, userID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "user_id"}).
+				AddRow(uuid.NewString(), "Home", userID).
+				AddRow(uuid.NewString(), "Work", userID))
+
+		result, err := s.repo.GetProjectsByUserID(context.Background(), s.db, userID)
+		s.Nil(err)
+		s.Len(result, 2)
+	})
+}
+
+func (s *ProjectTestSuite) TestGetProjectByID() {
+	s.Run("Project not found", func() {
+		id := uuid.NewString()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "projects" WHERE id = $1 ORDER BY "projects"."id" LIMIT $2`)).
+			WithArgs(id, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		result, err := s.repo.GetProjectByID(context.Background(), s.db, id)
+		s.ErrorAs(err, &gorm.ErrRecordNotFound)
+		s.Nil(result)
+	})
+
+	s.Run("Get project successfully", func() {
+		id := uuid.NewString()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "projects" WHERE id = $1 ORDER BY "projects"."id" LIMIT $2`)).
+			WithArgs(id, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).
+				AddRow(id))
+
+		result, err := s.repo.GetProjectByID(context.Background(), s.db, id)
+		s.Nil(err)
+		s.NotNil(result)
+		s.Equal(id, result.ID.String())
+	})
+}
+
+func (s *ProjectTestSuite) TestGetProjectTodoIDs() {
+	project := &entity.Project{}
+	project.ID = uuid.Must(uuid.NewV7())
+
+	s.Run("Failed to get todo ids", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "todos" WHERE project_id = $1`)).
+			WithArgs(project.ID).
+			WillReturnError(gorm.ErrInvalidData)
+
+		result, err := s.repo.GetProjectTodoIDs(context.Background(), s.db, project)
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+		s.Nil(result)
+	})
+
+	s.Run("Get todo ids successfully", func() {
+		todoID := uuid.NewString()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "todos" WHERE project_id = $1`)).
+			WithArgs(project.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).
+				AddRow(todoID))
+
+		result, err := s.repo.GetProjectTodoIDs(context.Background(), s.db, project)
+		s.Nil(err)
+		s.Equal([]string{todoID}, result)
+	})
+}
+
+func (s *ProjectTestSuite) TestCreateProject() {
+	s.Run("Failed to create project", func() {
+		project := &entity.Project{}
+
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "projects"`)).
+			WillReturnError(gorm.ErrInvalidData)
+		s.mock.ExpectRollback()
+
+		err := s.repo.CreateProject(context.Background(), s.db, project)
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+	})
+
+	s.Run("Create project successfully", func() {
+		project := &entity.Project{}
+
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "projects"`)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectCommit()
+
+		err := s.repo.CreateProject(context.Background(), s.db, project)
+		s.Nil(err)
+	})
+}
+
+func (s *ProjectTestSuite) TestUpdateProject() {
+	s.Run("Failed to update project", func() {
+		project := &entity.Project{}
+		project.ID = uuid.Must(uuid.NewV7())
+
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "projects"`)).
+			WillReturnError(gorm.ErrInvalidData)
+		s.mock.ExpectRollback()
+
+		err := s.repo.UpdateProject(context.Background(), s.db, project)
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+	})
+
+	s.Run("Update project successfully", func() {
+		project := &entity.Project{}
+		project.ID = uuid.Must(uuid.NewV7())
+
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "projects"`)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectCommit()
+
+		err := s.repo.UpdateProject(context.Background(), s.db, project)
+		s.Nil(err)
+	})
+}
+
+func (s *ProjectTestSuite) TestDeleteProject() {
+	s.Run("Failed to delete project", func() {
+		project := &entity.Project{}
+		project.ID = uuid.Must(uuid.NewV7())
+
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "projects" WHERE "projects"."id" = $1`)).
+			WithArgs(project.ID).
+			WillReturnError(gorm.ErrInvalidData)
+		s.mock.ExpectRollback()
+
+		err := s.repo.DeleteProject(context.Background(), s.db, project)
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+	})
+
+	s.Run("Delete project successfully", func() {
+		project := &entity.Project{}
+		project.ID = uuid.Must(uuid.NewV7())
+
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "projects" WHERE "projects"."id" = $1`)).
+			WithArgs(project.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectCommit()
+
+		err := s.repo.DeleteProject(context.Background(), s.db, project)
+		s.Nil(err)
+	})
+}
+
+func (s *ProjectTestSuite) TestMoveTodosToInbox() {
+	project := &entity.Project{}
+	project.ID = uuid.Must(uuid.NewV7())
+
+	s.Run("Failed to move todos", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "todos" SET "project_id"=$1,"version"=version + 1,"updated_at"=$2 WHERE project_id = $3`)).
+			WithArgs(nil, sqlmock.AnyArg(), project.ID).
+			WillReturnError(gorm.ErrInvalidData)
+		s.mock.ExpectRollback()
+
+		err := s.repo.MoveTodosToInbox(context.Background(), s.db, project)
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+	})
+
+	s.Run("Move todos successfully", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "todos" SET "project_id"=$1,"version"=version + 1,"updated_at"=$2 WHERE project_id = $3`)).
+			WithArgs(nil, sqlmock.AnyArg(), project.ID).
+			WillReturnResult(sqlmock.NewResult(1, 2))
+		s.mock.ExpectCommit()
+
+		err := s.repo.MoveTodosToInbox(context.Background(), s.db, project)
+		s.Nil(err)
+	})
+}
+
+func (s *ProjectTestSuite) TestDeleteProjectTodos() {
+	project := &entity.Project{}
+	project.ID = uuid.Must(uuid.NewV7())
+
+	s.Run("Failed to delete todos", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "todos" SET "deleted_at"=$1 WHERE project_id = $2 AND "todos"."deleted_at" IS NULL`)).
+			WithArgs(sqlmock.AnyArg(), project.ID).
+			WillReturnError(gorm.ErrInvalidData)
+		s.mock.ExpectRollback()
+
+		err := s.repo.DeleteProjectTodos(context.Background(), s.db, project)
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+	})
+
+	s.Run("Delete todos successfully", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "todos" SET "deleted_at"=$1 WHERE project_id = $2 AND "todos"."deleted_at" IS NULL`)).
+			WithArgs(sqlmock.AnyArg(), project.ID).
+			WillReturnResult(sqlmock.NewResult(1, 2))
+		s.mock.ExpectCommit()
+
+		err := s.repo.DeleteProjectTodos(context.Background(), s.db, project)
+		s.Nil(err)
+	})
+}
+
+func (s *ProjectTestSuite) TestGetMember() {
+	projectID := uuid.NewString()
+	userID := uuid.NewString()
+	query := `SELECT * FROM "project_members" WHERE project_id = $1 AND user_id = $2 ORDER BY "project_members"."project_id" LIMIT $3`
+
+	s.Run("Member not found", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(projectID, userID, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		result, err := s.repo.GetMember(context.Background(), s.db, projectID, userID)
+		s.ErrorIs(err, gorm.ErrRecordNotFound)
+		s.Nil(result)
+	})
+
+	s.Run("Get member successfully", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(projectID, userID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"project_id", "user_id", "role"}).
+				AddRow(projectID, userID, "editor"))
+
+		result, err := s.repo.GetMember(context.Background(), s.db, projectID, userID)
+		s.Nil(err)
+		s.Equal(entity.ProjectEditor, result.Role)
+	})
+}
+
+func (s *ProjectTestSuite) TestGetMemberUserIDs() {
+	projectID := uuid.NewString()
+	query := `SELECT user_id FROM projects WHERE id = $1 UNION SELECT user_id FROM project_members WHERE project_id = $2 AND accepted_at IS NOT NULL`
+
+	s.Run("Failed to get member user ids", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(projectID, projectID).
+			WillReturnError(gorm.ErrInvalidData)
+
+		result, err := s.repo.GetMemberUserIDs(context.Background(), s.db, projectID)
+		s.ErrorIs(err, gorm.ErrInvalidData)
+		s.Nil(result)
+	})
+
+	s.Run("Get member user ids successfully", func() {
+		ownerID := uuid.NewString()
+		memberID := uuid.NewString()
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(projectID, projectID).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}).
+				AddRow(ownerID).
+				AddRow(memberID))
+
+		result, err := s.repo.GetMemberUserIDs(context.Background(), s.db, projectID)
+		s.Nil(err)
+		s.Equal([]string{ownerID, memberID}, result)
+	})
+}
+
+func (s *ProjectTestSuite) TestGetInvitations() {
+	userID := uuid.NewString()
+
+	s.Run("Failed to get invitations", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "project_members" WHERE user_id = $1 AND accepted_at IS NULL ORDER BY created_at`)).
+			WithArgs(userID).
+			WillReturnError(gorm.ErrInvalidData)
+
+		result, err := s.repo.GetInvitations(context.Background(), s.db, userID)
+		s.ErrorIs(err, gorm.ErrInvalidData)
+		s.Nil(result)
+	})
+
+	s.Run("Get invitations successfully", func() {
+		projectID := uuid.NewString()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "project_members" WHERE user_id = $1 AND accepted_at IS NULL ORDER BY created_at`)).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"project_id", "user_id", "role"}).
+				AddRow(projectID, userID, "viewer"))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "projects" WHERE "projects"."id" = $1`)).
+			WithArgs(projectID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+				AddRow(projectID, "Groceries"))
+
+		result, err := s.repo.GetInvitations(context.Background(), s.db, userID)
+		s.Nil(err)
+		s.Len(result, 1)
+		s.Equal("Groceries", result[0].Project.Name)
+	})
+}
+
+func (s *ProjectTestSuite) TestDeleteMember() {
+	member := &entity.ProjectMember{ProjectID: uuid.New(), UserID: uuid.New()}
+
+	s.Run("Failed to delete member", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "project_members" WHERE ("project_members"."project_id","project_members"."user_id") IN (($1,$2))`)).
+			WithArgs(member.ProjectID, member.UserID).
+			WillReturnError(gorm.ErrInvalidData)
+		s.mock.ExpectRollback()
+
+		err := s.repo.DeleteMember(context.Background(), s.db, member)
+		s.ErrorIs(err, gorm.ErrInvalidData)
+	})
+
+	s.Run("Delete member successfully", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "project_members" WHERE ("project_members"."project_id","project_members"."user_id") IN (($1,$2))`)).
+			WithArgs(member.ProjectID, member.UserID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectCommit()
+
+		err := s.repo.DeleteMember(context.Background(), s.db, member)
+		s.Nil(err)
+	})
+}
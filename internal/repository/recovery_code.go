@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RecoveryCodeRepository interface {
+	BaseRepository
+	GetRecoveryCode(ctx context.Context, tx *gorm.DB, userID string, codeHash string) (*entity.RecoveryCode, error)
+	CreateRecoveryCodes(ctx context.Context, tx *gorm.DB, codes []entity.RecoveryCode) error
+	DeleteRecoveryCode(ctx context.Context, tx *gorm.DB, code *entity.RecoveryCode) error
+	DeleteRecoveryCodes(ctx context.Context, tx *gorm.DB, userID string) error
+}
+
+type recoveryCodeRepository struct {
+	baseRepository
+}
+
+func NewRecoveryCodeRepository(db *gorm.DB) RecoveryCodeRepository {
+	return &recoveryCodeRepository{baseRepository{db}}
+}
+
+// GetRecoveryCode locks the code until the transaction ends, so it can only
+// be used once.
+func (r *recoveryCodeRepository) GetRecoveryCode(ctx context.Context, tx *gorm.DB, userID string, codeHash string) (*entity.RecoveryCode, error) {
+	var code entity.RecoveryCode
+
+	if err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ? AND code_hash = ?", userID, codeHash).First(&code).Error; err != nil {
+		return nil, err
+	}
+
+	return &code, nil
+}
+
+func (r *recoveryCodeRepository) CreateRecoveryCodes(ctx context.Context, tx *gorm.DB, codes []entity.RecoveryCode) error {
+	if err := tx.WithContext(ctx).Create(&codes).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *recoveryCodeRepository) DeleteRecoveryCode(ctx context.Context, tx *gorm.DB, code *entity.RecoveryCode) error {
+	if err := tx.WithContext(ctx).Delete(code).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteRecoveryCodes removes every recovery code of the user.
+func (r *recoveryCodeRepository) DeleteRecoveryCodes(ctx context.Context, tx *gorm.DB, userID string) error {
+	if err := tx.WithContext(ctx).Where("user_id = ?", userID).Delete(&entity.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type RecoveryCodeTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	mock sqlmock.Sqlmock
+	repo repository.RecoveryCodeRepository
+}
+
+func TestRecoveryCodeRepository(t *testing.T) {
+	suite.Run(t, new(RecoveryCodeTestSuite))
+}
+
+func (s *RecoveryCodeTestSuite) SetupSuite() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.FailNow("Failed to create mock db", err.Error())
+	}
+
+	s.db, err = gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		s.FailNow("Failed to open mock db", err)
+	}
+
+	s.mock = mock
+	s.repo = repository.NewRecoveryCodeRepository(s.db)
+}
+
+func (s *RecoveryCodeTestSuite) AfterTest(string, string) {
+	if err := s.mock.ExpectationsWereMet(); err != nil {
+		s.FailNow("Failed to meet expectations", err)
+	}
+}
+
+func (s *RecoveryCodeTestSuite) TestGetRecoveryCode() {
+	userID := uuid.NewString()
+
+	s.Run("Recovery code not found", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "recovery_codes" WHERE user_id = $1 AND code_hash = $2 ORDER BY "recovery_codes"."id" LIMIT $3 FOR UPDATE`)).
+			WithArgs(userID, "hash", 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		result, err := s.repo.GetRecoveryCode(context.Background(), s.db, userID, "hash")
+		s.ErrorIs(err, gorm.ErrRecordNotFound)
+		s.Nil(result)
+	})
+
+	s.Run("Get recovery code successfully", func() {
+		id := uuid.NewString()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "recovery_codes" WHERE user_id = $1 AND code_hash = $2 ORDER BY "recovery_codes"."id" LIMIT $3 FOR UPDATE`)).
+			WithArgs(userID, "hash", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "code_hash"}).AddRow(id, userID, "hash"))
+
+		result, err := s.repo.GetRecoveryCode(context.Background(), s.db, userID, "hash")
+		s.Nil(err)
+		s.Equal(id, result.ID.String())
+	})
+}
+
+func (s *RecoveryCodeTestSuite) TestCreateRecoveryCodes() {
+	s.Run("Create recovery codes successfully", func() {
+		userID := uuid.New()
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "recovery_codes"`)).
+			WillReturnResult(sqlmock.NewResult(2, 2))
+		s.mock.ExpectCommit()
+
+		err := s.repo.CreateRecoveryCodes(context.Background(), s.db, []entity.RecoveryCode{
+			{UserID: userID, CodeHash: "first"},
+			{UserID: userID, CodeHash: "second"},
+		})
+		s.Nil(err)
+	})
+}
+
+func (s *RecoveryCodeTestSuite) TestDeleteRecoveryCode() {
+	s.Run("Delete recovery code successfully", func() {
+		code := &entity.RecoveryCode{}
+		code.ID = uuid.New()
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "recovery_codes" WHERE "recovery_codes"."id" = $1`)).
+			WithArgs(code.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectCommit()
+
+		err := s.repo.DeleteRecoveryCode(context.Background(), s.db, code)
+		s.Nil(err)
+	})
+}
+
+func (s *RecoveryCodeTestSuite) TestDeleteRecoveryCodes() {
+	userID := uuid.NewString()
+
+	s.Run("Delete recovery codes successfully", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "recovery_codes" WHERE user_id = $1`)).
+			WithArgs(userID).
+			WillReturnResult(sqlmock.NewResult(10, 10))
+		s.mock.ExpectCommit()
+
+		err := s.repo.DeleteRecoveryCodes(context.Background(), s.db, userID)
+		s.Nil(err)
+	})
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RefreshTokenRepository interface {
+	BaseRepository
+	GetRefreshTokenByHash(ctx context.Context, tx *gorm.DB, tokenHash string) (*entity.RefreshToken, error)
+	CreateRefreshToken(ctx context.Context, tx *gorm.DB, token *entity.RefreshToken) error
+	UpdateRefreshToken(ctx context.Context, tx *gorm.DB, token *entity.RefreshToken) error
+	RevokeFamily(ctx context.Context, tx *gorm.DB, familyID string, revokedAt time.Time) error
+	RevokeUserRefreshTokens(ctx context.Context, tx *gorm.DB, userID string, revokedAt time.Time) error
+	DeleteExpiredRefreshTokens(ctx context.Context, tx *gorm.DB, userID string, now time.Time) error
+}
+
+type refreshTokenRepository struct {
+	baseRepository
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) RefreshTokenRepository {
+	return &refreshTokenRepository{baseRepository{db}}
+}
+
+// GetRefreshTokenByHash locks the token until the transaction ends, so two
+// requests cannot rotate the same token.
+func (r *refreshTokenRepository) GetRefreshTokenByHash(ctx context.Context, tx *gorm.DB, tokenHash string) (*entity.RefreshToken, error) {
+	var token entity.RefreshToken
+
+	if err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *refreshTokenRepository) CreateRefreshToken(ctx context.Context, tx *gorm.DB, token *entity.RefreshToken) error {
+	if err := tx.WithContext(ctx).Create(token).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *refreshTokenRepository) UpdateRefreshToken(ctx context.Context, tx *gorm.DB, token *entity.RefreshToken) error {
+	if err := tx.WithContext(ctx).Save(token).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RevokeFamily revokes every token issued from the same login. Tokens revoked
+// earlier keep their revocation time.
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, tx *gorm.DB, familyID string, revokedAt time.Time) error {
+	if err := tx.WithContext(ctx).Model(&entity.RefreshToken{}).Where("family_id = ? AND revoked_at IS NULL", familyID).UpdateColumn("revoked_at", revokedAt).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *refreshTokenRepository) RevokeUserRefreshTokens(ctx context.Context, tx *gorm.DB, userID string, revokedAt time.Time) error {
+	if err := tx.WithContext(ctx).Model(&entity.RefreshToken{}).Where("user_id = ? AND revoked_at IS NULL", userID).UpdateColumn("revoked_at", revokedAt).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteExpiredRefreshTokens removes the user's expired tokens. They are of no
+// use for reuse detection any more, since they would be refused anyway.
+func (r *refreshTokenRepository) DeleteExpiredRefreshTokens(ctx context.Context, tx *gorm.DB, userID string, now time.Time) error {
+	if err := tx.WithContext(ctx).Where("user_id = ? AND expires_at < ?", userID, now).Delete(&entity.RefreshToken{}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
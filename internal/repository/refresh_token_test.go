@@ -0,0 +1,142 @@
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type RefreshTokenTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	mock sqlmock.Sqlmock
+	repo repository.RefreshTokenRepository
+}
+
+func TestRefreshTokenRepository(t *testing.T) {
+	suite.Run(t, new(RefreshTokenTestSuite))
+}
+
+func (s *RefreshTokenTestSuite) SetupSuite() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.FailNow("Failed to create mock db", err.Error())
+	}
+
+	s.db, err = gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		s.FailNow("Failed to open mock db", err)
+	}
+
+	s.mock = mock
+	s.repo = repository.NewRefreshTokenRepository(s.db)
+}
+
+func (s *RefreshTokenTestSuite) AfterTest(string, string) {
+	if err := s.mock.ExpectationsWereMet(); err != nil {
+		s.FailNow("Failed to meet expectations", err)
+	}
+}
+
+func (s *RefreshTokenTestSuite) TestGetRefreshTokenByHash() {
+	s.Run("Refresh token not found", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "refresh_tokens" WHERE token_hash = $1 ORDER BY "refresh_tokens"."id" LIMIT $2 FOR UPDATE`)).
+			WithArgs("hash", 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		result, err := s.repo.GetRefreshTokenByHash(context.Background(), s.db, "hash")
+		s.ErrorIs(err, gorm.ErrRecordNotFound)
+		s.Nil(result)
+	})
+
+	s.Run("Get refresh token successfully", func() {
+		id := uuid.NewString()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "refresh_tokens" WHERE token_hash = $1 ORDER BY "refresh_tokens"."id" LIMIT $2 FOR UPDATE`)).
+			WithArgs("hash", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "token_hash"}).AddRow(id, "hash"))
+
+		result, err := s.repo.GetRefreshTokenByHash(context.Background(), s.db, "hash")
+		s.Nil(err)
+		s.Equal(id, result.ID.String())
+	})
+}
+
+func (s *RefreshTokenTestSuite) TestCreateRefreshToken() {
+	s.Run("Create refresh token successfully", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "refresh_tokens"`)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectCommit()
+
+		err := s.repo.CreateRefreshToken(context.Background(), s.db, &entity.RefreshToken{
+			UserID:    uuid.New(),
+			FamilyID:  uuid.New(),
+			TokenHash: "hash",
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+		s.Nil(err)
+	})
+}
+
+func (s *RefreshTokenTestSuite) TestRevokeFamily() {
+	familyID := uuid.NewString()
+	revokedAt := time.Now()
+
+	s.Run("Revoke family successfully", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "refresh_tokens" SET "revoked_at"=$1 WHERE family_id = $2 AND revoked_at IS NULL`)).
+			WithArgs(revokedAt, familyID).
+			WillReturnResult(sqlmock.NewResult(1, 3))
+		s.mock.ExpectCommit()
+
+		err := s.repo.RevokeFamily(context.Background(), s.db, familyID, revokedAt)
+		s.Nil(err)
+	})
+}
+
+func (s *RefreshTokenTestSuite) TestRevokeUserRefreshTokens() {
+	userID := uuid.NewString()
+	revokedAt := time.Now()
+
+	s.Run("Revoke user refresh tokens successfully", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "refresh_tokens" SET "revoked_at"=$1 WHERE user_id = $2 AND revoked_at IS NULL`)).
+			WithArgs(revokedAt, userID).
+			WillReturnResult(sqlmock.NewResult(1, 2))
+		s.mock.ExpectCommit()
+
+		err := s.repo.RevokeUserRefreshTokens(context.Background(), s.db, userID, revokedAt)
+		s.Nil(err)
+	})
+}
+
+func (s *RefreshTokenTestSuite) TestDeleteExpiredRefreshTokens() {
+	userID := uuid.NewString()
+	now := time.Now()
+
+	s.Run("Delete expired refresh tokens successfully", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "refresh_tokens" WHERE user_id = $1 AND expires_at < $2`)).
+			WithArgs(userID, now).
+			WillReturnResult(sqlmock.NewResult(1, 2))
+		s.mock.ExpectCommit()
+
+		err := s.repo.DeleteExpiredRefreshTokens(context.Background(), s.db, userID, now)
+		s.Nil(err)
+	})
+}
@@ -64,17 +64,9 @@ func (r *roleRepository) CreateRole(ctx context.Context, tx *gorm.DB, role *enti
 }
 
 func (r *roleRepository) UpdateRole(ctx context.Context, tx *gorm.DB, role *entity.Role) error {
-	if err := tx.WithContext(ctx).Save(role).Error; err != nil {
-		return err
-	}
-
-	return nil
+	return updateVersioned(ctx, tx, role)
 }
 
 func (r *roleRepository) DeleteRole(ctx context.Context, tx *gorm.DB, role *entity.Role) error {
-	if err := tx.WithContext(ctx).Delete(role).Error; err != nil {
-		return err
-	}
-
-	return nil
+	return deleteVersioned(ctx, tx, role)
 }
@@ -136,6 +136,20 @@ func (s *RoleTestSuite) TestUpdateRole() {
 		s.ErrorAs(err, &gorm.ErrInvalidData)
 	})
 
+	s.Run("Update stale role", func() {
+		role := &entity.Role{}
+		role.ID = uuid.Must(uuid.NewV7())
+		role.Version = 2
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "roles"`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		s.mock.ExpectCommit()
+
+		err := s.repo.UpdateRole(context.Background(), s.db, role)
+		s.ErrorIs(err, repository.ErrVersionConflict)
+		s.Equal(2, role.Version)
+	})
+
 	s.Run("Update role successfully", func() {
 		role := &entity.Role{}
 		role.ID = uuid.Must(uuid.NewV7())
@@ -154,8 +168,8 @@ func (s *RoleTestSuite) TestDeleteRole() {
 		role := &entity.Role{}
 		role.ID = uuid.Must(uuid.NewV7())
 		s.mock.ExpectBegin()
-		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "roles" WHERE "roles"."id" = $1`)).
-			WithArgs(role.ID).
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "roles" WHERE version = $1 AND "roles"."id" = $2`)).
+			WithArgs(role.Version, role.ID).
 			WillReturnError(gorm.ErrInvalidData)
 		s.mock.ExpectRollback()
 
@@ -163,12 +177,25 @@ func (s *RoleTestSuite) TestDeleteRole() {
 		s.ErrorAs(err, &gorm.ErrInvalidData)
 	})
 
+	s.Run("Delete stale role", func() {
+		role := &entity.Role{}
+		role.ID = uuid.Must(uuid.NewV7())
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "roles" WHERE version = $1 AND "roles"."id" = $2`)).
+			WithArgs(role.Version, role.ID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		s.mock.ExpectCommit()
+
+		err := s.repo.DeleteRole(context.Background(), s.db, role)
+		s.ErrorIs(err, repository.ErrVersionConflict)
+	})
+
 	s.Run("Delete role successfully", func() {
 		role := &entity.Role{}
 		role.ID = uuid.Must(uuid.NewV7())
 		s.mock.ExpectBegin()
-		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "roles" WHERE "roles"."id" = $1`)).
-			WithArgs(role.ID).
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "roles" WHERE version = $1 AND "roles"."id" = $2`)).
+			WithArgs(role.Version, role.ID).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 		s.mock.ExpectCommit()
 
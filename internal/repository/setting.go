@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SettingRepository interface {
+	BaseRepository
+	GetSetting(ctx context.Context, tx *gorm.DB, key string) (*entity.Setting, error)
+	SaveSetting(ctx context.Context, tx *gorm.DB, setting *entity.Setting) error
+}
+
+type settingRepository struct {
+	baseRepository
+}
+
+func NewSettingRepository(db *gorm.DB) SettingRepository {
+	return &settingRepository{baseRepository{db}}
+}
+
+func (r *settingRepository) GetSetting(ctx context.Context, tx *gorm.DB, key string) (*entity.Setting, error) {
+	var setting entity.Setting
+
+	if err := tx.WithContext(ctx).Where("key = ?", key).First(&setting).Error; err != nil {
+		return nil, err
+	}
+
+	return &setting, nil
+}
+
+// SaveSetting creates the setting or replaces the value of an existing one.
+func (r *settingRepository) SaveSetting(ctx context.Context, tx *gorm.DB, setting *entity.Setting) error {
+	if err := tx.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+	}).Create(setting).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
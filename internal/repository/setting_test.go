@@ -0,0 +1,87 @@
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type SettingTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	mock sqlmock.Sqlmock
+	repo repository.SettingRepository
+}
+
+func TestSettingRepository(t *testing.T) {
+	suite.Run(t, new(SettingTestSuite))
+}
+
+func (s *SettingTestSuite) SetupSuite() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.FailNow("Failed to create mock db", err.Error())
+	}
+
+	s.db, err = gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		s.FailNow("Failed to open mock db", err)
+	}
+
+	s.mock = mock
+	s.repo = repository.NewSettingRepository(s.db)
+}
+
+func (s *SettingTestSuite) AfterTest(string, string) {
+	if err := s.mock.ExpectationsWereMet(); err != nil {
+		s.FailNow("Failed to meet expectations", err)
+	}
+}
+
+func (s *SettingTestSuite) TestGetSetting() {
+	s.Run("Setting not found", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "settings" WHERE key = $1 ORDER BY "settings"."key" LIMIT $2`)).
+			WithArgs(entity.SettingMFARequiredLevel, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		result, err := s.repo.GetSetting(context.Background(), s.db, entity.SettingMFARequiredLevel)
+		s.ErrorIs(err, gorm.ErrRecordNotFound)
+		s.Nil(result)
+	})
+
+	s.Run("Get setting successfully", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "settings" WHERE key = $1 ORDER BY "settings"."key" LIMIT $2`)).
+			WithArgs(entity.SettingMFARequiredLevel, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"key", "value"}).AddRow(entity.SettingMFARequiredLevel, "2"))
+
+		result, err := s.repo.GetSetting(context.Background(), s.db, entity.SettingMFARequiredLevel)
+		s.Nil(err)
+		s.Equal("2", result.Value)
+	})
+}
+
+func (s *SettingTestSuite) TestSaveSetting() {
+	s.Run("Save setting successfully", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "settings" ("key","value","created_at","updated_at") VALUES ($1,$2,$3,$4) ON CONFLICT ("key") DO UPDATE SET "value"="excluded"."value","updated_at"="excluded"."updated_at"`)).
+			WithArgs(entity.SettingMFARequiredLevel, "2", sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectCommit()
+
+		err := s.repo.SaveSetting(context.Background(), s.db, &entity.Setting{Key: entity.SettingMFARequiredLevel, Value: "2"})
+		s.Nil(err)
+	})
+}
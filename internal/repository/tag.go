@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"gorm.io/gorm"
+)
+
+type TagRepository interface {
+	BaseRepository
+	GetTagsByUserID(ctx context.Context, tx *gorm.DB, userID string) ([]entity.Tag, error)
+	GetTagByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Tag, error)
+	GetTaggedTodoIDs(ctx context.Context, tx *gorm.DB, tag *entity.Tag) ([]string, error)
+	TouchTaggedTodos(ctx context.Context, tx *gorm.DB, tag *entity.Tag) error
+	CreateTag(ctx context.Context, tx *gorm.DB, tag *entity.Tag) error
+	UpdateTag(ctx context.Context, tx *gorm.DB, tag *entity.Tag) error
+	DeleteTag(ctx context.Context, tx *gorm.DB, tag *entity.Tag) error
+}
+
+type tagRepository struct {
+	baseRepository
+}
+
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{baseRepository{db}}
+}
+
+func (r *tagRepository) GetTagsByUserID(ctx context.Context, tx *gorm.DB, userID string) ([]entity.Tag, error) {
+	var tags []entity.Tag
+
+	if err := tx.WithContext(ctx).Order("name").Find(&tags, "user_id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (r *tagRepository) GetTagByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Tag, error) {
+	var tag entity.Tag
+
+	if err := tx.WithContext(ctx).Where("id = ?", id).First(&tag).Error; err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+func (r *tagRepository) GetTaggedTodoIDs(ctx context.Context, tx *gorm.DB, tag *entity.Tag) ([]string, error) {
+	var ids []string
+
+	if err := tx.WithContext(ctx).Table("tag_todos").Where("tag_id = ?", tag.ID).Pluck("todo_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// TouchTaggedTodos bumps the version of every todo carrying tag. Todos embed
+// their tags, so renaming or deleting one changes them too.
+func (r *tagRepository) TouchTaggedTodos(ctx context.Context, tx *gorm.DB, tag *entity.Tag) error {
+	if err := tx.WithContext(ctx).
+		Model(&entity.Todo{}).
+		Where("id IN (SELECT todo_id FROM tag_todos WHERE tag_id = ?)", tag.ID).
+		UpdateColumn("version", gorm.Expr("version + 1")).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *tagRepository) CreateTag(ctx context.Context, tx *gorm.DB, tag *entity.Tag) error {
+	if err := tx.WithContext(ctx).Create(tag).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *tagRepository) UpdateTag(ctx context.Context, tx *gorm.DB, tag *entity.Tag) error {
+	if err := tx.WithContext(ctx).Save(tag).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *tagRepository) DeleteTag(ctx context.Context, tx *gorm.DB, tag *entity.Tag) error {
+	if err := tx.WithContext(ctx).Delete(tag).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,233 @@
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type TagTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	mock sqlmock.Sqlmock
+	repo repository.TagRepository
+}
+
+func TestTagRepository(t *testing.T) {
+	suite.Run(t, new(TagTestSuite))
+}
+
+func (s *TagTestSuite) SetupSuite() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.FailNow("Failed to create mock db", err.Error())
+	}
+
+	s.db, err = gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		s.FailNow("Failed to open mock db", err)
+	}
+
+	s.mock = mock
+	s.repo = repository.NewTagRepository(s.db)
+}
+
+func (s *TagTestSuite) AfterTest(string, string) {
+	if err := s.mock.ExpectationsWereMet(); err != nil {
+		s.FailNow("Failed to meet expectations", err)
+	}
+}
+
+func (s *TagTestSuite) TestGetTagsByUserID() {
+	userID := uuid.NewString()
+
+	s.Run("Failed to get tags", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tags" WHERE user_id = $1 ORDER BY name`)).
+			WithArgs(userID).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		result, err := s.repo.GetTagsByUserID(context.Background(), s.db, userID)
+		s.ErrorAs(err, &gorm.ErrRecordNotFound)
+		s.Nil(result)
+	})
+
+	s.Run("Get tags successfully", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tags" WHERE user_id = $1 ORDER BY name`)).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+				AddRow(uuid.NewString(), "home").
+				AddRow(uuid.NewString(), "work"))
+
+		result, err := s.repo.GetTagsByUserID(context.Background(), s.db, userID)
+		s.Nil(err)
+		s.Len(result, 2)
+	})
+}
+
+func (s *TagTestSuite) TestGetTagByID() {
+	id := uuid.NewString()
+
+	s.Run("Tag not found", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tags" WHERE id = $1 ORDER BY "tags"."id" LIMIT $2`)).
+			WithArgs(id, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		result, err := s.repo.GetTagByID(context.Background(), s.db, id)
+		s.ErrorAs(err, &gorm.ErrRecordNotFound)
+		s.Nil(result)
+	})
+
+	s.Run("Get tag successfully", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tags" WHERE id = $1 ORDER BY "tags"."id" LIMIT $2`)).
+			WithArgs(id, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(id, "work"))
+
+		result, err := s.repo.GetTagByID(context.Background(), s.db, id)
+		s.Nil(err)
+		s.Equal(id, result.ID.String())
+	})
+}
+
+func (s *TagTestSuite) TestGetTaggedTodoIDs() {
+	tag := &entity.Tag{}
+	tag.ID = uuid.Must(uuid.NewV7())
+
+	s.Run("Failed to get todo ids", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "todo_id" FROM "tag_todos" WHERE tag_id = $1`)).
+			WithArgs(tag.ID).
+			WillReturnError(gorm.ErrInvalidData)
+
+		result, err := s.repo.GetTaggedTodoIDs(context.Background(), s.db, tag)
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+		s.Nil(result)
+	})
+
+	s.Run("Get todo ids successfully", func() {
+		todoID := uuid.NewString()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT "todo_id" FROM "tag_todos" WHERE tag_id = $1`)).
+			WithArgs(tag.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"todo_id"}).AddRow(todoID))
+
+		result, err := s.repo.GetTaggedTodoIDs(context.Background(), s.db, tag)
+		s.Nil(err)
+		s.Equal([]string{todoID}, result)
+	})
+}
+
+func (s *TagTestSuite) TestTouchTaggedTodos() {
+	tag := &entity.Tag{}
+	tag.ID = uuid.Must(uuid.NewV7())
+	query := `UPDATE "todos" SET "version"=version + 1 WHERE id IN (SELECT todo_id FROM tag_todos WHERE tag_id = $1) AND "todos"."deleted_at" IS NULL`
+
+	s.Run("Failed to touch todos", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(tag.ID).
+			WillReturnError(gorm.ErrInvalidData)
+		s.mock.ExpectRollback()
+
+		err := s.repo.TouchTaggedTodos(context.Background(), s.db, tag)
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+	})
+
+	s.Run("Touch todos successfully", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(tag.ID).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		s.mock.ExpectCommit()
+
+		err := s.repo.TouchTaggedTodos(context.Background(), s.db, tag)
+		s.Nil(err)
+	})
+}
+
+func (s *TagTestSuite) TestCreateTag() {
+	s.Run("Failed to create tag", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "tags"`)).
+			WillReturnError(gorm.ErrInvalidData)
+		s.mock.ExpectRollback()
+
+		err := s.repo.CreateTag(context.Background(), s.db, &entity.Tag{})
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+	})
+
+	s.Run("Create tag successfully", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "tags"`)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectCommit()
+
+		err := s.repo.CreateTag(context.Background(), s.db, &entity.Tag{})
+		s.Nil(err)
+	})
+}
+
+func (s *TagTestSuite) TestUpdateTag() {
+	s.Run("Failed to update tag", func() {
+		tag := &entity.Tag{}
+		tag.ID = uuid.Must(uuid.NewV7())
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "tags"`)).
+			WillReturnError(gorm.ErrInvalidData)
+		s.mock.ExpectRollback()
+
+		err := s.repo.UpdateTag(context.Background(), s.db, tag)
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+	})
+
+	s.Run("Update tag successfully", func() {
+		tag := &entity.Tag{}
+		tag.ID = uuid.Must(uuid.NewV7())
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "tags"`)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectCommit()
+
+		err := s.repo.UpdateTag(context.Background(), s.db, tag)
+		s.Nil(err)
+	})
+}
+
+func (s *TagTestSuite) TestDeleteTag() {
+	s.Run("Failed to delete tag", func() {
+		tag := &entity.Tag{}
+		tag.ID = uuid.Must(uuid.NewV7())
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "tags" WHERE "tags"."id" = $1`)).
+			WithArgs(tag.ID).
+			WillReturnError(gorm.ErrInvalidData)
+		s.mock.ExpectRollback()
+
+		err := s.repo.DeleteTag(context.Background(), s.db, tag)
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+	})
+
+	s.Run("Delete tag successfully", func() {
+		tag := &entity.Tag{}
+		tag.ID = uuid.Must(uuid.NewV7())
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "tags" WHERE "tags"."id" = $1`)).
+			WithArgs(tag.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectCommit()
+
+		err := s.repo.DeleteTag(context.Background(), s.db, tag)
+		s.Nil(err)
+	})
+}
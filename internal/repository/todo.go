@@ -4,16 +4,43 @@ import (
 	"context"
 	"github.com/sherwin-77/golang-todos/internal/entity"
 	"gorm.io/gorm"
+	"html"
+	"strings"
+	"time"
 )
 
 type TodoRepository interface {
 	BaseRepository
 	GetTodosByUserID(ctx context.Context, tx *gorm.DB, userID string) ([]entity.Todo, error)
 	GetTodosFiltered(ctx context.Context, tx *gorm.DB, limit int, offset int, order interface{}, query interface{}, args ...interface{}) ([]entity.Todo, error)
+	CountTodosFiltered(ctx context.Context, tx *gorm.DB, query interface{}, args ...interface{}) (int64, error)
 	GetTodoByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Todo, error)
+	GetTodosByIDs(ctx context.Context, tx *gorm.DB, ids []string) ([]entity.Todo, error)
+	SearchTodos(ctx context.Context, tx *gorm.DB, userID string, query string, limit int, offset int) ([]entity.TodoMatch, error)
+	CountSearchTodos(ctx context.Context, tx *gorm.DB, userID string, query string) (int64, error)
+	GetOccurrences(ctx context.Context, tx *gorm.DB, recurrenceID string) ([]entity.Todo, error)
+	GetSubtasks(ctx context.Context, tx *gorm.DB, parentID string) ([]entity.Todo, error)
+	GetSubtaskProgress(ctx context.Context, tx *gorm.DB, parentIDs []string) (map[string]entity.TodoProgress, error)
+	NextPosition(ctx context.Context, tx *gorm.DB, todo *entity.Todo) (float64, error)
+	RebalancePositions(ctx context.Context, tx *gorm.DB, todo *entity.Todo) ([]string, error)
+	GetSiblingAfter(ctx context.Context, tx *gorm.DB, todo *entity.Todo, neighbour *entity.Todo) (*entity.Todo, error)
+	GetSiblingBefore(ctx context.Context, tx *gorm.DB, todo *entity.Todo, neighbour *entity.Todo) (*entity.Todo, error)
+	CompleteSubtasks(ctx context.Context, tx *gorm.DB, parent *entity.Todo) error
+	GetDueReminders(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]entity.Todo, error)
+	IsReminderPending(ctx context.Context, tx *gorm.DB, id string) (bool, error)
+	MarkReminderSent(ctx context.Context, tx *gorm.DB, todo *entity.Todo, sentAt time.Time) (bool, error)
 	CreateTodo(ctx context.Context, tx *gorm.DB, todo *entity.Todo) error
 	UpdateTodo(ctx context.Context, tx *gorm.DB, todo *entity.Todo) error
 	DeleteTodo(ctx context.Context, tx *gorm.DB, todo *entity.Todo) error
+	GetDeletedTodos(ctx context.Context, tx *gorm.DB, userID string, limit int, offset int) ([]entity.Todo, error)
+	CountDeletedTodos(ctx context.Context, tx *gorm.DB, userID string) (int64, error)
+	GetDeletedTodoByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Todo, error)
+	RestoreTodo(ctx context.Context, tx *gorm.DB, todo *entity.Todo) error
+	PurgeTodo(ctx context.Context, tx *gorm.DB, todo *entity.Todo) error
+	PurgeDeletedTodos(ctx context.Context, tx *gorm.DB, query interface{}, args ...interface{}) (int64, error)
+	GetTodoChanges(ctx context.Context, tx *gorm.DB, userID string, since ChangeCursor, limit int) ([]entity.Todo, error)
+	AddTags(ctx context.Context, tx *gorm.DB, todo *entity.Todo, tags []*entity.Tag) error
+	RemoveTags(ctx context.Context, tx *gorm.DB, todo *entity.Todo, tags []*entity.Tag) error
 }
 
 type todoRepository struct {
@@ -35,20 +62,245 @@ func (r *todoRepository) GetTodosByUserID(ctx context.Context, tx *gorm.DB, user
 func (r *todoRepository) GetTodosFiltered(ctx context.Context, tx *gorm.DB, limit int, offset int, order interface{}, query interface{}, args ...interface{}) ([]entity.Todo, error) {
 	var todos []entity.Todo
 
-	if err := tx.WithContext(ctx).Where(query, args...).Limit(limit).Offset(offset).Order(order).Find(&todos).Error; err != nil {
+	if err := tx.WithContext(ctx).Preload("Tags").Preload("Assignee").Where(query, args...).Limit(limit).Offset(offset).Order(order).Find(&todos).Error; err != nil {
 		return nil, err
 	}
 	return todos, nil
 }
 
+func (r *todoRepository) CountTodosFiltered(ctx context.Context, tx *gorm.DB, query interface{}, args ...interface{}) (int64, error) {
+	var count int64
+
+	if err := tx.WithContext(ctx).Model(&entity.Todo{}).Where(query, args...).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 func (r *todoRepository) GetTodoByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Todo, error) {
 	var todo entity.Todo
-	if err := tx.WithContext(ctx).First(&todo, "id = ?", id).Error; err != nil {
+	if err := tx.WithContext(ctx).Preload("Tags").Preload("Assignee").First(&todo, "id = ?", id).Error; err != nil {
 		return nil, err
 	}
 	return &todo, nil
 }
 
+func (r *todoRepository) GetTodosByIDs(ctx context.Context, tx *gorm.DB, ids []string) ([]entity.Todo, error) {
+	var todos []entity.Todo
+	if err := tx.WithContext(ctx).Preload("Tags").Preload("Assignee").Find(&todos, "id IN ?", ids).Error; err != nil {
+		return nil, err
+	}
+	return todos, nil
+}
+
+// TodoAccessScope matches the todos a user created and the todos in projects
+// they created or accepted an invitation to. It takes the user ID three times.
+const TodoAccessScope = "(user_id = ? OR project_id IN (SELECT id FROM projects WHERE user_id = ? UNION SELECT project_id FROM project_members WHERE user_id = ? AND accepted_at IS NOT NULL))"
+
+// searchScope matches the todos the user can see against a to_tsquery expression.
+const searchScope = TodoAccessScope + " AND search_vector @@ to_tsquery('english', ?)"
+
+// ts_headline marks matches around the raw todo text, so it marks them with
+// private-use characters that are stripped from the text beforehand. The
+// snippet is then escaped and the sentinels swapped for <mark> tags.
+const (
+	headlineStart   = "\uE000"
+	headlineStop    = "\uE001"
+	headlineOptions = `StartSel="` + headlineStart + `", StopSel="` + headlineStop + `", MaxWords=20, MinWords=5, MaxFragments=2`
+)
+
+var headlineReplacer = strings.NewReplacer(headlineStart, "<mark>", headlineStop, "</mark>")
+
+// highlight turns a ts_headline snippet into HTML safe to render as is.
+func highlight(snippet string) string {
+	return headlineReplacer.Replace(html.EscapeString(snippet))
+}
+
+// SearchTodos ranks the todos the user can see matching the to_tsquery expression, best
+// match first, and highlights the matched words.
+func (r *todoRepository) SearchTodos(ctx context.Context, tx *gorm.DB, userID string, query string, limit int, offset int) ([]entity.TodoMatch, error) {
+	var matches []entity.TodoMatch
+	sentinels := headlineStart + headlineStop
+
+	if err := tx.WithContext(ctx).
+		Model(&entity.Todo{}).
+		Select(
+			"id AS todo_id, ts_rank(search_vector, to_tsquery('english', ?)) AS rank, ts_headline('english', translate(title, ?, ''), to_tsquery('english', ?), ?) AS title, ts_headline('english', translate(description, ?, ''), to_tsquery('english', ?), ?) AS description",
+			query, sentinels, query, headlineOptions, sentinels, query, headlineOptions,
+		).
+		Where(searchScope, userID, userID, userID, query).
+		Order("rank DESC, id").
+		Limit(limit).
+		Offset(offset).
+		Scan(&matches).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range matches {
+		matches[i].Title = highlight(matches[i].Title)
+		matches[i].Description = highlight(matches[i].Description)
+	}
+	return matches, nil
+}
+
+func (r *todoRepository) CountSearchTodos(ctx context.Context, tx *gorm.DB, userID string, query string) (int64, error) {
+	var count int64
+
+	if err := tx.WithContext(ctx).Model(&entity.Todo{}).Where(searchScope, userID, userID, userID, query).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *todoRepository) GetOccurrences(ctx context.Context, tx *gorm.DB, recurrenceID string) ([]entity.Todo, error) {
+	var todos []entity.Todo
+	if err := tx.WithContext(ctx).Order("occurrence").Find(&todos, "recurrence_id = ?", recurrenceID).Error; err != nil {
+		return nil, err
+	}
+	return todos, nil
+}
+
+func (r *todoRepository) GetSubtasks(ctx context.Context, tx *gorm.DB, parentID string) ([]entity.Todo, error) {
+	var todos []entity.Todo
+	if err := tx.WithContext(ctx).Order("position, created_at").Find(&todos, "parent_id = ?", parentID).Error; err != nil {
+		return nil, err
+	}
+	return todos, nil
+}
+
+// GetSubtaskProgress counts subtasks per parent. Parents without subtasks are absent from the result.
+func (r *todoRepository) GetSubtaskProgress(ctx context.Context, tx *gorm.DB, parentIDs []string) (map[string]entity.TodoProgress, error) {
+	var rows []struct {
+		ParentID  string
+		Completed int64
+		Total     int64
+	}
+
+	if err := tx.WithContext(ctx).
+		Model(&entity.Todo{}).
+		Select("parent_id, COUNT(*) FILTER (WHERE is_completed) AS completed, COUNT(*) AS total").
+		Where("parent_id IN ?", parentIDs).
+		Group("parent_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	progress := make(map[string]entity.TodoProgress, len(rows))
+	for _, row := range rows {
+		progress[row.ParentID] = entity.TodoProgress{Completed: row.Completed, Total: row.Total}
+	}
+	return progress, nil
+}
+
+// siblingScope restricts a query to the todos ordered together with todo: the
+// subtasks of the same parent, or the user's top-level todos.
+func siblingScope(todo *entity.Todo) (string, []interface{}) {
+	if todo.ParentID != nil {
+		return "parent_id = ?", []interface{}{*todo.ParentID}
+	}
+	return "user_id = ? AND parent_id IS NULL", []interface{}{todo.UserID}
+}
+
+// NextPosition returns a position after every sibling of todo.
+func (r *todoRepository) NextPosition(ctx context.Context, tx *gorm.DB, todo *entity.Todo) (float64, error) {
+	var position float64
+	query, args := siblingScope(todo)
+	if err := tx.WithContext(ctx).Model(&entity.Todo{}).Select("COALESCE(MAX(position) + 1, 0)").Where(query, args...).Scan(&position).Error; err != nil {
+		return 0, err
+	}
+	return position, nil
+}
+
+// RebalancePositions renumbers the siblings of todo to 1, 2, 3... keeping their
+// order, and returns the IDs of the todos it touched. Todo itself is left out:
+// it is about to be moved and saved with the version it was loaded with.
+func (r *todoRepository) RebalancePositions(ctx context.Context, tx *gorm.DB, todo *entity.Todo) ([]string, error) {
+	var ids []string
+	query, args := siblingScope(todo)
+	if err := tx.WithContext(ctx).Raw(
+		"UPDATE todos SET position = ranked.row_number, version = version + 1 FROM (SELECT id, ROW_NUMBER() OVER (ORDER BY position, id) AS row_number FROM todos WHERE "+query+" AND id <> ?) ranked WHERE todos.id = ranked.id RETURNING todos.id",
+		append(args, todo.ID)...,
+	).Scan(&ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// GetSiblingAfter returns the sibling of todo ordered right after neighbour,
+// skipping todo itself, or nil when neighbour is the last one.
+func (r *todoRepository) GetSiblingAfter(ctx context.Context, tx *gorm.DB, todo *entity.Todo, neighbour *entity.Todo) (*entity.Todo, error) {
+	return r.getAdjacentSibling(ctx, tx, todo, "position > ? OR (position = ? AND id > ?)", "position, id", neighbour)
+}
+
+// GetSiblingBefore returns the sibling of todo ordered right before neighbour,
+// skipping todo itself, or nil when neighbour is the first one.
+func (r *todoRepository) GetSiblingBefore(ctx context.Context, tx *gorm.DB, todo *entity.Todo, neighbour *entity.Todo) (*entity.Todo, error) {
+	return r.getAdjacentSibling(ctx, tx, todo, "position < ? OR (position = ? AND id < ?)", "position DESC, id DESC", neighbour)
+}
+
+func (r *todoRepository) getAdjacentSibling(ctx context.Context, tx *gorm.DB, todo *entity.Todo, cond string, order string, neighbour *entity.Todo) (*entity.Todo, error) {
+	var siblings []entity.Todo
+	query, args := siblingScope(todo)
+	if err := tx.WithContext(ctx).
+		Where(query, args...).
+		Where("id <> ?", todo.ID).
+		Where(cond, neighbour.Position, neighbour.Position, neighbour.ID).
+		Order(order).
+		Limit(1).
+		Find(&siblings).Error; err != nil {
+		return nil, err
+	}
+
+	if len(siblings) == 0 {
+		return nil, nil
+	}
+	return &siblings[0], nil
+}
+
+func (r *todoRepository) CompleteSubtasks(ctx context.Context, tx *gorm.DB, parent *entity.Todo) error {
+	if err := tx.WithContext(ctx).Model(&entity.Todo{}).Where("parent_id = ? AND is_completed = ?", parent.ID, false).Updates(map[string]interface{}{
+		"is_completed": true,
+		"version":      gorm.Expr("version + 1"),
+	}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *todoRepository) GetDueReminders(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]entity.Todo, error) {
+	var todos []entity.Todo
+
+	if err := tx.WithContext(ctx).
+		Preload("User").
+		Where("remind_at <= ? AND reminded_at IS NULL AND is_completed = ?", now, false).
+		Order("remind_at").
+		Limit(limit).
+		Find(&todos).Error; err != nil {
+		return nil, err
+	}
+	return todos, nil
+}
+
+// IsReminderPending reports whether the reminder has not been sent yet, read
+// fresh from the database rather than from a batch loaded earlier.
+func (r *todoRepository) IsReminderPending(ctx context.Context, tx *gorm.DB, id string) (bool, error) {
+	var count int64
+	if err := tx.WithContext(ctx).Model(&entity.Todo{}).Where("id = ? AND reminded_at IS NULL", id).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// MarkReminderSent records delivery without touching updated_at, since it is not a user edit.
+// It reports false when the reminder had already been marked by someone else.
+func (r *todoRepository) MarkReminderSent(ctx context.Context, tx *gorm.DB, todo *entity.Todo, sentAt time.Time) (bool, error) {
+	result := tx.WithContext(ctx).Model(todo).Where("reminded_at IS NULL").UpdateColumn("reminded_at", sentAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
 func (r *todoRepository) CreateTodo(ctx context.Context, tx *gorm.DB, todo *entity.Todo) error {
 	if err := tx.WithContext(ctx).Create(todo).Error; err != nil {
 		return err
@@ -57,14 +309,138 @@ func (r *todoRepository) CreateTodo(ctx context.Context, tx *gorm.DB, todo *enti
 }
 
 func (r *todoRepository) UpdateTodo(ctx context.Context, tx *gorm.DB, todo *entity.Todo) error {
-	if err := tx.WithContext(ctx).Save(todo).Error; err != nil {
+	return updateVersioned(ctx, tx, todo)
+}
+
+// DeleteTodo moves the todo and its subtasks to the trash. They all get the
+// same deleted_at, which is how RestoreTodo finds the subtasks to bring back.
+// Subtasks trashed earlier keep their own deleted_at, so restoring the todo
+// leaves them there.
+func (r *todoRepository) DeleteTodo(ctx context.Context, tx *gorm.DB, todo *entity.Todo) error {
+	deletedAt := gorm.DeletedAt{Time: time.Now(), Valid: true}
+
+	result := tx.WithContext(ctx).Model(todo).Where("version = ?", todo.Version).UpdateColumn("deleted_at", deletedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.Todo{}).Where("parent_id = ?", todo.ID).UpdateColumn("deleted_at", deletedAt).Error; err != nil {
 		return err
 	}
+
+	todo.DeletedAt = deletedAt
 	return nil
 }
 
-func (r *todoRepository) DeleteTodo(ctx context.Context, tx *gorm.DB, todo *entity.Todo) error {
-	if err := tx.WithContext(ctx).Delete(todo).Error; err != nil {
+// trashScope selects the user's trashed todos, leaving out subtasks that were
+// trashed together with their parent.
+const trashScope = "user_id = ? AND deleted_at IS NOT NULL AND (parent_id IS NULL OR parent_id NOT IN (SELECT id FROM todos WHERE deleted_at IS NOT NULL))"
+
+func (r *todoRepository) GetDeletedTodos(ctx context.Context, tx *gorm.DB, userID string, limit int, offset int) ([]entity.Todo, error) {
+	var todos []entity.Todo
+
+	if err := tx.WithContext(ctx).Unscoped().Where(trashScope, userID).Limit(limit).Offset(offset).Order("deleted_at DESC, id").Find(&todos).Error; err != nil {
+		return nil, err
+	}
+	return todos, nil
+}
+
+func (r *todoRepository) CountDeletedTodos(ctx context.Context, tx *gorm.DB, userID string) (int64, error) {
+	var count int64
+
+	if err := tx.WithContext(ctx).Unscoped().Model(&entity.Todo{}).Where(trashScope, userID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *todoRepository) GetDeletedTodoByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Todo, error) {
+	var todo entity.Todo
+	if err := tx.WithContext(ctx).Unscoped().First(&todo, "id = ? AND deleted_at IS NOT NULL", id).Error; err != nil {
+		return nil, err
+	}
+	return &todo, nil
+}
+
+// RestoreTodo brings back the todo and the subtasks that were trashed in the same delete.
+func (r *todoRepository) RestoreTodo(ctx context.Context, tx *gorm.DB, todo *entity.Todo) error {
+	if err := tx.WithContext(ctx).
+		Unscoped().
+		Model(&entity.Todo{}).
+		Where("(id = ? OR parent_id = ?) AND deleted_at = ?", todo.ID, todo.ID, todo.DeletedAt).
+		Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+		}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// PurgeTodo permanently deletes the todo. Its subtasks go with it through the foreign key cascade.
+func (r *todoRepository) PurgeTodo(ctx context.Context, tx *gorm.DB, todo *entity.Todo) error {
+	if err := tx.WithContext(ctx).Unscoped().Delete(todo).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *todoRepository) PurgeDeletedTodos(ctx context.Context, tx *gorm.DB, query interface{}, args ...interface{}) (int64, error) {
+	result := tx.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").Where(query, args...).Delete(&entity.Todo{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// ChangeCursor is a position in the todo change feed: the transaction that
+// wrote a todo, then the order of the write within all transactions.
+type ChangeCursor struct {
+	XID int64
+	Seq int64
+}
+
+// After reports whether c comes later in the feed than other.
+func (c ChangeCursor) After(other ChangeCursor) bool {
+	return c.XID > other.XID || (c.XID == other.XID && c.Seq > other.Seq)
+}
+
+// GetTodoChanges returns the todos the user can see written after the given
+// cursor, trashed ones included, in feed order. Both columns are maintained by
+// a trigger on every insert and update. Todos written by transactions at or
+// after the oldest one still running are held back: a transaction that commits
+// later than a newer one must not land behind a cursor the client already holds.
+// Joining or leaving a project does not write its todos, so it does not show
+// up in the feed either.
+func (r *todoRepository) GetTodoChanges(ctx context.Context, tx *gorm.DB, userID string, since ChangeCursor, limit int) ([]entity.Todo, error) {
+	var todos []entity.Todo
+
+	if err := tx.WithContext(ctx).
+		Unscoped().
+		Preload("Tags").
+		Where(TodoAccessScope, userID, userID, userID).
+		Where("(change_xid, change_seq) > (?, ?)", since.XID, since.Seq).
+		Where("change_xid < pg_snapshot_xmin(pg_current_snapshot())::text::bigint").
+		Order("change_xid, change_seq").
+		Limit(limit).
+		Find(&todos).Error; err != nil {
+		return nil, err
+	}
+	return todos, nil
+}
+
+func (r *todoRepository) AddTags(ctx context.Context, tx *gorm.DB, todo *entity.Todo, tags []*entity.Tag) error {
+	if err := tx.WithContext(ctx).Model(todo).Omit("Tags.*").Association("Tags").Append(tags); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *todoRepository) RemoveTags(ctx context.Context, tx *gorm.DB, todo *entity.Todo, tags []*entity.Tag) error {
+	if err := tx.WithContext(ctx).Model(todo).Association("Tags").Delete(tags); err != nil {
 		return err
 	}
 	return nil
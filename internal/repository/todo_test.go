@@ -2,6 +2,7 @@ package repository_test
 
 import (
 	"context"
+	"database/sql/driver"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/sherwin-77/golang-todos/internal/entity"
@@ -12,6 +13,7 @@ import (
 	"gorm.io/gorm/logger"
 	"regexp"
 	"testing"
+	"time"
 )
 
 type TodoTestSuite struct {
@@ -54,7 +56,7 @@ func (s *TodoTestSuite) TestGetTodosByUserID() {
 	userID := uuid.NewString()
 
 	s.Run("Failed to get todos", func() {
-		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "todos" WHERE user_id = $1`)).
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "todos" WHERE user_id = $1 AND "todos"."deleted_at" IS NULL`)).
 			WithArgs(userID).
 			WillReturnError(gorm.ErrRecordNotFound)
 
@@ -64,7 +66,7 @@ func (s *TodoTestSuite) TestGetTodosByUserID() {
 	})
 
 	s.Run("Get todos successfully", func() {
-		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "todos" WHERE user_id = $1`)).
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "todos" WHERE user_id = $1 AND "todos"."deleted_at" IS NULL`)).
 			WithArgs(userID).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
 				AddRow(uuid.NewString(), "Todo 1").
@@ -80,7 +82,7 @@ func (s *TodoTestSuite) TestGetTodosFiltered() {
 	todoID := uuid.NewString()
 
 	s.Run("Failed to get todos", func() {
-		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "todos" WHERE id != $1 ORDER BY id LIMIT $2 OFFSET $3`)).
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "todos" WHERE id != $1 AND "todos"."deleted_at" IS NULL ORDER BY id LIMIT $2 OFFSET $3`)).
 			WithArgs(todoID, 1, 1).
 			WillReturnError(gorm.ErrRecordNotFound)
 
@@ -90,11 +92,13 @@ func (s *TodoTestSuite) TestGetTodosFiltered() {
 	})
 
 	s.Run("Get todos successfully", func() {
-		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "todos" WHERE id != $1 ORDER BY id LIMIT $2 OFFSET $3`)).
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "todos" WHERE id != $1 AND "todos"."deleted_at" IS NULL ORDER BY id LIMIT $2 OFFSET $3`)).
 			WithArgs(todoID, 1, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
 				AddRow(uuid.NewString(), "Todo 1").
 				AddRow(uuid.NewString(), "Todo 2"))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tag_todos" WHERE "tag_todos"."todo_id" IN ($1,$2)`)).
+			WillReturnRows(sqlmock.NewRows([]string{"tag_id", "todo_id"}))
 
 		result, err := s.repo.GetTodosFiltered(context.Background(), s.db, 1, 1, "id", "id != ?", todoID)
 		s.Nil(err)
@@ -102,11 +106,35 @@ func (s *TodoTestSuite) TestGetTodosFiltered() {
 	})
 }
 
+func (s *TodoTestSuite) TestCountTodosFiltered() {
+	userID := uuid.NewString()
+
+	s.Run("Failed to count todos", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "todos" WHERE user_id = $1 AND "todos"."deleted_at" IS NULL`)).
+			WithArgs(userID).
+			WillReturnError(gorm.ErrInvalidData)
+
+		result, err := s.repo.CountTodosFiltered(context.Background(), s.db, "user_id = ?", userID)
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+		s.Zero(result)
+	})
+
+	s.Run("Count todos successfully", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "todos" WHERE user_id = $1 AND "todos"."deleted_at" IS NULL`)).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+		result, err := s.repo.CountTodosFiltered(context.Background(), s.db, "user_id = ?", userID)
+		s.Nil(err)
+		s.Equal(int64(2), result)
+	})
+}
+
 func (s *TodoTestSuite) TestGetTodoByID() {
 	todoID := uuid.NewString()
 
 	s.Run("Todo not found", func() {
-		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "todos" WHERE id = $1 ORDER BY "todos"."id" LIMIT $2`)).
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "todos" WHERE id = $1 AND "todos"."deleted_at" IS NULL ORDER BY "todos"."id" LIMIT $2`)).
 			WithArgs(todoID, 1).
 			WillReturnError(gorm.ErrRecordNotFound)
 
@@ -116,9 +144,12 @@ func (s *TodoTestSuite) TestGetTodoByID() {
 	})
 
 	s.Run("Get todo successfully", func() {
-		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "todos" WHERE id = $1 ORDER BY "todos"."id" LIMIT $2`)).
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "todos" WHERE id = $1 AND "todos"."deleted_at" IS NULL ORDER BY "todos"."id" LIMIT $2`)).
 			WithArgs(todoID, 1).
 			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(todoID, "Todo 1"))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tag_todos" WHERE "tag_todos"."todo_id" = $1`)).
+			WithArgs(todoID).
+			WillReturnRows(sqlmock.NewRows([]string{"tag_id", "todo_id"}))
 
 		result, err := s.repo.GetTodoByID(context.Background(), s.db, todoID)
 		s.Nil(err)
@@ -127,6 +158,452 @@ func (s *TodoTestSuite) TestGetTodoByID() {
 	})
 }
 
+func (s *TodoTestSuite) TestGetTodosByIDs() {
+	ids := []string{uuid.NewString(), uuid.NewString()}
+	query := `SELECT * FROM "todos" WHERE id IN ($1,$2) AND "todos"."deleted_at" IS NULL`
+
+	s.Run("Failed to get todos", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(ids[0], ids[1]).
+			WillReturnError(gorm.ErrInvalidData)
+
+		result, err := s.repo.GetTodosByIDs(context.Background(), s.db, ids)
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+		s.Nil(result)
+	})
+
+	s.Run("Get todos successfully", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(ids[0], ids[1]).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(ids[0]).AddRow(ids[1]))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tag_todos" WHERE "tag_todos"."todo_id" IN ($1,$2)`)).
+			WithArgs(ids[0], ids[1]).
+			WillReturnRows(sqlmock.NewRows([]string{"tag_id", "todo_id"}))
+
+		result, err := s.repo.GetTodosByIDs(context.Background(), s.db, ids)
+		s.Nil(err)
+		s.Len(result, 2)
+	})
+}
+
+func (s *TodoTestSuite) TestSearchTodos() {
+	userID := uuid.NewString()
+	query := `SELECT id AS todo_id, ts_rank(search_vector, to_tsquery('english', $1)) AS rank, ts_headline('english', translate(title, $2, ''), to_tsquery('english', $3), $4) AS title, ts_headline('english', translate(description, $5, ''), to_tsquery('english', $6), $7) AS description FROM "todos" WHERE ((user_id = $8 OR project_id IN (SELECT id FROM projects WHERE user_id = $9 UNION SELECT project_id FROM project_members WHERE user_id = $10 AND accepted_at IS NOT NULL)) AND search_vector @@ to_tsquery('english', $11)) AND "todos"."deleted_at" IS NULL ORDER BY rank DESC, id LIMIT $12 OFFSET $13`
+	tsQuery := "milk:*"
+	args := []driver.Value{tsQuery, sqlmock.AnyArg(), tsQuery, sqlmock.AnyArg(), sqlmock.AnyArg(), tsQuery, sqlmock.AnyArg(), userID, userID, userID, tsQuery, 10, 10}
+
+	s.Run("Failed to search todos", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(args...).
+			WillReturnError(gorm.ErrInvalidData)
+
+		result, err := s.repo.SearchTodos(context.Background(), s.db, userID, tsQuery, 10, 10)
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+		s.Nil(result)
+	})
+
+	s.Run("Search todos successfully", func() {
+		todoID := uuid.New()
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(args...).
+			WillReturnRows(sqlmock.NewRows([]string{"todo_id", "rank", "title", "description"}).
+				AddRow(todoID, 0.6, "Buy \uE000milk\uE001", "<script>\uE000milk\uE001</script> & eggs"))
+
+		result, err := s.repo.SearchTodos(context.Background(), s.db, userID, tsQuery, 10, 10)
+		s.Nil(err)
+		s.Equal([]entity.TodoMatch{{
+			TodoID:      todoID,
+			Rank:        0.6,
+			Title:       "Buy <mark>milk</mark>",
+			Description: "&lt;script&gt;<mark>milk</mark>&lt;/script&gt; &amp; eggs",
+		}}, result)
+	})
+}
+
+func (s *TodoTestSuite) TestCountSearchTodos() {
+	userID := uuid.NewString()
+	query := `SELECT count(*) FROM "todos" WHERE ((user_id = $1 OR project_id IN (SELECT id FROM projects WHERE user_id = $2 UNION SELECT project_id FROM project_members WHERE user_id = $3 AND accepted_at IS NOT NULL)) AND search_vector @@ to_tsquery('english', $4)) AND "todos"."deleted_at" IS NULL`
+
+	s.Run("Failed to count todos", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(userID, userID, userID, "milk:*").
+			WillReturnError(gorm.ErrInvalidData)
+
+		result, err := s.repo.CountSearchTodos(context.Background(), s.db, userID, "milk:*")
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+		s.Zero(result)
+	})
+
+	s.Run("Count todos successfully", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(userID, userID, userID, "milk:*").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		result, err := s.repo.CountSearchTodos(context.Background(), s.db, userID, "milk:*")
+		s.Nil(err)
+		s.Equal(int64(3), result)
+	})
+}
+
+func (s *TodoTestSuite) TestGetOccurrences() {
+	recurrenceID := uuid.NewString()
+
+	s.Run("Failed to get occurrences", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "todos" WHERE recurrence_id = $1 AND "todos"."deleted_at" IS NULL ORDER BY occurrence`)).
+			WithArgs(recurrenceID).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		result, err := s.repo.GetOccurrences(context.Background(), s.db, recurrenceID)
+		s.ErrorAs(err, &gorm.ErrRecordNotFound)
+		s.Nil(result)
+	})
+
+	s.Run("Get occurrences successfully", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "todos" WHERE recurrence_id = $1 AND "todos"."deleted_at" IS NULL ORDER BY occurrence`)).
+			WithArgs(recurrenceID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "recurrence_id", "occurrence", "is_completed"}).
+				AddRow(recurrenceID, recurrenceID, 1, true).
+				AddRow(uuid.NewString(), recurrenceID, 2, false))
+
+		result, err := s.repo.GetOccurrences(context.Background(), s.db, recurrenceID)
+		s.Nil(err)
+		s.Len(result, 2)
+	})
+}
+
+func (s *TodoTestSuite) TestGetSubtasks() {
+	parentID := uuid.NewString()
+
+	s.Run("Failed to get subtasks", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "todos" WHERE parent_id = $1 AND "todos"."deleted_at" IS NULL ORDER BY position, created_at`)).
+			WithArgs(parentID).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		result, err := s.repo.GetSubtasks(context.Background(), s.db, parentID)
+		s.ErrorAs(err, &gorm.ErrRecordNotFound)
+		s.Nil(result)
+	})
+
+	s.Run("Get subtasks successfully", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "todos" WHERE parent_id = $1 AND "todos"."deleted_at" IS NULL ORDER BY position, created_at`)).
+			WithArgs(parentID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "parent_id", "position"}).
+				AddRow(uuid.NewString(), parentID, 0).
+				AddRow(uuid.NewString(), parentID, 1))
+
+		result, err := s.repo.GetSubtasks(context.Background(), s.db, parentID)
+		s.Nil(err)
+		s.Len(result, 2)
+	})
+}
+
+func (s *TodoTestSuite) TestGetSubtaskProgress() {
+	parentID := uuid.NewString()
+	query := `SELECT parent_id, COUNT(*) FILTER (WHERE is_completed) AS completed, COUNT(*) AS total FROM "todos" WHERE parent_id IN ($1) AND "todos"."deleted_at" IS NULL GROUP BY "parent_id"`
+
+	s.Run("Failed to get progress", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(parentID).
+			WillReturnError(gorm.ErrInvalidData)
+
+		result, err := s.repo.GetSubtaskProgress(context.Background(), s.db, []string{parentID})
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+		s.Nil(result)
+	})
+
+	s.Run("Get progress successfully", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(parentID).
+			WillReturnRows(sqlmock.NewRows([]string{"parent_id", "completed", "total"}).
+				AddRow(parentID, 3, 5))
+
+		result, err := s.repo.GetSubtaskProgress(context.Background(), s.db, []string{parentID})
+		s.Nil(err)
+		s.Equal(entity.TodoProgress{Completed: 3, Total: 5}, result[parentID])
+	})
+}
+
+func (s *TodoTestSuite) TestNextPosition() {
+	s.Run("Get next top-level position successfully", func() {
+		todo := &entity.Todo{UserID: uuid.Must(uuid.NewV7())}
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(MAX(position) + 1, 0) FROM "todos" WHERE (user_id = $1 AND parent_id IS NULL) AND "todos"."deleted_at" IS NULL`)).
+			WithArgs(todo.UserID).
+			WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).
+				AddRow(7.5))
+
+		result, err := s.repo.NextPosition(context.Background(), s.db, todo)
+		s.Nil(err)
+		s.Equal(7.5, result)
+	})
+
+	s.Run("Get next subtask position successfully", func() {
+		parentID := uuid.Must(uuid.NewV7())
+		todo := &entity.Todo{ParentID: &parentID}
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(MAX(position) + 1, 0) FROM "todos" WHERE parent_id = $1 AND "todos"."deleted_at" IS NULL`)).
+			WithArgs(parentID).
+			WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).
+				AddRow(4))
+
+		result, err := s.repo.NextPosition(context.Background(), s.db, todo)
+		s.Nil(err)
+		s.Equal(float64(4), result)
+	})
+}
+
+func (s *TodoTestSuite) TestRebalancePositions() {
+	todo := &entity.Todo{UserID: uuid.Must(uuid.NewV7())}
+	todo.ID = uuid.Must(uuid.NewV7())
+	todo.Version = 3
+	query := `UPDATE todos SET position = ranked.row_number, version = version + 1 FROM (SELECT id, ROW_NUMBER() OVER (ORDER BY position, id) AS row_number FROM todos WHERE user_id = $1 AND parent_id IS NULL AND id <> $2) ranked WHERE todos.id = ranked.id RETURNING todos.id`
+
+	s.Run("Failed to rebalance positions", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(todo.UserID, todo.ID).
+			WillReturnError(gorm.ErrInvalidData)
+
+		result, err := s.repo.RebalancePositions(context.Background(), s.db, todo)
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+		s.Nil(result)
+	})
+
+	s.Run("Rebalance positions successfully", func() {
+		id := uuid.NewString()
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(todo.UserID, todo.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).
+				AddRow(id))
+
+		result, err := s.repo.RebalancePositions(context.Background(), s.db, todo)
+		s.Nil(err)
+		s.Equal([]string{id}, result)
+	})
+
+	s.Run("Moved todo keeps its version through the rebalance", func() {
+		id := uuid.NewString()
+		// The save sets version 4 only where the row is still at version 3.
+		args := make([]driver.Value, 21)
+		for i := range args {
+			args[i] = sqlmock.AnyArg()
+		}
+		args[2], args[19], args[20] = 4, 3, todo.ID
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(todo.UserID, todo.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).
+				AddRow(id))
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(`UPDATE "todos" SET .* WHERE version = \$\d+ AND "todos"."deleted_at" IS NULL AND "id" = \$\d+`).
+			WithArgs(args...).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectCommit()
+
+		result, err := s.repo.RebalancePositions(context.Background(), s.db, todo)
+		s.Nil(err)
+		s.NotContains(result, todo.ID.String())
+
+		todo.Position = 1.5
+		s.Nil(s.repo.UpdateTodo(context.Background(), s.db, todo))
+		s.Equal(4, todo.Version)
+	})
+}
+
+func (s *TodoTestSuite) TestGetSiblingAfter() {
+	todo := &entity.Todo{UserID: uuid.Must(uuid.NewV7())}
+	todo.ID = uuid.Must(uuid.NewV7())
+	neighbour := &entity.Todo{Position: 2}
+	neighbour.ID = uuid.Must(uuid.NewV7())
+	query := `SELECT * FROM "todos" WHERE (user_id = $1 AND parent_id IS NULL) AND id <> $2 AND (position > $3 OR (position = $4 AND id > $5)) AND "todos"."deleted_at" IS NULL ORDER BY position, id LIMIT $6`
+
+	s.Run("Failed to get sibling", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(todo.UserID, todo.ID, neighbour.Position, neighbour.Position, neighbour.ID, 1).
+			WillReturnError(gorm.ErrInvalidData)
+
+		result, err := s.repo.GetSiblingAfter(context.Background(), s.db, todo, neighbour)
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+		s.Nil(result)
+	})
+
+	s.Run("Neighbour is the last sibling", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(todo.UserID, todo.ID, neighbour.Position, neighbour.Position, neighbour.ID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		result, err := s.repo.GetSiblingAfter(context.Background(), s.db, todo, neighbour)
+		s.Nil(err)
+		s.Nil(result)
+	})
+
+	s.Run("Get sibling successfully", func() {
+		id := uuid.Must(uuid.NewV7())
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(todo.UserID, todo.ID, neighbour.Position, neighbour.Position, neighbour.ID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "position"}).
+				AddRow(id, 3))
+
+		result, err := s.repo.GetSiblingAfter(context.Background(), s.db, todo, neighbour)
+		s.Nil(err)
+		s.Equal(id, result.ID)
+		s.Equal(float64(3), result.Position)
+	})
+}
+
+func (s *TodoTestSuite) TestGetSiblingBefore() {
+	parentID := uuid.Must(uuid.NewV7())
+	todo := &entity.Todo{ParentID: &parentID}
+	todo.ID = uuid.Must(uuid.NewV7())
+	neighbour := &entity.Todo{Position: 2}
+	neighbour.ID = uuid.Must(uuid.NewV7())
+
+	s.Run("Get sibling successfully", func() {
+		id := uuid.Must(uuid.NewV7())
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "todos" WHERE parent_id = $1 AND id <> $2 AND (position < $3 OR (position = $4 AND id < $5)) AND "todos"."deleted_at" IS NULL ORDER BY position DESC, id DESC LIMIT $6`)).
+			WithArgs(parentID, todo.ID, neighbour.Position, neighbour.Position, neighbour.ID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "position"}).
+				AddRow(id, 1))
+
+		result, err := s.repo.GetSiblingBefore(context.Background(), s.db, todo, neighbour)
+		s.Nil(err)
+		s.Equal(id, result.ID)
+	})
+}
+
+func (s *TodoTestSuite) TestCompleteSubtasks() {
+	parent := &entity.Todo{}
+	parent.ID = uuid.Must(uuid.NewV7())
+
+	s.Run("Failed to complete subtasks", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "todos" SET "is_completed"=$1,"version"=version + 1,"updated_at"=$2 WHERE (parent_id = $3 AND is_completed = $4) AND "todos"."deleted_at" IS NULL`)).
+			WithArgs(true, sqlmock.AnyArg(), parent.ID, false).
+			WillReturnError(gorm.ErrInvalidData)
+		s.mock.ExpectRollback()
+
+		err := s.repo.CompleteSubtasks(context.Background(), s.db, parent)
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+	})
+
+	s.Run("Complete subtasks successfully", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "todos" SET "is_completed"=$1,"version"=version + 1,"updated_at"=$2 WHERE (parent_id = $3 AND is_completed = $4) AND "todos"."deleted_at" IS NULL`)).
+			WithArgs(true, sqlmock.AnyArg(), parent.ID, false).
+			WillReturnResult(sqlmock.NewResult(1, 2))
+		s.mock.ExpectCommit()
+
+		err := s.repo.CompleteSubtasks(context.Background(), s.db, parent)
+		s.Nil(err)
+	})
+}
+
+func (s *TodoTestSuite) TestGetDueReminders() {
+	now := time.Now()
+
+	s.Run("Failed to get reminders", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "todos" WHERE (remind_at <= $1 AND reminded_at IS NULL AND is_completed = $2) AND "todos"."deleted_at" IS NULL ORDER BY remind_at LIMIT $3`)).
+			WithArgs(now, false, 10).
+			WillReturnError(gorm.ErrInvalidData)
+
+		result, err := s.repo.GetDueReminders(context.Background(), s.db, now, 10)
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+		s.Nil(result)
+	})
+
+	s.Run("Get reminders successfully", func() {
+		userID := uuid.NewString()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "todos" WHERE (remind_at <= $1 AND reminded_at IS NULL AND is_completed = $2) AND "todos"."deleted_at" IS NULL ORDER BY remind_at LIMIT $3`)).
+			WithArgs(now, false, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "user_id"}).
+				AddRow(uuid.NewString(), "Todo 1", userID))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE "users"."id" = $1`)).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(userID, "user@example.com"))
+
+		result, err := s.repo.GetDueReminders(context.Background(), s.db, now, 10)
+		s.Nil(err)
+		s.Len(result, 1)
+		s.Equal("user@example.com", result[0].User.Email)
+	})
+}
+
+func (s *TodoTestSuite) TestIsReminderPending() {
+	id := uuid.Must(uuid.NewV7()).String()
+
+	s.Run("Failed to check reminder", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "todos" WHERE (id = $1 AND reminded_at IS NULL) AND "todos"."deleted_at" IS NULL`)).
+			WithArgs(id).
+			WillReturnError(gorm.ErrInvalidData)
+
+		pending, err := s.repo.IsReminderPending(context.Background(), s.db, id)
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+		s.False(pending)
+	})
+
+	s.Run("Reminder already sent", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "todos" WHERE (id = $1 AND reminded_at IS NULL) AND "todos"."deleted_at" IS NULL`)).
+			WithArgs(id).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		pending, err := s.repo.IsReminderPending(context.Background(), s.db, id)
+		s.Nil(err)
+		s.False(pending)
+	})
+
+	s.Run("Reminder still pending", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "todos" WHERE (id = $1 AND reminded_at IS NULL) AND "todos"."deleted_at" IS NULL`)).
+			WithArgs(id).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		pending, err := s.repo.IsReminderPending(context.Background(), s.db, id)
+		s.Nil(err)
+		s.True(pending)
+	})
+}
+
+func (s *TodoTestSuite) TestMarkReminderSent() {
+	now := time.Now()
+
+	s.Run("Failed to mark reminder", func() {
+		todo := &entity.Todo{}
+		todo.ID = uuid.Must(uuid.NewV7())
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "todos" SET "reminded_at"=$1 WHERE reminded_at IS NULL AND "todos"."deleted_at" IS NULL AND "id" = $2`)).
+			WithArgs(now, todo.ID).
+			WillReturnError(gorm.ErrInvalidData)
+		s.mock.ExpectRollback()
+
+		marked, err := s.repo.MarkReminderSent(context.Background(), s.db, todo, now)
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+		s.False(marked)
+	})
+
+	s.Run("Reminder already marked", func() {
+		todo := &entity.Todo{}
+		todo.ID = uuid.Must(uuid.NewV7())
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "todos" SET "reminded_at"=$1 WHERE reminded_at IS NULL AND "todos"."deleted_at" IS NULL AND "id" = $2`)).
+			WithArgs(now, todo.ID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		s.mock.ExpectCommit()
+
+		marked, err := s.repo.MarkReminderSent(context.Background(), s.db, todo, now)
+		s.Nil(err)
+		s.False(marked)
+	})
+
+	s.Run("Mark reminder successfully", func() {
+		todo := &entity.Todo{}
+		todo.ID = uuid.Must(uuid.NewV7())
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "todos" SET "reminded_at"=$1 WHERE reminded_at IS NULL AND "todos"."deleted_at" IS NULL AND "id" = $2`)).
+			WithArgs(now, todo.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectCommit()
+
+		marked, err := s.repo.MarkReminderSent(context.Background(), s.db, todo, now)
+		s.Nil(err)
+		s.True(marked)
+	})
+}
+
 func (s *TodoTestSuite) TestCreateTodo() {
 	s.Run("Failed to create todo", func() {
 		s.mock.ExpectBegin()
@@ -159,25 +636,43 @@ func (s *TodoTestSuite) TestUpdateTodo() {
 		s.ErrorAs(err, &gorm.ErrInvalidData)
 	})
 
+	s.Run("Update stale todo", func() {
+		todo := &entity.Todo{}
+		todo.ID = uuid.Must(uuid.NewV7())
+		todo.Version = 3
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "todos"`)).WillReturnResult(sqlmock.NewResult(0, 0))
+		s.mock.ExpectCommit()
+
+		err := s.repo.UpdateTodo(context.Background(), s.db, todo)
+		s.ErrorIs(err, repository.ErrVersionConflict)
+		s.Equal(3, todo.Version)
+	})
+
 	s.Run("Update todo successfully", func() {
 		todo := &entity.Todo{}
 		todo.ID = uuid.Must(uuid.NewV7())
+		todo.Version = 3
 		s.mock.ExpectBegin()
-		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "todos"`)).WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectExec(`UPDATE "todos" SET .*"version"=\$\d+.* WHERE version = \$\d+ AND`).WillReturnResult(sqlmock.NewResult(1, 1))
 		s.mock.ExpectCommit()
 
 		err := s.repo.UpdateTodo(context.Background(), s.db, todo)
 		s.Nil(err)
+		s.Equal(4, todo.Version)
 	})
 }
 
 func (s *TodoTestSuite) TestDeleteTodo() {
+	query := `UPDATE "todos" SET "deleted_at"=$1 WHERE version = $2 AND "todos"."deleted_at" IS NULL AND "id" = $3`
+	subtaskQuery := `UPDATE "todos" SET "deleted_at"=$1 WHERE parent_id = $2 AND "todos"."deleted_at" IS NULL`
+
 	s.Run("Failed to delete todo", func() {
 		todo := &entity.Todo{}
 		todo.ID = uuid.Must(uuid.NewV7())
 		s.mock.ExpectBegin()
-		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "todos" WHERE "todos"."id" = $1`)).
-			WithArgs(todo.ID).
+		s.mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(sqlmock.AnyArg(), todo.Version, todo.ID).
 			WillReturnError(gorm.ErrInvalidData)
 		s.mock.ExpectRollback()
 
@@ -185,16 +680,350 @@ func (s *TodoTestSuite) TestDeleteTodo() {
 		s.ErrorAs(err, &gorm.ErrInvalidData)
 	})
 
+	s.Run("Delete stale todo", func() {
+		todo := &entity.Todo{}
+		todo.ID = uuid.Must(uuid.NewV7())
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(sqlmock.AnyArg(), todo.Version, todo.ID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		s.mock.ExpectCommit()
+
+		err := s.repo.DeleteTodo(context.Background(), s.db, todo)
+		s.ErrorIs(err, repository.ErrVersionConflict)
+	})
+
 	s.Run("Delete todo successfully", func() {
 		todo := &entity.Todo{}
 		todo.ID = uuid.Must(uuid.NewV7())
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(sqlmock.AnyArg(), todo.Version, todo.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectCommit()
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(subtaskQuery)).
+			WithArgs(sqlmock.AnyArg(), todo.ID).
+			WillReturnResult(sqlmock.NewResult(1, 2))
+		s.mock.ExpectCommit()
+
+		err := s.repo.DeleteTodo(context.Background(), s.db, todo)
+		s.Nil(err)
+	})
+}
+
+func (s *TodoTestSuite) TestGetDeletedTodos() {
+	userID := uuid.NewString()
+	query := `SELECT * FROM "todos" WHERE user_id = $1 AND deleted_at IS NOT NULL AND (parent_id IS NULL OR parent_id NOT IN (SELECT id FROM todos WHERE deleted_at IS NOT NULL)) ORDER BY deleted_at DESC, id LIMIT $2 OFFSET $3`
+
+	s.Run("Failed to get trash", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(userID, 10, 10).
+			WillReturnError(gorm.ErrInvalidData)
+
+		result, err := s.repo.GetDeletedTodos(context.Background(), s.db, userID, 10, 10)
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+		s.Nil(result)
+	})
+
+	s.Run("Get trash successfully", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(userID, 10, 10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "deleted_at"}).
+				AddRow(uuid.NewString(), userID, time.Now()))
+
+		result, err := s.repo.GetDeletedTodos(context.Background(), s.db, userID, 10, 10)
+		s.Nil(err)
+		s.Len(result, 1)
+		s.True(result[0].DeletedAt.Valid)
+	})
+}
+
+func (s *TodoTestSuite) TestCountDeletedTodos() {
+	userID := uuid.NewString()
+	query := `SELECT count(*) FROM "todos" WHERE user_id = $1 AND deleted_at IS NOT NULL`
+
+	s.Run("Failed to count trash", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(userID).
+			WillReturnError(gorm.ErrInvalidData)
+
+		result, err := s.repo.CountDeletedTodos(context.Background(), s.db, userID)
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+		s.Zero(result)
+	})
+
+	s.Run("Count trash successfully", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+		result, err := s.repo.CountDeletedTodos(context.Background(), s.db, userID)
+		s.Nil(err)
+		s.Equal(int64(2), result)
+	})
+}
+
+func (s *TodoTestSuite) TestGetDeletedTodoByID() {
+	id := uuid.NewString()
+	query := `SELECT * FROM "todos" WHERE id = $1 AND deleted_at IS NOT NULL ORDER BY "todos"."id" LIMIT $2`
+
+	s.Run("Todo not in trash", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(id, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		result, err := s.repo.GetDeletedTodoByID(context.Background(), s.db, id)
+		s.ErrorIs(err, gorm.ErrRecordNotFound)
+		s.Nil(result)
+	})
+
+	s.Run("Get trashed todo successfully", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(id, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "deleted_at"}).
+				AddRow(id, time.Now()))
+
+		result, err := s.repo.GetDeletedTodoByID(context.Background(), s.db, id)
+		s.Nil(err)
+		s.Equal(id, result.ID.String())
+	})
+}
+
+func (s *TodoTestSuite) TestRestoreTodo() {
+	todo := &entity.Todo{DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}}
+	todo.ID = uuid.Must(uuid.NewV7())
+	query := `UPDATE "todos" SET "deleted_at"=$1,"version"=version + 1,"updated_at"=$2 WHERE (id = $3 OR parent_id = $4) AND deleted_at = $5`
+
+	s.Run("Failed to restore todo", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(nil, sqlmock.AnyArg(), todo.ID, todo.ID, todo.DeletedAt).
+			WillReturnError(gorm.ErrInvalidData)
+		s.mock.ExpectRollback()
+
+		err := s.repo.RestoreTodo(context.Background(), s.db, todo)
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+	})
+
+	s.Run("Restore todo successfully", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(nil, sqlmock.AnyArg(), todo.ID, todo.ID, todo.DeletedAt).
+			WillReturnResult(sqlmock.NewResult(1, 2))
+		s.mock.ExpectCommit()
+
+		err := s.repo.RestoreTodo(context.Background(), s.db, todo)
+		s.Nil(err)
+	})
+}
+
+func (s *TodoTestSuite) TestTrashAndRestoreTodoWithSubtasks() {
+	todo := &entity.Todo{}
+	todo.ID = uuid.Must(uuid.NewV7())
+	deletedAt := &timeArg{}
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "todos" SET "deleted_at"=$1 WHERE version = $2 AND "todos"."deleted_at" IS NULL AND "id" = $3`)).
+		WithArgs(deletedAt, todo.Version, todo.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.mock.ExpectCommit()
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "todos" SET "deleted_at"=$1 WHERE parent_id = $2 AND "todos"."deleted_at" IS NULL`)).
+		WithArgs(deletedAt, todo.ID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	s.mock.ExpectCommit()
+
+	s.Nil(s.repo.DeleteTodo(context.Background(), s.db, todo))
+	s.True(todo.DeletedAt.Valid)
+	s.True(deletedAt.value.Equal(todo.DeletedAt.Time))
+
+	// The subtasks share the parent's deleted_at, so they come back with it.
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "todos" SET "deleted_at"=$1,"version"=version + 1,"updated_at"=$2 WHERE (id = $3 OR parent_id = $4) AND deleted_at = $5`)).
+		WithArgs(nil, sqlmock.AnyArg(), todo.ID, todo.ID, deletedAt).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	s.mock.ExpectCommit()
+
+	s.Nil(s.repo.RestoreTodo(context.Background(), s.db, todo))
+}
+
+func (s *TodoTestSuite) TestPurgeTodo() {
+	todo := &entity.Todo{}
+	todo.ID = uuid.Must(uuid.NewV7())
+
+	s.Run("Failed to purge todo", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "todos" WHERE "todos"."id" = $1`)).
+			WithArgs(todo.ID).
+			WillReturnError(gorm.ErrInvalidData)
+		s.mock.ExpectRollback()
+
+		err := s.repo.PurgeTodo(context.Background(), s.db, todo)
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+	})
+
+	s.Run("Purge todo successfully", func() {
 		s.mock.ExpectBegin()
 		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "todos" WHERE "todos"."id" = $1`)).
 			WithArgs(todo.ID).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 		s.mock.ExpectCommit()
 
-		err := s.repo.DeleteTodo(context.Background(), s.db, todo)
+		err := s.repo.PurgeTodo(context.Background(), s.db, todo)
+		s.Nil(err)
+	})
+}
+
+func (s *TodoTestSuite) TestPurgeDeletedTodos() {
+	cutoff := time.Now()
+	query := `DELETE FROM "todos" WHERE deleted_at IS NOT NULL AND deleted_at < $1`
+
+	s.Run("Failed to purge trash", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(cutoff).
+			WillReturnError(gorm.ErrInvalidData)
+		s.mock.ExpectRollback()
+
+		result, err := s.repo.PurgeDeletedTodos(context.Background(), s.db, "deleted_at < ?", cutoff)
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+		s.Zero(result)
+	})
+
+	s.Run("Purge trash successfully", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(cutoff).
+			WillReturnResult(sqlmock.NewResult(0, 4))
+		s.mock.ExpectCommit()
+
+		result, err := s.repo.PurgeDeletedTodos(context.Background(), s.db, "deleted_at < ?", cutoff)
+		s.Nil(err)
+		s.Equal(int64(4), result)
+	})
+}
+
+func (s *TodoTestSuite) TestGetTodoChanges() {
+	userID := uuid.NewString()
+	since := repository.ChangeCursor{XID: 7, Seq: 42}
+	query := `SELECT * FROM "todos" WHERE ((user_id = $1 OR project_id IN (SELECT id FROM projects WHERE user_id = $2 UNION SELECT project_id FROM project_members WHERE user_id = $3 AND accepted_at IS NOT NULL))) AND (change_xid, change_seq) > ($4, $5) AND change_xid < pg_snapshot_xmin(pg_current_snapshot())::text::bigint ORDER BY change_xid, change_seq LIMIT $6`
+
+	s.Run("Failed to get changes", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(userID, userID, userID, since.XID, since.Seq, 101).
+			WillReturnError(gorm.ErrInvalidData)
+
+		result, err := s.repo.GetTodoChanges(context.Background(), s.db, userID, since, 101)
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+		s.Nil(result)
+	})
+
+	s.Run("Get changes successfully", func() {
+		todoID := uuid.NewString()
+		s.mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(userID, userID, userID, since.XID, since.Seq, 101).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "change_seq", "created_seq", "change_xid", "created_xid", "deleted_at"}).
+				AddRow(todoID, userID, 57, 12, 9, 3, time.Now()))
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "tag_todos" WHERE "tag_todos"."todo_id" = $1`)).
+			WithArgs(todoID).
+			WillReturnRows(sqlmock.NewRows([]string{"todo_id", "tag_id"}))
+
+		result, err := s.repo.GetTodoChanges(context.Background(), s.db, userID, since, 101)
+		s.Nil(err)
+		s.Len(result, 1)
+		s.Equal(int64(57), result[0].ChangeSeq)
+		s.Equal(int64(12), result[0].CreatedSeq)
+		s.Equal(int64(9), result[0].ChangeXID)
+		s.Equal(int64(3), result[0].CreatedXID)
+		s.True(result[0].DeletedAt.Valid)
+	})
+}
+
+func (s *TodoTestSuite) TestAddTags() {
+	s.Run("Failed to add tags", func() {
+		todo := &entity.Todo{}
+		todo.ID = uuid.Must(uuid.NewV7())
+		tag := &entity.Tag{}
+		tag.ID = uuid.Must(uuid.NewV7())
+
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "todos"`)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "tag_todos"`)).
+			WillReturnError(gorm.ErrInvalidData)
+		s.mock.ExpectRollback()
+
+		err := s.repo.AddTags(context.Background(), s.db, todo, []*entity.Tag{tag})
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+	})
+
+	s.Run("Add tags successfully", func() {
+		todo := &entity.Todo{}
+		todo.ID = uuid.Must(uuid.NewV7())
+		tag := &entity.Tag{}
+		tag.ID = uuid.Must(uuid.NewV7())
+
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "todos"`)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "tag_todos"`)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectCommit()
+
+		err := s.repo.AddTags(context.Background(), s.db, todo, []*entity.Tag{tag})
 		s.Nil(err)
 	})
 }
+
+func (s *TodoTestSuite) TestRemoveTags() {
+	s.Run("Failed to remove tags", func() {
+		todo := &entity.Todo{}
+		todo.ID = uuid.Must(uuid.NewV7())
+		tag := &entity.Tag{}
+		tag.ID = uuid.Must(uuid.NewV7())
+
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "tag_todos" WHERE "tag_todos"."todo_id" = $1 AND "tag_todos"."tag_id" = $2`)).
+			WithArgs(todo.ID, tag.ID).
+			WillReturnError(gorm.ErrInvalidData)
+		s.mock.ExpectRollback()
+
+		err := s.repo.RemoveTags(context.Background(), s.db, todo, []*entity.Tag{tag})
+		s.ErrorAs(err, &gorm.ErrInvalidData)
+	})
+
+	s.Run("Remove tags successfully", func() {
+		todo := &entity.Todo{}
+		todo.ID = uuid.Must(uuid.NewV7())
+		tag := &entity.Tag{}
+		tag.ID = uuid.Must(uuid.NewV7())
+
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "tag_todos" WHERE "tag_todos"."todo_id" = $1 AND "tag_todos"."tag_id" = $2`)).
+			WithArgs(todo.ID, tag.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectCommit()
+
+		err := s.repo.RemoveTags(context.Background(), s.db, todo, []*entity.Tag{tag})
+		s.Nil(err)
+	})
+}
+
+// timeArg matches the first time it is given and from then on only the same
+// time, so a test can check that several statements share one timestamp.
+type timeArg struct {
+	value *time.Time
+}
+
+func (a *timeArg) Match(v driver.Value) bool {
+	t, ok := v.(time.Time)
+	if !ok {
+		return false
+	}
+	if a.value == nil {
+		a.value = &t
+		return true
+	}
+	return a.value.Equal(t)
+}
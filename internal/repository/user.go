@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/sherwin-77/golang-todos/internal/entity"
 	"gorm.io/gorm"
 )
@@ -14,6 +15,13 @@ type UserRepository interface {
 	GetUsersFiltered(ctx context.Context, tx *gorm.DB, limit int, offset int, order interface{}, query interface{}, args ...interface{}) ([]entity.User, error)
 	GetUserByID(ctx context.Context, tx *gorm.DB, id string) (*entity.User, error)
 	GetUserByEmail(ctx context.Context, tx *gorm.DB, email string) (*entity.User, error)
+	GetUserByCalendarToken(ctx context.Context, tx *gorm.DB, tokenHash string) (*entity.User, error)
+	GetUsersByUsernames(ctx context.Context, tx *gorm.DB, usernames []string) ([]entity.User, error)
+	GetAuthLevel(ctx context.Context, tx *gorm.DB, userID string) (int, error)
+	HasAdmin(ctx context.Context, tx *gorm.DB) (bool, error)
+	LockAdminGrant(ctx context.Context, tx *gorm.DB) error
+	UseTOTPCounter(ctx context.Context, tx *gorm.DB, userID string, counter int64) (bool, error)
+	ChangeTokenVersion(ctx context.Context, tx *gorm.DB, userID string) error
 	CreateUser(ctx context.Context, tx *gorm.DB, user *entity.User) error
 	UpdateUser(ctx context.Context, tx *gorm.DB, user *entity.User) error
 	DeleteUser(ctx context.Context, tx *gorm.DB, user *entity.User) error
@@ -68,30 +76,100 @@ func (r *userRepository) GetUserByEmail(ctx context.Context, tx *gorm.DB, email
 	return &user, nil
 }
 
-func (r *userRepository) CreateUser(ctx context.Context, tx *gorm.DB, user *entity.User) error {
-	if err := tx.WithContext(ctx).Create(user).Error; err != nil {
-		return err
+func (r *userRepository) GetUserByCalendarToken(ctx context.Context, tx *gorm.DB, tokenHash string) (*entity.User, error) {
+	var user entity.User
+
+	if err := tx.WithContext(ctx).Where("calendar_token_hash = ?", tokenHash).First(&user).Error; err != nil {
+		return nil, err
 	}
 
-	return nil
+	return &user, nil
 }
 
-func (r *userRepository) UpdateUser(ctx context.Context, tx *gorm.DB, user *entity.User) error {
-	if err := tx.WithContext(ctx).Save(user).Error; err != nil {
-		return err
+func (r *userRepository) GetUsersByUsernames(ctx context.Context, tx *gorm.DB, usernames []string) ([]entity.User, error) {
+	var users []entity.User
+
+	if err := tx.WithContext(ctx).Where("username IN ?", usernames).Find(&users).Error; err != nil {
+		return nil, err
 	}
 
-	return nil
+	return users, nil
 }
 
-func (r *userRepository) DeleteUser(ctx context.Context, tx *gorm.DB, user *entity.User) error {
-	if err := tx.WithContext(ctx).Delete(user).Error; err != nil {
+// GetAuthLevel returns the highest auth level among the user's roles, or zero
+// if the user has none.
+func (r *userRepository) GetAuthLevel(ctx context.Context, tx *gorm.DB, userID string) (int, error) {
+	var level int
+
+	if err := tx.WithContext(ctx).Table("role_users").
+		Joins("JOIN roles ON role_users.role_id = roles.id").
+		Where("role_users.user_id = ?", userID).
+		Select("COALESCE(MAX(roles.auth_level), 0)").
+		Scan(&level).Error; err != nil {
+		return 0, err
+	}
+
+	return level, nil
+}
+
+// HasAdmin reports whether any user holds a role of admin level.
+func (r *userRepository) HasAdmin(ctx context.Context, tx *gorm.DB) (bool, error) {
+	var exists bool
+
+	if err := tx.WithContext(ctx).
+		Raw("SELECT EXISTS (SELECT 1 FROM role_users JOIN roles ON role_users.role_id = roles.id WHERE roles.auth_level >= ?)", 3).
+		Scan(&exists).Error; err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// LockAdminGrant holds an advisory lock until tx ends, so only one transaction
+// at a time can decide whether to grant the first admin.
+func (r *userRepository) LockAdminGrant(ctx context.Context, tx *gorm.DB) error {
+	return tx.WithContext(ctx).Exec("SELECT pg_advisory_xact_lock(hashtext('admin_grant'))").Error
+}
+
+// UseTOTPCounter records counter as the period of the user's last accepted
+// TOTP code. It reports false if a code of that or a later period was already
+// used, so a code cannot be replayed even by concurrent requests.
+func (r *userRepository) UseTOTPCounter(ctx context.Context, tx *gorm.DB, userID string, counter int64) (bool, error) {
+	result := tx.WithContext(ctx).Model(&entity.User{}).
+		Where("id = ? AND totp_last_counter < ?", userID, counter).
+		UpdateColumn("totp_last_counter", counter)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// ChangeTokenVersion moves the user to a new random token version, which
+// revokes every token issued to them so far. The column is create-only on the
+// entity, so it is written through the table.
+func (r *userRepository) ChangeTokenVersion(ctx context.Context, tx *gorm.DB, userID string) error {
+	return tx.WithContext(ctx).Table("users").
+		Where("id = ?", userID).
+		UpdateColumn("token_version", uuid.NewString()).Error
+}
+
+func (r *userRepository) CreateUser(ctx context.Context, tx *gorm.DB, user *entity.User) error {
+	if err := tx.WithContext(ctx).Create(user).Error; err != nil {
 		return err
 	}
 
 	return nil
 }
 
+func (r *userRepository) UpdateUser(ctx context.Context, tx *gorm.DB, user *entity.User) error {
+	return updateVersioned(ctx, tx, user)
+}
+
+func (r *userRepository) DeleteUser(ctx context.Context, tx *gorm.DB, user *entity.User) error {
+	return deleteVersioned(ctx, tx, user)
+}
+
 func (r *userRepository) AddRoles(ctx context.Context, tx *gorm.DB, user *entity.User, roles []*entity.Role) error {
 	fmt.Println(roles)
 	if err := tx.WithContext(ctx).Model(&user).Association("Roles").Append(roles); err != nil {
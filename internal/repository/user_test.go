@@ -153,6 +153,126 @@ func (s *UserTestSuite) TestGetUserByEmail() {
 
 }
 
+func (s *UserTestSuite) TestGetUsersByUsernames() {
+	s.Run("Get users successfully", func() {
+		userID := uuid.NewString()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE username IN ($1,$2)`)).
+			WithArgs("alice", "bob").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "username"}).
+				AddRow(userID, "alice"))
+
+		result, err := s.repo.GetUsersByUsernames(context.Background(), s.db, []string{"alice", "bob"})
+		s.Nil(err)
+		s.Len(result, 1)
+		s.Equal("alice", result[0].Username)
+	})
+}
+
+func (s *UserTestSuite) TestGetUserByCalendarToken() {
+	s.Run("User not found", func() {
+		tokenHash := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE calendar_token_hash = $1 ORDER BY "users"."id" LIMIT $2`)).
+			WithArgs(tokenHash, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		result, err := s.repo.GetUserByCalendarToken(context.Background(), s.db, tokenHash)
+		s.ErrorAs(err, &gorm.ErrRecordNotFound)
+		s.Nil(result)
+	})
+
+	s.Run("Get user successfully", func() {
+		tokenHash := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+		id := uuid.NewString()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE calendar_token_hash = $1 ORDER BY "users"."id" LIMIT $2`)).
+			WithArgs(tokenHash, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "calendar_token_hash"}).
+				AddRow(id, tokenHash))
+
+		result, err := s.repo.GetUserByCalendarToken(context.Background(), s.db, tokenHash)
+		s.Nil(err)
+		s.NotNil(result)
+		s.Equal(id, result.ID.String())
+	})
+}
+
+func (s *UserTestSuite) TestGetAuthLevel() {
+	s.Run("Get auth level successfully", func() {
+		userID := uuid.NewString()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(MAX(roles.auth_level), 0) FROM "role_users" JOIN roles ON role_users.role_id = roles.id WHERE role_users.user_id = $1`)).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(2))
+
+		result, err := s.repo.GetAuthLevel(context.Background(), s.db, userID)
+		s.Nil(err)
+		s.Equal(2, result)
+	})
+}
+
+func (s *UserTestSuite) TestHasAdmin() {
+	s.Run("Has admin successfully", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS (SELECT 1 FROM role_users JOIN roles ON role_users.role_id = roles.id WHERE roles.auth_level >= $1)`)).
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		result, err := s.repo.HasAdmin(context.Background(), s.db)
+		s.Nil(err)
+		s.True(result)
+	})
+}
+
+func (s *UserTestSuite) TestLockAdminGrant() {
+	s.Run("Lock admin grant successfully", func() {
+		s.mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock(hashtext('admin_grant'))`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := s.repo.LockAdminGrant(context.Background(), s.db)
+		s.Nil(err)
+	})
+}
+
+func (s *UserTestSuite) TestUseTOTPCounter() {
+	userID := uuid.NewString()
+
+	s.Run("Counter already used", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "totp_last_counter"=$1 WHERE id = $2 AND totp_last_counter < $3`)).
+			WithArgs(int64(100), userID, int64(100)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		s.mock.ExpectCommit()
+
+		used, err := s.repo.UseTOTPCounter(context.Background(), s.db, userID, 100)
+		s.Nil(err)
+		s.False(used)
+	})
+
+	s.Run("Use counter successfully", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "totp_last_counter"=$1 WHERE id = $2 AND totp_last_counter < $3`)).
+			WithArgs(int64(101), userID, int64(101)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectCommit()
+
+		used, err := s.repo.UseTOTPCounter(context.Background(), s.db, userID, 101)
+		s.Nil(err)
+		s.True(used)
+	})
+}
+
+func (s *UserTestSuite) TestChangeTokenVersion() {
+	userID := uuid.NewString()
+
+	s.Run("Change token version successfully", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "token_version"=$1 WHERE id = $2`)).
+			WithArgs(sqlmock.AnyArg(), userID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectCommit()
+
+		err := s.repo.ChangeTokenVersion(context.Background(), s.db, userID)
+		s.Nil(err)
+	})
+}
+
 func (s *UserTestSuite) TestCreateUser() {
 	s.Run("Failed to create user", func() {
 		user := &entity.User{}
@@ -176,6 +296,7 @@ func (s *UserTestSuite) TestCreateUser() {
 
 		err := s.repo.CreateUser(context.Background(), s.db, user)
 		s.Nil(err)
+		s.NotEmpty(user.TokenVersion)
 	})
 }
 
@@ -193,6 +314,20 @@ func (s *UserTestSuite) TestUpdateUser() {
 		s.ErrorAs(err, &gorm.ErrInvalidData)
 	})
 
+	s.Run("Update stale user", func() {
+		user := &entity.User{}
+		user.ID = uuid.Must(uuid.NewV7())
+		user.Version = 2
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users"`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		s.mock.ExpectCommit()
+
+		err := s.repo.UpdateUser(context.Background(), s.db, user)
+		s.ErrorIs(err, repository.ErrVersionConflict)
+		s.Equal(2, user.Version)
+	})
+
 	s.Run("Update user successfully", func() {
 		user := &entity.User{}
 		user.ID = uuid.Must(uuid.NewV7())
@@ -213,8 +348,8 @@ func (s *UserTestSuite) TestDeleteUser() {
 		user.ID = uuid.Must(uuid.NewV7())
 
 		s.mock.ExpectBegin()
-		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "users" WHERE "users"."id" = $1`)).
-			WithArgs(user.ID).
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "users" WHERE version = $1 AND "users"."id" = $2`)).
+			WithArgs(user.Version, user.ID).
 			WillReturnError(gorm.ErrInvalidData)
 		s.mock.ExpectRollback()
 
@@ -222,13 +357,26 @@ func (s *UserTestSuite) TestDeleteUser() {
 		s.ErrorAs(err, &gorm.ErrInvalidData)
 	})
 
+	s.Run("Delete stale user", func() {
+		user := &entity.User{}
+		user.ID = uuid.Must(uuid.NewV7())
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "users" WHERE version = $1 AND "users"."id" = $2`)).
+			WithArgs(user.Version, user.ID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		s.mock.ExpectCommit()
+
+		err := s.repo.DeleteUser(context.Background(), s.db, user)
+		s.ErrorIs(err, repository.ErrVersionConflict)
+	})
+
 	s.Run("Delete user successfully", func() {
 		user := &entity.User{}
 		user.ID = uuid.Must(uuid.NewV7())
 
 		s.mock.ExpectBegin()
-		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "users" WHERE "users"."id" = $1`)).
-			WithArgs(user.ID).
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "users" WHERE version = $1 AND "users"."id" = $2`)).
+			WithArgs(user.Version, user.ID).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 		s.mock.ExpectCommit()
 
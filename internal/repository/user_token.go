@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UserTokenRepository interface {
+	BaseRepository
+	GetUserTokenByHash(ctx context.Context, tx *gorm.DB, purpose string, tokenHash string) (*entity.UserToken, error)
+	GetLatestUserToken(ctx context.Context, tx *gorm.DB, userID string, purpose string) (*entity.UserToken, error)
+	CreateUserToken(ctx context.Context, tx *gorm.DB, token *entity.UserToken) error
+	DeleteUserTokens(ctx context.Context, tx *gorm.DB, userID string, purpose string) error
+}
+
+type userTokenRepository struct {
+	baseRepository
+}
+
+func NewUserTokenRepository(db *gorm.DB) UserTokenRepository {
+	return &userTokenRepository{baseRepository{db}}
+}
+
+// GetUserTokenByHash locks the token until the transaction ends, so it can
+// only be used once.
+func (r *userTokenRepository) GetUserTokenByHash(ctx context.Context, tx *gorm.DB, purpose string, tokenHash string) (*entity.UserToken, error) {
+	var token entity.UserToken
+
+	if err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("purpose = ? AND token_hash = ?", purpose, tokenHash).First(&token).Error; err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *userTokenRepository) GetLatestUserToken(ctx context.Context, tx *gorm.DB, userID string, purpose string) (*entity.UserToken, error) {
+	var token entity.UserToken
+
+	if err := tx.WithContext(ctx).Where("user_id = ? AND purpose = ?", userID, purpose).Order("created_at DESC").First(&token).Error; err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *userTokenRepository) CreateUserToken(ctx context.Context, tx *gorm.DB, token *entity.UserToken) error {
+	if err := tx.WithContext(ctx).Create(token).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteUserTokens removes every token of the user issued for purpose.
+func (r *userTokenRepository) DeleteUserTokens(ctx context.Context, tx *gorm.DB, userID string, purpose string) error {
+	if err := tx.WithContext(ctx).Where("user_id = ? AND purpose = ?", userID, purpose).Delete(&entity.UserToken{}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package repository_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type UserTokenTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	mock sqlmock.Sqlmock
+	repo repository.UserTokenRepository
+}
+
+func TestUserTokenRepository(t *testing.T) {
+	suite.Run(t, new(UserTokenTestSuite))
+}
+
+func (s *UserTokenTestSuite) SetupSuite() {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		s.FailNow("Failed to create mock db", err.Error())
+	}
+
+	s.db, err = gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		s.FailNow("Failed to open mock db", err)
+	}
+
+	s.mock = mock
+	s.repo = repository.NewUserTokenRepository(s.db)
+}
+
+func (s *UserTokenTestSuite) AfterTest(string, string) {
+	if err := s.mock.ExpectationsWereMet(); err != nil {
+		s.FailNow("Failed to meet expectations", err)
+	}
+}
+
+func (s *UserTokenTestSuite) TestGetUserTokenByHash() {
+	s.Run("User token not found", func() {
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_tokens" WHERE purpose = $1 AND token_hash = $2 ORDER BY "user_tokens"."id" LIMIT $3 FOR UPDATE`)).
+			WithArgs(entity.UserTokenPasswordReset, "hash", 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		result, err := s.repo.GetUserTokenByHash(context.Background(), s.db, entity.UserTokenPasswordReset, "hash")
+		s.ErrorIs(err, gorm.ErrRecordNotFound)
+		s.Nil(result)
+	})
+
+	s.Run("Get user token successfully", func() {
+		id := uuid.NewString()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_tokens" WHERE purpose = $1 AND token_hash = $2 ORDER BY "user_tokens"."id" LIMIT $3 FOR UPDATE`)).
+			WithArgs(entity.UserTokenPasswordReset, "hash", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "purpose", "token_hash"}).AddRow(id, entity.UserTokenPasswordReset, "hash"))
+
+		result, err := s.repo.GetUserTokenByHash(context.Background(), s.db, entity.UserTokenPasswordReset, "hash")
+		s.Nil(err)
+		s.Equal(id, result.ID.String())
+	})
+}
+
+func (s *UserTokenTestSuite) TestGetLatestUserToken() {
+	userID := uuid.NewString()
+
+	s.Run("Get latest user token successfully", func() {
+		id := uuid.NewString()
+		s.mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_tokens" WHERE user_id = $1 AND purpose = $2 ORDER BY created_at DESC,"user_tokens"."id" LIMIT $3`)).
+			WithArgs(userID, entity.UserTokenEmailVerification, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "purpose"}).AddRow(id, userID, entity.UserTokenEmailVerification))
+
+		result, err := s.repo.GetLatestUserToken(context.Background(), s.db, userID, entity.UserTokenEmailVerification)
+		s.Nil(err)
+		s.Equal(id, result.ID.String())
+	})
+}
+
+func (s *UserTokenTestSuite) TestCreateUserToken() {
+	s.Run("Create user token successfully", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "user_tokens"`)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectCommit()
+
+		err := s.repo.CreateUserToken(context.Background(), s.db, &entity.UserToken{
+			UserID:    uuid.New(),
+			Purpose:   entity.UserTokenPasswordReset,
+			TokenHash: "hash",
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+		s.Nil(err)
+	})
+}
+
+func (s *UserTokenTestSuite) TestDeleteUserTokens() {
+	userID := uuid.NewString()
+
+	s.Run("Delete user tokens successfully", func() {
+		s.mock.ExpectBegin()
+		s.mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "user_tokens" WHERE user_id = $1 AND purpose = $2`)).
+			WithArgs(userID, entity.UserTokenPasswordReset).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectCommit()
+
+		err := s.repo.DeleteUserTokens(context.Background(), s.db, userID, entity.UserTokenPasswordReset)
+		s.Nil(err)
+	})
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/pkg/response"
+	"gorm.io/gorm"
+)
+
+// GetAssignedTodos lists the todos assigned to the user, soonest due first.
+// Like the due date views, the list is not cached.
+func (s *todoService) GetAssignedTodos(ctx context.Context, userID string, query dto.AssignedTodoQuery) ([]entity.Todo, *response.Meta, error) {
+	page, perPage := normalizePage(query.Page, query.PerPage)
+	condition := "assignee_id = ? AND parent_id IS NULL"
+	args := []interface{}{userID}
+	if query.IsCompleted != nil {
+		condition += " AND is_completed = ?"
+		args = append(args, *query.IsCompleted)
+	}
+
+	db := s.todoRepository.SingleTransaction()
+
+	total, err := s.todoRepository.CountTodosFiltered(ctx, db, condition, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	todos, err := s.todoRepository.GetTodosFiltered(ctx, db, perPage, (page-1)*perPage, "due_at, id", condition, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return todos, response.NewMeta(page, perPage, int(total)), nil
+}
+
+// AssignTodo hands the todo to another user, or takes it back when no assignee
+// is given. Only owners of the todo may assign it, and both the previous and
+// the new assignee are notified.
+func (s *todoService) AssignTodo(ctx context.Context, request dto.AssignTodoRequest, userID string) (*entity.Todo, error) {
+	var todo *entity.Todo
+	var audience []string
+
+	if err := s.todoRepository.WithTransaction(func(tx *gorm.DB) error {
+		var err error
+		todo, err = s.todoRepository.GetTodoByID(ctx, tx, request.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkTodoAccess(ctx, tx, s.projectRepository, todo, userID, entity.ProjectOwner); err != nil {
+			return err
+		}
+
+		if err := checkVersion(request.Version, todo.Version); err != nil {
+			return err
+		}
+
+		var assigneeID *uuid.UUID
+		if request.AssigneeID != "" {
+			assignee, err := s.userRepository.GetUserByID(ctx, tx, request.AssigneeID)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, "User not found")
+			}
+			if err != nil {
+				return err
+			}
+			assigneeID = &assignee.ID
+		}
+
+		if sameID(todo.AssigneeID, assigneeID) {
+			return nil
+		}
+
+		previousID := todo.AssigneeID
+		todo.AssigneeID = assigneeID
+		if err := s.todoRepository.UpdateTodo(ctx, tx, todo); err != nil {
+			return err
+		}
+
+		notifications := assignmentNotifications(todo, previousID, userID)
+		if len(notifications) > 0 {
+			if err := s.notificationRepository.CreateNotifications(ctx, tx, notifications); err != nil {
+				return err
+			}
+		}
+
+		audience, err = todoAudience(ctx, tx, s.projectRepository, todo)
+		if err != nil {
+			return err
+		}
+
+		// Reload so the response embeds the new assignee's profile.
+		todo, err = s.todoRepository.GetTodoByID(ctx, tx, request.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.Del("todos:" + todo.ID.String()); err != nil {
+		return nil, err
+	}
+
+	if err := delUserKeys(s.cache, "todos:all:", audience); err != nil {
+		return nil, err
+	}
+
+	return todo, nil
+}
+
+// assignmentNotifications tells the previous and the new assignee about a
+// reassignment. Users are not notified about their own actions.
+func assignmentNotifications(todo *entity.Todo, previousID *uuid.UUID, actorID string) []entity.Notification {
+	actor := uuid.MustParse(actorID)
+	todoID := todo.ID
+
+	var notifications []entity.Notification
+	if previousID != nil && *previousID != actor {
+		notifications = append(notifications, entity.Notification{
+			UserID:  *previousID,
+			ActorID: &actor,
+			TodoID:  &todoID,
+			Type:    entity.NotificationTodoUnassigned,
+			Message: "You were unassigned from \"" + todo.Title + "\"",
+		})
+	}
+	if todo.AssigneeID != nil && *todo.AssigneeID != actor {
+		notifications = append(notifications, entity.Notification{
+			UserID:  *todo.AssigneeID,
+			ActorID: &actor,
+			TodoID:  &todoID,
+			Type:    entity.NotificationTodoAssigned,
+			Message: "You were assigned \"" + todo.Title + "\"",
+		})
+	}
+
+	return notifications
+}
@@ -0,0 +1,209 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/pkg/patch"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func (s *TodoTestSuite) TestAssignTodo() {
+	ownerID := uuid.NewString()
+	todoID := uuid.NewString()
+	previous := &entity.User{}
+	previous.ID = uuid.New()
+	assignee := &entity.User{}
+	assignee.ID = uuid.New()
+	newTodo := func() *entity.Todo {
+		todo := &entity.Todo{Title: "Write report", UserID: uuid.MustParse(ownerID), AssigneeID: &previous.ID}
+		todo.ID = uuid.MustParse(todoID)
+		return todo
+	}
+
+	s.Run("Assignee cannot reassign", func() {
+		var e *echo.HTTPError
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(newTodo(), nil)
+			return f(&gorm.DB{})
+		})
+		result, err := s.todoService.AssignTodo(context.Background(), dto.AssignTodoRequest{ID: todoID, AssigneeID: assignee.ID.String()}, previous.ID.String())
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusForbidden, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Stranger cannot see todo", func() {
+		var e *echo.HTTPError
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(newTodo(), nil)
+			return f(&gorm.DB{})
+		})
+		result, err := s.todoService.AssignTodo(context.Background(), dto.AssignTodoRequest{ID: todoID, AssigneeID: assignee.ID.String()}, uuid.NewString())
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusNotFound, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Unknown assignee", func() {
+		var e *echo.HTTPError
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(newTodo(), nil)
+			s.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), assignee.ID.String()).Return(nil, gorm.ErrRecordNotFound)
+			return f(&gorm.DB{})
+		})
+		result, err := s.todoService.AssignTodo(context.Background(), dto.AssignTodoRequest{ID: todoID, AssigneeID: assignee.ID.String()}, ownerID)
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusNotFound, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Same assignee is a no-op", func() {
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(newTodo(), nil)
+			s.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), previous.ID.String()).Return(previous, nil)
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("todos:" + todoID).Return(nil)
+		result, err := s.todoService.AssignTodo(context.Background(), dto.AssignTodoRequest{ID: todoID, AssigneeID: previous.ID.String()}, ownerID)
+
+		s.Nil(err)
+		s.Equal(previous.ID, *result.AssigneeID)
+	})
+
+	s.Run("Successfully reassign todo", func() {
+		var notifications []entity.Notification
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(newTodo(), nil)
+			s.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), assignee.ID.String()).Return(assignee, nil)
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *gorm.DB, todo *entity.Todo) error {
+				s.Equal(assignee.ID, *todo.AssigneeID)
+				return nil
+			})
+			s.notifyRepo.EXPECT().CreateNotifications(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *gorm.DB, n []entity.Notification) error {
+				notifications = n
+				return nil
+			})
+			reloaded := newTodo()
+			reloaded.AssigneeID = &assignee.ID
+			reloaded.Assignee = &entity.UserProfile{ID: assignee.ID, Username: "assignee"}
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(reloaded, nil)
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("todos:" + todoID).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + ownerID).Return(nil)
+		result, err := s.todoService.AssignTodo(context.Background(), dto.AssignTodoRequest{ID: todoID, AssigneeID: assignee.ID.String()}, ownerID)
+
+		s.Nil(err)
+		s.Equal("assignee", result.Assignee.Username)
+		s.Len(notifications, 2)
+		s.Equal(previous.ID, notifications[0].UserID)
+		s.Equal(entity.NotificationTodoUnassigned, notifications[0].Type)
+		s.Equal(assignee.ID, notifications[1].UserID)
+		s.Equal(entity.NotificationTodoAssigned, notifications[1].Type)
+	})
+
+	s.Run("Assignee can view todo", func() {
+		todo := newTodo()
+		data, _ := json.Marshal(todo)
+		s.cache.EXPECT().Get("todos:" + todoID).Return(string(data))
+		s.projectRepo.EXPECT().SingleTransaction().Return(nil)
+		result, err := s.todoService.GetTodoByID(context.Background(), todoID, previous.ID.String())
+
+		s.Nil(err)
+		s.Equal(todo.ID, result.ID)
+	})
+}
+
+func (s *TodoTestSuite) TestAssigneeAccess() {
+	ownerID := uuid.NewString()
+	todoID := uuid.NewString()
+	assigneeID := uuid.New()
+	newTodo := func() *entity.Todo {
+		todo := &entity.Todo{Title: "Write report", UserID: uuid.MustParse(ownerID), AssigneeID: &assigneeID}
+		todo.ID = uuid.MustParse(todoID)
+		return todo
+	}
+
+	s.Run("Assignee can complete todo", func() {
+		todo := newTodo()
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), todo).Return(nil)
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("todos:" + todoID).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + ownerID).Return(nil)
+		result, err := s.todoService.UpdateTodo(context.Background(), dto.UpdateTodoRequest{ID: todoID, IsCompleted: patch.Value(true)}, assigneeID.String())
+
+		s.Nil(err)
+		s.True(result.IsCompleted)
+	})
+
+	s.Run("Assignee cannot edit todo", func() {
+		var e *echo.HTTPError
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(newTodo(), nil)
+			return f(&gorm.DB{})
+		})
+		result, err := s.todoService.UpdateTodo(context.Background(), dto.UpdateTodoRequest{
+			ID:          todoID,
+			Title:       patch.Value("Skip report"),
+			IsCompleted: patch.Value(true),
+		}, assigneeID.String())
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusForbidden, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Assignee cannot delete todo", func() {
+		var e *echo.HTTPError
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(newTodo(), nil)
+			return f(&gorm.DB{})
+		})
+		err := s.todoService.DeleteTodo(context.Background(), todoID, assigneeID.String(), 0)
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusForbidden, e.Code)
+	})
+
+	s.Run("Assignee cannot move todo", func() {
+		var e *echo.HTTPError
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(newTodo(), nil)
+			return f(&gorm.DB{})
+		})
+		result, err := s.todoService.UpdateTodo(context.Background(), dto.UpdateTodoRequest{ID: todoID, ProjectID: patch.Value(uuid.NewString())}, assigneeID.String())
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusForbidden, e.Code)
+		s.Nil(result)
+	})
+}
+
+func (s *TodoTestSuite) TestGetAssignedTodos() {
+	userID := uuid.NewString()
+	completed := false
+
+	s.Run("Successfully get assigned todos", func() {
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().CountTodosFiltered(gomock.Any(), gomock.Any(), "assignee_id = ? AND parent_id IS NULL AND is_completed = ?", userID, false).Return(int64(1), nil)
+		s.repo.EXPECT().GetTodosFiltered(gomock.Any(), gomock.Any(), 10, 0, "due_at, id", "assignee_id = ? AND parent_id IS NULL AND is_completed = ?", userID, false).Return([]entity.Todo{{}}, nil)
+		result, meta, err := s.todoService.GetAssignedTodos(context.Background(), userID, dto.AssignedTodoQuery{IsCompleted: &completed})
+
+		s.Nil(err)
+		s.Len(result, 1)
+		s.Equal(1, meta.Total)
+	})
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/configs"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"github.com/sherwin-77/golang-todos/pkg/storage"
+	"gorm.io/gorm"
+)
+
+type AttachmentService interface {
+	GetAttachments(ctx context.Context, todoID string, userID string) ([]entity.Attachment, error)
+	CreateAttachment(ctx context.Context, upload dto.AttachmentUpload, userID string) (*entity.Attachment, error)
+	GetAttachmentURL(ctx context.Context, todoID string, id string, userID string) (*dto.AttachmentURLResponse, error)
+	DeleteAttachment(ctx context.Context, todoID string, id string, userID string) error
+}
+
+type attachmentService struct {
+	attachmentRepository repository.AttachmentRepository
+	todoRepository       repository.TodoRepository
+	projectRepository    repository.ProjectRepository
+	blobStore            storage.BlobStore
+	config               configs.UploadConfig
+}
+
+func NewAttachmentService(
+	attachmentRepository repository.AttachmentRepository,
+	todoRepository repository.TodoRepository,
+	projectRepository repository.ProjectRepository,
+	blobStore storage.BlobStore,
+	config configs.UploadConfig,
+) AttachmentService {
+	return &attachmentService{attachmentRepository, todoRepository, projectRepository, blobStore, config}
+}
+
+func (s *attachmentService) GetAttachments(ctx context.Context, todoID string, userID string) ([]entity.Attachment, error) {
+	db := s.attachmentRepository.SingleTransaction()
+
+	if err := s.checkAccess(ctx, db, todoID, userID, entity.ProjectViewer); err != nil {
+		return nil, err
+	}
+
+	return s.attachmentRepository.GetAttachmentsByTodoID(ctx, db, todoID)
+}
+
+// CreateAttachment stores the upload and records it on the todo. The blob is
+// written first, and removed again if the record cannot be saved.
+func (s *attachmentService) CreateAttachment(ctx context.Context, upload dto.AttachmentUpload, userID string) (*entity.Attachment, error) {
+	fileName, err := attachmentName(upload.FileName)
+	if err != nil {
+		return nil, err
+	}
+
+	db := s.attachmentRepository.SingleTransaction()
+	if err := s.checkAccess(ctx, db, upload.TodoID, userID, entity.ProjectEditor); err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, err
+	}
+	todoID := uuid.MustParse(upload.TodoID)
+	uploaderID := uuid.MustParse(userID)
+
+	attachment := &entity.Attachment{
+		TodoID:      &todoID,
+		UserID:      &uploaderID,
+		FileName:    fileName,
+		ContentType: upload.ContentType,
+		Size:        upload.Size,
+		StorageKey:  "attachments/" + id.String(),
+	}
+	attachment.ID = id
+
+	if err := s.blobStore.Put(ctx, attachment.StorageKey, upload.Body, upload.Size, upload.ContentType); err != nil {
+		return nil, err
+	}
+
+	if err := s.attachmentRepository.CreateAttachment(ctx, db, attachment); err != nil {
+		_ = s.blobStore.Delete(ctx, attachment.StorageKey)
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// GetAttachmentURL returns a short-lived URL that downloads the attachment
+// without further authentication.
+func (s *attachmentService) GetAttachmentURL(ctx context.Context, todoID string, id string, userID string) (*dto.AttachmentURLResponse, error) {
+	db := s.attachmentRepository.SingleTransaction()
+
+	if err := s.checkAccess(ctx, db, todoID, userID, entity.ProjectViewer); err != nil {
+		return nil, err
+	}
+
+	attachment, err := s.getAttachment(ctx, db, todoID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := s.blobStore.SignedURL(ctx, attachment.StorageKey, storage.URLOptions{
+		Expires:     s.config.URLExpiry,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.AttachmentURLResponse{URL: url, ExpiresAt: time.Now().Add(s.config.URLExpiry)}, nil
+}
+
+func (s *attachmentService) DeleteAttachment(ctx context.Context, todoID string, id string, userID string) error {
+	var attachment *entity.Attachment
+
+	if err := s.attachmentRepository.WithTransaction(func(tx *gorm.DB) error {
+		if err := s.checkAccess(ctx, tx, todoID, userID, entity.ProjectEditor); err != nil {
+			return err
+		}
+
+		var err error
+		attachment, err = s.getAttachment(ctx, tx, todoID, id)
+		if err != nil {
+			return err
+		}
+
+		return s.attachmentRepository.DeleteAttachment(ctx, tx, attachment)
+	}); err != nil {
+		return err
+	}
+
+	return s.blobStore.Delete(ctx, attachment.StorageKey)
+}
+
+// checkAccess requires the user to hold at least the given role on the todo.
+func (s *attachmentService) checkAccess(ctx context.Context, tx *gorm.DB, todoID string, userID string, required entity.ProjectRole) error {
+	todo, err := s.todoRepository.GetTodoByID(ctx, tx, todoID)
+	if err != nil {
+		return err
+	}
+
+	return checkTodoAccess(ctx, tx, s.projectRepository, todo, userID, required)
+}
+
+// getAttachment loads an attachment of the todo. Attachments of other todos
+// are reported as not found.
+func (s *attachmentService) getAttachment(ctx context.Context, tx *gorm.DB, todoID string, id string) (*entity.Attachment, error) {
+	attachment, err := s.attachmentRepository.GetAttachmentByID(ctx, tx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && (attachment.TodoID == nil || attachment.TodoID.String() != todoID)) {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Attachment not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// attachmentName keeps only the base name of an uploaded file, whichever
+// path separator the client used.
+func attachmentName(name string) (string, error) {
+	name = path.Base(strings.ReplaceAll(strings.TrimSpace(name), "\\", "/"))
+	if name == "." || name == "/" || name == ".." {
+		name = "file"
+	}
+
+	if len(name) > 255 {
+		return "", echo.NewHTTPError(http.StatusUnprocessableEntity, "File name is too long")
+	}
+
+	return name, nil
+}
@@ -0,0 +1,179 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/configs"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/service"
+	"github.com/sherwin-77/golang-todos/pkg/storage"
+	mock_storage "github.com/sherwin-77/golang-todos/test/mock/pkg/storage"
+	mock_repository "github.com/sherwin-77/golang-todos/test/mock/repository"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type AttachmentTestSuite struct {
+	suite.Suite
+	ctrl              *gomock.Controller
+	repo              *mock_repository.MockAttachmentRepository
+	todoRepo          *mock_repository.MockTodoRepository
+	projectRepo       *mock_repository.MockProjectRepository
+	blobStore         *mock_storage.MockBlobStore
+	attachmentService service.AttachmentService
+}
+
+func (s *AttachmentTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.repo = mock_repository.NewMockAttachmentRepository(s.ctrl)
+	s.todoRepo = mock_repository.NewMockTodoRepository(s.ctrl)
+	s.projectRepo = mock_repository.NewMockProjectRepository(s.ctrl)
+	s.blobStore = mock_storage.NewMockBlobStore(s.ctrl)
+	s.attachmentService = service.NewAttachmentService(s.repo, s.todoRepo, s.projectRepo, s.blobStore, configs.UploadConfig{
+		MaxSize:   1 << 20,
+		URLExpiry: 15 * time.Minute,
+	})
+}
+
+func TestAttachmentService(t *testing.T) {
+	suite.Run(t, new(AttachmentTestSuite))
+}
+
+func (s *AttachmentTestSuite) TestCreateAttachment() {
+	ownerID := uuid.NewString()
+	project := &entity.Project{UserID: uuid.MustParse(ownerID)}
+	project.ID = uuid.New()
+	todo := &entity.Todo{UserID: uuid.MustParse(ownerID), ProjectID: &project.ID}
+	todo.ID = uuid.New()
+	todoID := todo.ID.String()
+	upload := func() dto.AttachmentUpload {
+		return dto.AttachmentUpload{
+			TodoID:      todoID,
+			FileName:    `C:\Users\alice\report.pdf`,
+			ContentType: "application/pdf",
+			Size:        5,
+			Body:        strings.NewReader("%PDF-"),
+		}
+	}
+
+	s.Run("Viewers cannot upload", func() {
+		var e *echo.HTTPError
+		viewerID := uuid.NewString()
+		acceptedAt := time.Now()
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.todoRepo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+		s.projectRepo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), project.ID.String()).Return(project, nil)
+		s.projectRepo.EXPECT().GetMember(gomock.Any(), gomock.Any(), project.ID.String(), viewerID).Return(&entity.ProjectMember{Role: entity.ProjectViewer, AcceptedAt: &acceptedAt}, nil)
+		result, err := s.attachmentService.CreateAttachment(context.Background(), upload(), viewerID)
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusForbidden, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Blob is removed when the record fails", func() {
+		errorTest := errors.New("create attachment error")
+		var key string
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.todoRepo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+		s.blobStore.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), int64(5), "application/pdf").
+			DoAndReturn(func(_ context.Context, k string, _ interface{}, _ int64, _ string) error {
+				key = k
+				return nil
+			})
+		s.repo.EXPECT().CreateAttachment(gomock.Any(), gomock.Any(), gomock.Any()).Return(errorTest)
+		s.blobStore.EXPECT().Delete(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, k string) error {
+			s.Equal(key, k)
+			return nil
+		})
+		result, err := s.attachmentService.CreateAttachment(context.Background(), upload(), ownerID)
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+	})
+
+	s.Run("Successfully create attachment", func() {
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.todoRepo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+		s.blobStore.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), int64(5), "application/pdf").Return(nil)
+		s.repo.EXPECT().CreateAttachment(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		result, err := s.attachmentService.CreateAttachment(context.Background(), upload(), ownerID)
+
+		s.Nil(err)
+		s.Equal("report.pdf", result.FileName)
+		s.Equal(todo.ID, *result.TodoID)
+		s.Equal("attachments/"+result.ID.String(), result.StorageKey)
+	})
+}
+
+func (s *AttachmentTestSuite) TestGetAttachmentURL() {
+	ownerID := uuid.NewString()
+	todo := &entity.Todo{UserID: uuid.MustParse(ownerID)}
+	todo.ID = uuid.New()
+	todoID := todo.ID.String()
+	attachment := &entity.Attachment{TodoID: &todo.ID, FileName: "report.pdf", ContentType: "application/pdf", StorageKey: "attachments/1"}
+	attachment.ID = uuid.New()
+	attachmentID := attachment.ID.String()
+
+	s.Run("Attachment of another todo", func() {
+		var e *echo.HTTPError
+		otherTodoID := uuid.New()
+		other := &entity.Attachment{TodoID: &otherTodoID}
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.todoRepo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+		s.repo.EXPECT().GetAttachmentByID(gomock.Any(), gomock.Any(), attachmentID).Return(other, nil)
+		result, err := s.attachmentService.GetAttachmentURL(context.Background(), todoID, attachmentID, ownerID)
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusNotFound, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Successfully sign URL", func() {
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.todoRepo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+		s.repo.EXPECT().GetAttachmentByID(gomock.Any(), gomock.Any(), attachmentID).Return(attachment, nil)
+		s.blobStore.EXPECT().SignedURL(gomock.Any(), "attachments/1", storage.URLOptions{
+			Expires:     15 * time.Minute,
+			FileName:    "report.pdf",
+			ContentType: "application/pdf",
+		}).Return("https://blobs.example.com/attachments/1?signature=abc", nil)
+		result, err := s.attachmentService.GetAttachmentURL(context.Background(), todoID, attachmentID, ownerID)
+
+		s.Nil(err)
+		s.Equal("https://blobs.example.com/attachments/1?signature=abc", result.URL)
+		s.WithinDuration(time.Now().Add(15*time.Minute), result.ExpiresAt, time.Minute)
+	})
+}
+
+func (s *AttachmentTestSuite) TestDeleteAttachment() {
+	ownerID := uuid.NewString()
+	todo := &entity.Todo{UserID: uuid.MustParse(ownerID)}
+	todo.ID = uuid.New()
+	todoID := todo.ID.String()
+	attachment := &entity.Attachment{TodoID: &todo.ID, StorageKey: "attachments/1"}
+	attachment.ID = uuid.New()
+	attachmentID := attachment.ID.String()
+
+	s.Run("Successfully delete attachment", func() {
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.todoRepo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+			s.repo.EXPECT().GetAttachmentByID(gomock.Any(), gomock.Any(), attachmentID).Return(attachment, nil)
+			s.repo.EXPECT().DeleteAttachment(gomock.Any(), gomock.Any(), attachment).Return(nil)
+			return f(&gorm.DB{})
+		})
+		s.blobStore.EXPECT().Delete(gomock.Any(), "attachments/1").Return(nil)
+		err := s.attachmentService.DeleteAttachment(context.Background(), todoID, attachmentID, ownerID)
+
+		s.Nil(err)
+	})
+}
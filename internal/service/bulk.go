@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"gorm.io/gorm"
+)
+
+// cacheKeys collects the cache keys touched by a request so they can be
+// dropped together once it commits.
+type cacheKeys struct {
+	keys []string
+	seen map[string]bool
+}
+
+func (c *cacheKeys) add(key string) {
+	if c.seen == nil {
+		c.seen = map[string]bool{}
+	}
+
+	if !c.seen[key] {
+		c.seen[key] = true
+		c.keys = append(c.keys, key)
+	}
+}
+
+// BulkTodos runs every item in one transaction, each under its own savepoint.
+// In best-effort mode a rejected item is rolled back to its savepoint, so it
+// leaves nothing behind, and the transaction carries on with the next one.
+func (s *todoService) BulkTodos(ctx context.Context, request dto.BulkTodoRequest, userID string) ([]dto.BulkTodoResult, error) {
+	bestEffort := request.Mode == dto.BulkModeBestEffort
+	results := make([]dto.BulkTodoResult, len(request.Items))
+	var keys cacheKeys
+
+	if err := s.todoRepository.WithTransaction(func(tx *gorm.DB) error {
+		ids := make([]string, 0, len(request.Items))
+		for _, item := range request.Items {
+			ids = append(ids, item.ID)
+		}
+
+		todos, err := s.todoRepository.GetTodosByIDs(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+
+		loaded := map[string]*entity.Todo{}
+		for i := range todos {
+			loaded[todos[i].ID.String()] = &todos[i]
+		}
+
+		for i, item := range request.Items {
+			results[i] = dto.BulkTodoResult{Index: i, ID: item.ID, Action: item.Action}
+
+			var err error = echo.NewHTTPError(http.StatusNotFound, "Todo not found")
+			if todo, ok := loaded[item.ID]; ok {
+				saved := *todo
+				err = s.todoRepository.WithSavepoint(tx, func(tx *gorm.DB) error {
+					if err := s.checkBulkItem(ctx, tx, todo, item, userID); err != nil {
+						return err
+					}
+
+					return s.applyBulkItem(ctx, tx, todo, item, userID, loaded, &keys)
+				})
+				if err != nil {
+					// Later items on the same todo must see it as stored.
+					*todo = saved
+				}
+			}
+
+			if err == nil {
+				results[i].Status = dto.BulkStatusApplied
+				continue
+			}
+
+			var httpErr *echo.HTTPError
+			switch {
+			case errors.Is(err, repository.ErrVersionConflict):
+				httpErr = echo.NewHTTPError(http.StatusPreconditionFailed, "Todo has been modified by another request")
+			case !errors.As(err, &httpErr):
+				return err
+			}
+
+			if !bestEffort {
+				return echo.NewHTTPError(httpErr.Code, fmt.Sprintf("Item %d: %v", i, httpErr.Message))
+			}
+
+			results[i].Status = dto.BulkStatusRejected
+			results[i].Code = httpErr.Code
+			results[i].Message = fmt.Sprint(httpErr.Message)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	keys.add("todos:all:" + userID)
+
+	if err := s.cache.Del(keys.keys...); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// checkBulkItem applies the rules of the single-todo endpoints: editors may do
+// anything, and the assignee may only complete or uncomplete the todo.
+func (s *todoService) checkBulkItem(ctx context.Context, tx *gorm.DB, todo *entity.Todo, item dto.BulkTodoRequestItem, userID string) error {
+	role, err := todoRole(ctx, tx, s.projectRepository, todo, userID)
+	if err != nil {
+		return err
+	}
+
+	if role.Allows(entity.ProjectViewer) && isAssignee(todo, userID) && (item.Action == "complete" || item.Action == "uncomplete") {
+		return nil
+	}
+
+	return requireTodoRole(role, entity.ProjectEditor)
+}
+
+func (s *todoService) applyBulkItem(ctx context.Context, tx *gorm.DB, todo *entity.Todo, item dto.BulkTodoRequestItem, userID string, loaded map[string]*entity.Todo, keys *cacheKeys) error {
+	audience, err := todoAudience(ctx, tx, s.projectRepository, todo)
+	if err != nil {
+		return err
+	}
+
+	switch item.Action {
+	case "complete", "uncomplete":
+		completing := item.Action == "complete" && !todo.IsCompleted
+		todo.IsCompleted = item.Action == "complete"
+
+		if err := s.todoRepository.UpdateTodo(ctx, tx, todo); err != nil {
+			return err
+		}
+
+		if completing {
+			next, err := s.nextOccurrence(ctx, tx, todo)
+			if err != nil {
+				return err
+			}
+
+			if next != nil {
+				if err := s.todoRepository.CreateTodo(ctx, tx, next); err != nil {
+					return err
+				}
+			}
+		}
+	case "delete":
+		subtasks, err := s.todoRepository.GetSubtasks(ctx, tx, todo.ID.String())
+		if err != nil {
+			return err
+		}
+
+		if err := s.todoRepository.DeleteTodo(ctx, tx, todo); err != nil {
+			return err
+		}
+
+		// Later items can no longer reach the todo or the subtasks trashed with it.
+		delete(loaded, todo.ID.String())
+		for _, subtask := range subtasks {
+			delete(loaded, subtask.ID.String())
+			keys.add("todos:" + subtask.ID.String())
+		}
+	case "move":
+		projectID, err := s.resolveProject(ctx, tx, item.ProjectID, userID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, "Project not found")
+			}
+			return err
+		}
+
+		todo.ProjectID = projectID
+		if err := s.todoRepository.UpdateTodo(ctx, tx, todo); err != nil {
+			return err
+		}
+
+		// Members of the new project see the todo from now on.
+		newAudience, err := todoAudience(ctx, tx, s.projectRepository, todo)
+		if err != nil {
+			return err
+		}
+		audience = append(audience, newAudience...)
+	case "set_priority":
+		priority, err := parsePriority(item.Priority)
+		if err != nil {
+			return err
+		}
+
+		todo.Priority = priority
+		if err := s.todoRepository.UpdateTodo(ctx, tx, todo); err != nil {
+			return err
+		}
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid action")
+	}
+
+	keys.add("todos:" + todo.ID.String())
+	if todo.ParentID != nil {
+		keys.add("todos:" + todo.ParentID.String())
+	}
+	for _, memberID := range audience {
+		keys.add("todos:all:" + memberID)
+	}
+
+	return nil
+}
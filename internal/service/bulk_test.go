@@ -0,0 +1,228 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func (s *TodoTestSuite) TestBulkTodos() {
+	userID := uuid.NewString()
+	newTodo := func() entity.Todo {
+		todo := entity.Todo{UserID: uuid.MustParse(userID)}
+		todo.ID = uuid.New()
+		return todo
+	}
+
+	// passSavepoints runs every item straight through its savepoint.
+	passSavepoints := func() {
+		s.repo.EXPECT().WithSavepoint(gomock.Any(), gomock.Any()).DoAndReturn(func(tx *gorm.DB, f func(tx *gorm.DB) error) error {
+			return f(tx)
+		}).AnyTimes()
+	}
+
+	s.Run("Failed to get todos", func() {
+		errorTest := errors.New("get todos error")
+		todoID := uuid.NewString()
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodosByIDs(gomock.Any(), gomock.Any(), []string{todoID}).Return(nil, errorTest)
+
+			return f(&gorm.DB{})
+		})
+		result, err := s.todoService.BulkTodos(context.Background(), dto.BulkTodoRequest{Items: []dto.BulkTodoRequestItem{
+			{ID: todoID, Action: "complete"},
+		}}, userID)
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+	})
+
+	s.Run("All or nothing fails on a todo of another user", func() {
+		var e *echo.HTTPError
+		todo := newTodo()
+		other := newTodo()
+		other.UserID = uuid.New()
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodosByIDs(gomock.Any(), gomock.Any(), gomock.Any()).Return([]entity.Todo{todo, other}, nil)
+			passSavepoints()
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+			return f(&gorm.DB{})
+		})
+		result, err := s.todoService.BulkTodos(context.Background(), dto.BulkTodoRequest{Items: []dto.BulkTodoRequestItem{
+			{ID: todo.ID.String(), Action: "uncomplete"},
+			{ID: other.ID.String(), Action: "uncomplete"},
+		}}, userID)
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusNotFound, e.Code)
+		s.Equal("Item 1: Todo not found", e.Message)
+		s.Nil(result)
+	})
+
+	s.Run("Best effort reports rejected items and applies the rest", func() {
+		completed := newTodo()
+		prioritized := newTodo()
+		parentID := uuid.New()
+		prioritized.ParentID = &parentID
+		deleted := newTodo()
+		subtask := newTodo()
+		missingID := uuid.NewString()
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodosByIDs(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return([]entity.Todo{completed, prioritized, deleted, subtask}, nil)
+			passSavepoints()
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *gorm.DB, todo *entity.Todo) error {
+				s.True(todo.IsCompleted)
+				return nil
+			})
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *gorm.DB, todo *entity.Todo) error {
+				s.Equal(entity.PriorityHigh, todo.Priority)
+				return nil
+			})
+			s.repo.EXPECT().GetSubtasks(gomock.Any(), gomock.Any(), deleted.ID.String()).Return([]entity.Todo{subtask}, nil)
+			s.repo.EXPECT().DeleteTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del(
+			"todos:"+completed.ID.String(),
+			"todos:all:"+userID,
+			"todos:"+prioritized.ID.String(),
+			"todos:"+parentID.String(),
+			"todos:"+subtask.ID.String(),
+			"todos:"+deleted.ID.String(),
+		).Return(nil)
+		result, err := s.todoService.BulkTodos(context.Background(), dto.BulkTodoRequest{Mode: dto.BulkModeBestEffort, Items: []dto.BulkTodoRequestItem{
+			{ID: completed.ID.String(), Action: "complete"},
+			{ID: missingID, Action: "complete"},
+			{ID: prioritized.ID.String(), Action: "set_priority", Priority: "high"},
+			{ID: deleted.ID.String(), Action: "delete"},
+			{ID: subtask.ID.String(), Action: "complete"},
+		}}, userID)
+
+		s.Nil(err)
+		s.Len(result, 5)
+		s.Equal(dto.BulkStatusApplied, result[0].Status)
+		s.Equal(dto.BulkStatusRejected, result[1].Status)
+		s.Equal(http.StatusNotFound, result[1].Code)
+		s.Equal(dto.BulkStatusApplied, result[2].Status)
+		s.Equal(dto.BulkStatusApplied, result[3].Status)
+		s.Equal(dto.BulkStatusRejected, result[4].Status)
+	})
+
+	s.Run("Shared todos follow project roles", func() {
+		acceptedAt := time.Now()
+		ownerID := uuid.NewString()
+		project := &entity.Project{UserID: uuid.MustParse(ownerID)}
+		project.ID = uuid.New()
+		shared := newTodo()
+		shared.UserID = project.UserID
+		shared.ProjectID = &project.ID
+		assigned := newTodo()
+		assigned.UserID = project.UserID
+		assigneeID := uuid.MustParse(userID)
+		assigned.AssigneeID = &assigneeID
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodosByIDs(gomock.Any(), gomock.Any(), gomock.Any()).Return([]entity.Todo{shared, assigned}, nil)
+			passSavepoints()
+			s.projectRepo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), project.ID.String()).Return(project, nil)
+			s.projectRepo.EXPECT().GetMember(gomock.Any(), gomock.Any(), project.ID.String(), userID).Return(&entity.ProjectMember{Role: entity.ProjectEditor, AcceptedAt: &acceptedAt}, nil)
+			s.projectRepo.EXPECT().GetMemberUserIDs(gomock.Any(), gomock.Any(), project.ID.String()).Return([]string{userID}, nil)
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del(
+			"todos:"+shared.ID.String(),
+			"todos:all:"+userID,
+			"todos:all:"+ownerID,
+		).Return(nil)
+		result, err := s.todoService.BulkTodos(context.Background(), dto.BulkTodoRequest{Mode: dto.BulkModeBestEffort, Items: []dto.BulkTodoRequestItem{
+			{ID: shared.ID.String(), Action: "set_priority", Priority: "high"},
+			{ID: assigned.ID.String(), Action: "delete"},
+		}}, userID)
+
+		s.Nil(err)
+		s.Equal(dto.BulkStatusApplied, result[0].Status)
+		s.Equal(http.StatusForbidden, result[1].Code)
+	})
+
+	s.Run("Best effort rejects stale todos and foreign projects", func() {
+		stale := newTodo()
+		moved := newTodo()
+		project := &entity.Project{UserID: uuid.New()}
+		project.ID = uuid.New()
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodosByIDs(gomock.Any(), gomock.Any(), gomock.Any()).Return([]entity.Todo{stale, moved}, nil)
+			passSavepoints()
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(repository.ErrVersionConflict)
+			s.projectRepo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), project.ID.String()).Return(project, nil)
+			s.projectRepo.EXPECT().GetMember(gomock.Any(), gomock.Any(), project.ID.String(), userID).Return(nil, gorm.ErrRecordNotFound)
+
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("todos:all:" + userID).Return(nil)
+		result, err := s.todoService.BulkTodos(context.Background(), dto.BulkTodoRequest{Mode: dto.BulkModeBestEffort, Items: []dto.BulkTodoRequestItem{
+			{ID: stale.ID.String(), Action: "uncomplete"},
+			{ID: moved.ID.String(), Action: "move", ProjectID: project.ID.String()},
+		}}, userID)
+
+		s.Nil(err)
+		s.Equal(http.StatusPreconditionFailed, result[0].Code)
+		s.Equal(http.StatusNotFound, result[1].Code)
+	})
+
+	s.Run("Unexpected errors abort even in best effort", func() {
+		errorTest := errors.New("update todo error")
+		todo := newTodo()
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodosByIDs(gomock.Any(), gomock.Any(), gomock.Any()).Return([]entity.Todo{todo}, nil)
+			passSavepoints()
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(errorTest)
+
+			return f(&gorm.DB{})
+		})
+		result, err := s.todoService.BulkTodos(context.Background(), dto.BulkTodoRequest{Mode: dto.BulkModeBestEffort, Items: []dto.BulkTodoRequestItem{
+			{ID: todo.ID.String(), Action: "set_priority", Priority: "low"},
+		}}, userID)
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+	})
+
+	s.Run("Best effort rolls a rejected item back to its savepoint", func() {
+		todo := newTodo()
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodosByIDs(gomock.Any(), gomock.Any(), gomock.Any()).Return([]entity.Todo{todo}, nil)
+			passSavepoints()
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(repository.ErrVersionConflict)
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *gorm.DB, todo *entity.Todo) error {
+				// The first item's change went with its savepoint.
+				s.Equal(entity.PriorityNone, todo.Priority)
+				s.True(todo.IsCompleted)
+				return nil
+			})
+
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("todos:"+todo.ID.String(), "todos:all:"+userID).Return(nil)
+		result, err := s.todoService.BulkTodos(context.Background(), dto.BulkTodoRequest{Mode: dto.BulkModeBestEffort, Items: []dto.BulkTodoRequestItem{
+			{ID: todo.ID.String(), Action: "set_priority", Priority: "high"},
+			{ID: todo.ID.String(), Action: "complete"},
+		}}, userID)
+
+		s.Nil(err)
+		s.Equal(dto.BulkStatusRejected, result[0].Status)
+		s.Equal(dto.BulkStatusApplied, result[1].Status)
+	})
+}
@@ -0,0 +1,340 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/pkg/ical"
+	"github.com/sherwin-77/golang-todos/pkg/tokens"
+	"gorm.io/gorm"
+)
+
+const calendarProductID = "-//golang-todos//Todos//EN"
+
+// icsPriorities maps priorities to the 1 (highest) to 9 (lowest) scale of
+// RFC 5545. Zero means undefined.
+var icsPriorities = map[entity.Priority]int{
+	entity.PriorityUrgent: 1,
+	entity.PriorityHigh:   3,
+	entity.PriorityMedium: 5,
+	entity.PriorityLow:    9,
+}
+
+func icsPriority(value int) entity.Priority {
+	switch {
+	case value <= 0:
+		return entity.PriorityNone
+	case value == 1:
+		return entity.PriorityUrgent
+	case value < 5:
+		return entity.PriorityHigh
+	case value == 5:
+		return entity.PriorityMedium
+	default:
+		return entity.PriorityLow
+	}
+}
+
+// todoComponent describes the todo as a VTODO. DTSTAMP is the last change
+// rather than the time of the request, so the feed stays the same until a
+// todo changes.
+func todoComponent(todo *entity.Todo) *ical.Component {
+	c := &ical.Component{Name: "VTODO"}
+	c.Add("UID", todo.ID.String())
+	c.AddTime("DTSTAMP", todo.UpdatedAt)
+	c.AddTime("CREATED", todo.CreatedAt)
+	c.AddTime("LAST-MODIFIED", todo.UpdatedAt)
+	c.AddText("SUMMARY", todo.Title)
+	if todo.Description != "" {
+		c.AddText("DESCRIPTION", todo.Description)
+	}
+
+	if todo.IsCompleted {
+		c.Add("STATUS", "COMPLETED")
+		c.AddTime("COMPLETED", todo.UpdatedAt)
+		c.Add("PERCENT-COMPLETE", "100")
+	} else {
+		c.Add("STATUS", "NEEDS-ACTION")
+	}
+
+	if todo.DueAt != nil {
+		c.AddTime("DUE", *todo.DueAt)
+	}
+	if priority, ok := icsPriorities[todo.Priority]; ok {
+		c.Add("PRIORITY", strconv.Itoa(priority))
+	}
+	if len(todo.Tags) > 0 {
+		categories := make([]string, len(todo.Tags))
+		for i, tag := range todo.Tags {
+			categories[i] = ical.EscapeText(tag.Name)
+		}
+		c.Add("CATEGORIES", strings.Join(categories, ","))
+	}
+	if todo.Recurrence != "" {
+		c.Add("RRULE", todo.Recurrence)
+	}
+	if todo.ParentID != nil {
+		c.Add("RELATED-TO", todo.ParentID.String())
+	}
+
+	if todo.RemindAt != nil {
+		alarm := ical.Component{Name: "VALARM"}
+		alarm.Add("ACTION", "DISPLAY")
+		alarm.AddText("DESCRIPTION", todo.Title)
+		alarm.Properties = append(alarm.Properties, ical.Property{
+			Name:   "TRIGGER",
+			Params: map[string]string{"VALUE": "DATE-TIME"},
+			Value:  ical.FormatTime(*todo.RemindAt),
+		})
+		c.Components = append(c.Components, alarm)
+	}
+
+	return c
+}
+
+// icsEncoder writes a VCALENDAR with one VTODO per todo.
+type icsEncoder struct {
+	encoder *ical.Encoder
+	header  bool
+}
+
+func (e *icsEncoder) writeHeader() error {
+	e.header = true
+
+	e.encoder.Begin("VCALENDAR")
+	e.encoder.WriteProperty(ical.Property{Name: "VERSION", Value: "2.0"})
+	e.encoder.WriteProperty(ical.Property{Name: "PRODID", Value: calendarProductID})
+	e.encoder.WriteProperty(ical.Property{Name: "CALSCALE", Value: "GREGORIAN"})
+	return e.encoder.WriteProperty(ical.Property{Name: "X-WR-CALNAME", Value: "Todos"})
+}
+
+func (e *icsEncoder) Encode(todo *entity.Todo) error {
+	if !e.header {
+		if err := e.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	return e.encoder.Encode(todoComponent(todo))
+}
+
+func (e *icsEncoder) Close() error {
+	if !e.header {
+		if err := e.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	return e.encoder.End("VCALENDAR")
+}
+
+// icsUID turns a UID into a todo ID. Calendar apps use any string as a UID, so
+// ones that are not UUIDs are hashed into one, which keeps RELATED-TO links
+// between imported entries intact.
+func icsUID(uid string) uuid.UUID {
+	if id, err := uuid.Parse(uid); err == nil {
+		return id
+	}
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(uid))
+}
+
+// icsRow reads a VTODO or VEVENT. Events become todos due when they start.
+func icsRow(c *ical.Component, number int) importRow {
+	row := importRow{number: number}
+	todo := &row.todo
+
+	if uid := c.Get("UID"); uid != nil && uid.Value != "" {
+		todo.ID = icsUID(uid.Text())
+	}
+	if summary := c.Get("SUMMARY"); summary != nil {
+		todo.Title = strings.TrimSpace(summary.Text())
+	}
+	if description := c.Get("DESCRIPTION"); description != nil {
+		todo.Description = description.Text()
+	}
+	if status := c.Get("STATUS"); status != nil {
+		todo.IsCompleted = strings.EqualFold(status.Value, "COMPLETED")
+	} else {
+		todo.IsCompleted = c.Get("COMPLETED") != nil
+	}
+
+	due := c.Get("DTSTART")
+	if c.Name == "VTODO" && c.Get("DUE") != nil {
+		due = c.Get("DUE")
+	}
+	if due != nil {
+		dueAt, err := due.ParseTime()
+		if err != nil {
+			row.errs = append(row.errs, due.Name+" is not a valid date or date-time")
+		} else {
+			todo.DueAt = &dueAt
+		}
+	}
+
+	if priority := c.Get("PRIORITY"); priority != nil {
+		value, err := strconv.Atoi(priority.Value)
+		if err != nil || value > 9 {
+			row.errs = append(row.errs, "PRIORITY must be a number from 0 to 9")
+		}
+		todo.Priority = icsPriority(value)
+	}
+
+	for _, p := range c.Properties {
+		switch {
+		case p.Name == "CATEGORIES":
+			for _, name := range ical.SplitText(p.Value) {
+				if name = strings.TrimSpace(name); name != "" {
+					todo.Tags = append(todo.Tags, &entity.Tag{Name: name})
+				}
+			}
+		case p.Name == "RRULE":
+			todo.Recurrence = p.Value
+		case p.Name == "RELATED-TO" && (p.Param("RELTYPE") == "" || strings.EqualFold(p.Param("RELTYPE"), "PARENT")):
+			parentID := icsUID(p.Text())
+			todo.ParentID = &parentID
+		}
+	}
+
+	for _, alarm := range c.Components {
+		if alarm.Name != "VALARM" || alarm.Get("TRIGGER") == nil {
+			continue
+		}
+
+		remindAt, err := icsTrigger(alarm.Get("TRIGGER"), todo.DueAt)
+		if err != nil {
+			row.errs = append(row.errs, "TRIGGER is not a valid date-time or duration")
+		}
+		todo.RemindAt = remindAt
+		break
+	}
+
+	return row
+}
+
+// icsTrigger reads when an alarm goes off. Relative triggers count from the
+// due time, and are dropped when there is none.
+func icsTrigger(trigger *ical.Property, dueAt *time.Time) (*time.Time, error) {
+	if strings.EqualFold(trigger.Param("VALUE"), "DATE-TIME") {
+		t, err := trigger.ParseTime()
+		if err != nil {
+			return nil, err
+		}
+		return &t, nil
+	}
+
+	offset, err := ical.ParseDuration(trigger.Value)
+	if err != nil || dueAt == nil {
+		return nil, err
+	}
+
+	t := dueAt.Add(offset)
+	return &t, nil
+}
+
+// decodeICSTodos reads the VTODO and VEVENT entries of every VCALENDAR in the
+// file. Other components, such as VTIMEZONE and VJOURNAL, are skipped.
+func decodeICSTodos(r io.Reader) ([]importRow, error) {
+	components, err := ical.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []importRow
+	for _, calendar := range components {
+		if calendar.Name != "VCALENDAR" {
+			return nil, errors.New("expected a VCALENDAR")
+		}
+
+		for i := range calendar.Components {
+			c := &calendar.Components[i]
+			if c.Name == "VTODO" || c.Name == "VEVENT" {
+				rows = append(rows, icsRow(c, len(rows)+1))
+			}
+		}
+	}
+
+	return rows, nil
+}
+
+// GetCalendarFeed renders the todos of the user owning the token as an
+// iCalendar file. The feed is cached under the same generation as the todo
+// lists, so any change to the user's todos serves a fresh feed.
+func (s *todoService) GetCalendarFeed(ctx context.Context, token string) (string, error) {
+	user, err := s.userRepository.GetUserByCalendarToken(ctx, s.userRepository.SingleTransaction(), tokens.HashToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", echo.NewHTTPError(http.StatusNotFound, "Calendar not found")
+		}
+		return "", err
+	}
+
+	userID := user.ID.String()
+	version, err := s.todoListVersion(userID)
+	if err != nil {
+		return "", err
+	}
+
+	feedKey := "todos:ical:" + userID + ":" + version
+	if cachedData := s.cache.Get(feedKey); cachedData != "" {
+		return cachedData, nil
+	}
+
+	var feed strings.Builder
+	if err := s.ExportTodos(ctx, userID, dto.FormatICS, &feed); err != nil {
+		return "", err
+	}
+
+	if err := s.cache.Set(feedKey, feed.String(), time.Hour); err != nil {
+		return "", err
+	}
+
+	return feed.String(), nil
+}
+
+// CreateCalendarToken replaces the user's calendar token, so the previous
+// feed URL stops working. Only its hash is stored.
+func (s *todoService) CreateCalendarToken(ctx context.Context, userID string) (string, error) {
+	token, err := tokens.NewOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.setCalendarToken(ctx, userID, token); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (s *todoService) DeleteCalendarToken(ctx context.Context, userID string) error {
+	return s.setCalendarToken(ctx, userID, "")
+}
+
+func (s *todoService) setCalendarToken(ctx context.Context, userID string, token string) error {
+	db := s.userRepository.SingleTransaction()
+	user, err := s.userRepository.GetUserByID(ctx, db, userID)
+	if err != nil {
+		return err
+	}
+
+	user.CalendarTokenHash = nil
+	if token != "" {
+		tokenHash := tokens.HashToken(token)
+		user.CalendarTokenHash = &tokenHash
+	}
+
+	if err := s.userRepository.UpdateUser(ctx, db, user); err != nil {
+		return err
+	}
+
+	return s.cache.Del("users:"+userID, "users:all")
+}
@@ -0,0 +1,272 @@
+package service_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func calendarTokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *TodoTestSuite) TestGetCalendarFeed() {
+	token := "secret-token"
+	user := &entity.User{}
+	user.ID = uuid.New()
+	userID := user.ID.String()
+	feedKey := "todos:ical:" + userID + ":v1"
+
+	s.Run("Calendar not found", func() {
+		var e *echo.HTTPError
+		s.userRepo.EXPECT().SingleTransaction().Return(nil)
+		s.userRepo.EXPECT().GetUserByCalendarToken(gomock.Any(), gomock.Any(), calendarTokenHash(token)).Return(nil, gorm.ErrRecordNotFound)
+		feed, err := s.todoService.GetCalendarFeed(context.Background(), token)
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusNotFound, e.Code)
+		s.Empty(feed)
+	})
+
+	s.Run("Get cached feed", func() {
+		s.userRepo.EXPECT().SingleTransaction().Return(nil)
+		s.userRepo.EXPECT().GetUserByCalendarToken(gomock.Any(), gomock.Any(), calendarTokenHash(token)).Return(user, nil)
+		s.cache.EXPECT().Get("todos:all:" + userID).Return("v1")
+		s.cache.EXPECT().Get(feedKey).Return("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n")
+		feed, err := s.todoService.GetCalendarFeed(context.Background(), token)
+
+		s.Nil(err)
+		s.Equal("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n", feed)
+	})
+
+	s.Run("Failed to get todos", func() {
+		errorTest := errors.New("get todos error")
+		s.userRepo.EXPECT().SingleTransaction().Return(nil)
+		s.userRepo.EXPECT().GetUserByCalendarToken(gomock.Any(), gomock.Any(), calendarTokenHash(token)).Return(user, nil)
+		s.cache.EXPECT().Get("todos:all:" + userID).Return("v1")
+		s.cache.EXPECT().Get(feedKey).Return("")
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTodosFiltered(gomock.Any(), gomock.Any(), 200, 0, gomock.Any(), repository.TodoAccessScope, userID, userID, userID).Return(nil, errorTest)
+		feed, err := s.todoService.GetCalendarFeed(context.Background(), token)
+
+		s.ErrorIs(err, errorTest)
+		s.Empty(feed)
+	})
+
+	s.Run("Build and cache feed", func() {
+		createdAt := time.Date(2024, 12, 1, 9, 0, 0, 0, time.UTC)
+		dueAt := time.Date(2024, 12, 20, 17, 0, 0, 0, time.UTC)
+		remindAt := dueAt.Add(-time.Hour)
+		parentID := uuid.New()
+		todo := entity.Todo{
+			Title:       "Buy milk, eggs",
+			Description: "Semi-skimmed",
+			IsCompleted: true,
+			DueAt:       &dueAt,
+			RemindAt:    &remindAt,
+			ParentID:    &parentID,
+			Priority:    entity.PriorityHigh,
+			Recurrence:  "FREQ=WEEKLY",
+			UserID:      user.ID,
+			Tags:        []*entity.Tag{{Name: "home"}, {Name: "shop"}},
+		}
+		todo.ID = uuid.New()
+		todo.CreatedAt = createdAt
+		todo.UpdatedAt = createdAt.Add(time.Hour)
+
+		s.userRepo.EXPECT().SingleTransaction().Return(nil)
+		s.userRepo.EXPECT().GetUserByCalendarToken(gomock.Any(), gomock.Any(), calendarTokenHash(token)).Return(user, nil)
+		s.cache.EXPECT().Get("todos:all:" + userID).Return("v1")
+		s.cache.EXPECT().Get(feedKey).Return("")
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTodosFiltered(gomock.Any(), gomock.Any(), 200, 0, gomock.Any(), repository.TodoAccessScope, userID, userID, userID).Return([]entity.Todo{todo}, nil)
+		s.cache.EXPECT().Set(feedKey, gomock.Any(), time.Hour).Return(nil)
+		feed, err := s.todoService.GetCalendarFeed(context.Background(), token)
+
+		s.Nil(err)
+		s.Equal(strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"PRODID:-//golang-todos//Todos//EN",
+			"CALSCALE:GREGORIAN",
+			"X-WR-CALNAME:Todos",
+			"BEGIN:VTODO",
+			"UID:" + todo.ID.String(),
+			"DTSTAMP:20241201T100000Z",
+			"CREATED:20241201T090000Z",
+			"LAST-MODIFIED:20241201T100000Z",
+			`SUMMARY:Buy milk\, eggs`,
+			"DESCRIPTION:Semi-skimmed",
+			"STATUS:COMPLETED",
+			"COMPLETED:20241201T100000Z",
+			"PERCENT-COMPLETE:100",
+			"DUE:20241220T170000Z",
+			"PRIORITY:3",
+			"CATEGORIES:home,shop",
+			"RRULE:FREQ=WEEKLY",
+			"RELATED-TO:" + parentID.String(),
+			"BEGIN:VALARM",
+			"ACTION:DISPLAY",
+			`DESCRIPTION:Buy milk\, eggs`,
+			"TRIGGER;VALUE=DATE-TIME:20241220T160000Z",
+			"END:VALARM",
+			"END:VTODO",
+			"END:VCALENDAR",
+			"",
+		}, "\r\n"), feed)
+	})
+}
+
+func (s *TodoTestSuite) TestCreateCalendarToken() {
+	user := &entity.User{}
+	user.ID = uuid.New()
+	userID := user.ID.String()
+
+	s.Run("Failed to get user", func() {
+		s.userRepo.EXPECT().SingleTransaction().Return(nil)
+		s.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), userID).Return(nil, gorm.ErrRecordNotFound)
+		token, err := s.todoService.CreateCalendarToken(context.Background(), userID)
+
+		s.ErrorIs(err, gorm.ErrRecordNotFound)
+		s.Empty(token)
+	})
+
+	s.Run("Create token successfully", func() {
+		var stored *string
+		s.userRepo.EXPECT().SingleTransaction().Return(nil)
+		s.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), userID).Return(user, nil)
+		s.userRepo.EXPECT().UpdateUser(gomock.Any(), gomock.Any(), user).DoAndReturn(func(_ context.Context, _ *gorm.DB, user *entity.User) error {
+			stored = user.CalendarTokenHash
+			return nil
+		})
+		s.cache.EXPECT().Del("users:"+userID, "users:all").Return(nil)
+		token, err := s.todoService.CreateCalendarToken(context.Background(), userID)
+
+		s.Nil(err)
+		s.Len(token, 43)
+		s.NotNil(stored)
+		s.Equal(calendarTokenHash(token), *stored)
+	})
+}
+
+func (s *TodoTestSuite) TestDeleteCalendarToken() {
+	tokenHash := calendarTokenHash("secret-token")
+	user := &entity.User{CalendarTokenHash: &tokenHash}
+	user.ID = uuid.New()
+	userID := user.ID.String()
+
+	s.userRepo.EXPECT().SingleTransaction().Return(nil)
+	s.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), userID).Return(user, nil)
+	s.userRepo.EXPECT().UpdateUser(gomock.Any(), gomock.Any(), user).Return(nil)
+	s.cache.EXPECT().Del("users:"+userID, "users:all").Return(nil)
+	err := s.todoService.DeleteCalendarToken(context.Background(), userID)
+
+	s.Nil(err)
+	s.Nil(user.CalendarTokenHash)
+}
+
+func (s *TodoTestSuite) TestImportCalendar() {
+	userID := uuid.NewString()
+
+	s.Run("Malformed file", func() {
+		var e *echo.HTTPError
+		result, err := s.todoService.ImportTodos(context.Background(), userID, dto.FormatICS, strings.NewReader("BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\n"))
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusBadRequest, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Import todos and events", func() {
+		file := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"BEGIN:VTIMEZONE",
+			"TZID:Asia/Jakarta",
+			"END:VTIMEZONE",
+			"BEGIN:VTODO",
+			"UID:groceries@example.com",
+			"SUMMARY:Groceries",
+			"DUE;TZID=Asia/Jakarta:20241220T170000",
+			"PRIORITY:1",
+			`CATEGORIES:home,errands\, misc`,
+			"BEGIN:VALARM",
+			"ACTION:DISPLAY",
+			"TRIGGER:-PT30M",
+			"END:VALARM",
+			"END:VTODO",
+			"BEGIN:VTODO",
+			"UID:milk@example.com",
+			"SUMMARY:Milk",
+			"STATUS:COMPLETED",
+			"RELATED-TO:groceries@example.com",
+			"END:VTODO",
+			"BEGIN:VEVENT",
+			"UID:standup@example.com",
+			"SUMMARY:Standup",
+			"DESCRIPTION:Daily\\nsync",
+			"DTSTART:20241220T020000Z",
+			"DTEND:20241220T021500Z",
+			"RRULE:FREQ=DAILY",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"SUMMARY:Broken",
+			"DTSTART:tomorrow",
+			"END:VEVENT",
+			"END:VCALENDAR",
+			"",
+		}, "\r\n")
+		var created []*entity.Todo
+
+		s.tagRepo.EXPECT().SingleTransaction().Return(nil)
+		s.tagRepo.EXPECT().GetTagsByUserID(gomock.Any(), gomock.Any(), userID).Return(nil, nil)
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().NextPosition(gomock.Any(), gomock.Any(), gomock.Any()).Return(float64(1), nil).Times(3)
+			s.tagRepo.EXPECT().CreateTag(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+			s.repo.EXPECT().CreateTodo(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *gorm.DB, todo *entity.Todo) error {
+				if todo.ID == uuid.Nil {
+					todo.ID = uuid.New()
+				}
+				created = append(created, todo)
+				return nil
+			}).Times(3)
+
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("todos:all:"+userID, "tags:all:"+userID).Return(nil)
+		result, err := s.todoService.ImportTodos(context.Background(), userID, dto.FormatICS, strings.NewReader(file))
+
+		s.Nil(err)
+		s.Equal(3, result.Imported)
+		s.Equal([]dto.ImportRowError{{Row: 4, Errors: []string{"DTSTART is not a valid date or date-time"}}}, result.Errors)
+
+		groceries, milk, standup := created[0], created[1], created[2]
+		s.Equal("Groceries", groceries.Title)
+		s.Equal(time.Date(2024, 12, 20, 10, 0, 0, 0, time.UTC), groceries.DueAt.UTC())
+		s.Equal(time.Date(2024, 12, 20, 9, 30, 0, 0, time.UTC), groceries.RemindAt.UTC())
+		s.Equal(entity.PriorityUrgent, groceries.Priority)
+		s.Equal("home", groceries.Tags[0].Name)
+		s.Equal("errands, misc", groceries.Tags[1].Name)
+
+		s.True(milk.IsCompleted)
+		s.Equal(&groceries.ID, milk.ParentID)
+
+		s.Equal("Standup", standup.Title)
+		s.Equal("Daily\nsync", standup.Description)
+		s.Equal(time.Date(2024, 12, 20, 2, 0, 0, 0, time.UTC), standup.DueAt.UTC())
+		s.Equal("FREQ=DAILY", standup.Recurrence)
+	})
+}
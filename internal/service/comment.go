@@ -0,0 +1,294 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"github.com/sherwin-77/golang-todos/pkg/response"
+	"gorm.io/gorm"
+)
+
+// maxMentions bounds how many users a single comment can notify.
+const maxMentions = 20
+
+// mentionPattern matches @username, but not the domain of an email address.
+// A trailing dot is left out so "thanks @bob." mentions bob.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@.])@([\w.-]*\w)`)
+
+type CommentService interface {
+	GetComments(ctx context.Context, query dto.CommentQuery, userID string) ([]entity.Comment, *response.Meta, error)
+	CreateComment(ctx context.Context, request dto.CommentRequest, userID string) (*entity.Comment, error)
+	UpdateComment(ctx context.Context, request dto.UpdateCommentRequest, userID string) (*entity.Comment, error)
+	DeleteComment(ctx context.Context, todoID string, id string, userID string) error
+}
+
+type commentService struct {
+	commentRepository      repository.CommentRepository
+	todoRepository         repository.TodoRepository
+	userRepository         repository.UserRepository
+	projectRepository      repository.ProjectRepository
+	notificationRepository repository.NotificationRepository
+}
+
+func NewCommentService(
+	commentRepository repository.CommentRepository,
+	todoRepository repository.TodoRepository,
+	userRepository repository.UserRepository,
+	projectRepository repository.ProjectRepository,
+	notificationRepository repository.NotificationRepository,
+) CommentService {
+	return &commentService{commentRepository, todoRepository, userRepository, projectRepository, notificationRepository}
+}
+
+// GetComments lists the thread of a todo, oldest first. Anyone who can see
+// the todo can read it.
+func (s *commentService) GetComments(ctx context.Context, query dto.CommentQuery, userID string) ([]entity.Comment, *response.Meta, error) {
+	page, perPage := normalizePage(query.Page, query.PerPage)
+	db := s.commentRepository.SingleTransaction()
+
+	if _, err := s.visibleTodo(ctx, db, query.TodoID, userID); err != nil {
+		return nil, nil, err
+	}
+
+	total, err := s.commentRepository.CountComments(ctx, db, query.TodoID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	comments, err := s.commentRepository.GetCommentsByTodoID(ctx, db, query.TodoID, perPage, (page-1)*perPage)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return comments, response.NewMeta(page, perPage, int(total)), nil
+}
+
+// CreateComment adds a comment to the thread of a todo the user can see and
+// notifies the users it mentions.
+func (s *commentService) CreateComment(ctx context.Context, request dto.CommentRequest, userID string) (*entity.Comment, error) {
+	body, err := commentBody(request.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var comment *entity.Comment
+	if err := s.commentRepository.WithTransaction(func(tx *gorm.DB) error {
+		todo, err := s.visibleTodo(ctx, tx, request.TodoID, userID)
+		if err != nil {
+			return err
+		}
+
+		created := &entity.Comment{
+			TodoID: todo.ID,
+			UserID: uuid.MustParse(userID),
+			Body:   body,
+		}
+		if err := s.commentRepository.CreateComment(ctx, tx, created); err != nil {
+			return err
+		}
+
+		// Reload so the response embeds the author's profile.
+		comment, err = s.commentRepository.GetCommentByID(ctx, tx, created.ID.String())
+		if err != nil {
+			return err
+		}
+
+		return s.notifyMentions(ctx, tx, todo, comment, "")
+	}); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// UpdateComment changes the body of a comment. Only its author may edit it,
+// and only users who were not mentioned before are notified.
+func (s *commentService) UpdateComment(ctx context.Context, request dto.UpdateCommentRequest, userID string) (*entity.Comment, error) {
+	body, err := commentBody(request.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var comment *entity.Comment
+	if err := s.commentRepository.WithTransaction(func(tx *gorm.DB) error {
+		var todo *entity.Todo
+		todo, comment, err = s.visibleComment(ctx, tx, request.TodoID, request.ID, userID)
+		if err != nil {
+			return err
+		}
+
+		if comment.UserID.String() != userID {
+			return echo.NewHTTPError(http.StatusForbidden, "Only the author can edit this comment")
+		}
+
+		if comment.Body == body {
+			return nil
+		}
+
+		previous := comment.Body
+		now := time.Now()
+		comment.Body = body
+		comment.EditedAt = &now
+		if err := s.commentRepository.UpdateComment(ctx, tx, comment); err != nil {
+			return err
+		}
+
+		return s.notifyMentions(ctx, tx, todo, comment, previous)
+	}); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// DeleteComment removes a comment. Owners of the todo may delete any comment
+// in its thread, and authors may delete their own.
+func (s *commentService) DeleteComment(ctx context.Context, todoID string, id string, userID string) error {
+	return s.commentRepository.WithTransaction(func(tx *gorm.DB) error {
+		todo, comment, err := s.visibleComment(ctx, tx, todoID, id, userID)
+		if err != nil {
+			return err
+		}
+
+		if comment.UserID.String() != userID {
+			if err := checkTodoAccess(ctx, tx, s.projectRepository, todo, userID, entity.ProjectOwner); err != nil {
+				return err
+			}
+		}
+
+		return s.commentRepository.DeleteComment(ctx, tx, comment)
+	})
+}
+
+// visibleTodo loads a todo the user can at least view.
+func (s *commentService) visibleTodo(ctx context.Context, tx *gorm.DB, todoID string, userID string) (*entity.Todo, error) {
+	todo, err := s.todoRepository.GetTodoByID(ctx, tx, todoID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkTodoAccess(ctx, tx, s.projectRepository, todo, userID, entity.ProjectViewer); err != nil {
+		return nil, err
+	}
+
+	return todo, nil
+}
+
+// visibleComment loads a comment of a todo the user can at least view.
+// Comments of other todos are reported as not found.
+func (s *commentService) visibleComment(ctx context.Context, tx *gorm.DB, todoID string, id string, userID string) (*entity.Todo, *entity.Comment, error) {
+	comment, err := s.commentRepository.GetCommentByID(ctx, tx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && comment.TodoID.String() != todoID) {
+		return nil, nil, echo.NewHTTPError(http.StatusNotFound, "Comment not found")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	todo, err := s.visibleTodo(ctx, tx, todoID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return todo, comment, nil
+}
+
+// notifyMentions notifies the users mentioned in comment who were not already
+// mentioned in its previous body. Mentions of users who cannot see the todo,
+// and of the author, are ignored.
+func (s *commentService) notifyMentions(ctx context.Context, tx *gorm.DB, todo *entity.Todo, comment *entity.Comment, previous string) error {
+	seen := make(map[string]bool)
+	for _, username := range mentionedUsernames(previous) {
+		seen[username] = true
+	}
+
+	var usernames []string
+	for _, username := range mentionedUsernames(comment.Body) {
+		if !seen[username] {
+			usernames = append(usernames, username)
+		}
+	}
+	if len(usernames) == 0 {
+		return nil
+	}
+
+	users, err := s.userRepository.GetUsersByUsernames(ctx, tx, usernames)
+	if err != nil {
+		return err
+	}
+
+	audience, err := todoAudience(ctx, tx, s.projectRepository, todo)
+	if err != nil {
+		return err
+	}
+	canSee := make(map[string]bool)
+	for _, id := range audience {
+		canSee[id] = true
+	}
+	if todo.AssigneeID != nil {
+		canSee[todo.AssigneeID.String()] = true
+	}
+
+	author := "Someone"
+	if comment.Author != nil {
+		author = comment.Author.Username
+	}
+
+	var notifications []entity.Notification
+	for _, user := range users {
+		if user.ID == comment.UserID || !canSee[user.ID.String()] {
+			continue
+		}
+
+		notifications = append(notifications, entity.Notification{
+			UserID:  user.ID,
+			ActorID: &comment.UserID,
+			TodoID:  &todo.ID,
+			Type:    entity.NotificationCommentMention,
+			Message: author + " mentioned you on \"" + todo.Title + "\"",
+		})
+	}
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	return s.notificationRepository.CreateNotifications(ctx, tx, notifications)
+}
+
+// mentionedUsernames returns the distinct usernames mentioned in body, in the
+// order they first appear.
+func mentionedUsernames(body string) []string {
+	var usernames []string
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		username := match[1]
+		if seen[username] {
+			continue
+		}
+		seen[username] = true
+		usernames = append(usernames, username)
+		if len(usernames) == maxMentions {
+			break
+		}
+	}
+
+	return usernames
+}
+
+func commentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", echo.NewHTTPError(http.StatusUnprocessableEntity, "Body cannot be empty")
+	}
+
+	return body, nil
+}
@@ -0,0 +1,214 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/service"
+	mock_repository "github.com/sherwin-77/golang-todos/test/mock/repository"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type CommentTestSuite struct {
+	suite.Suite
+	ctrl           *gomock.Controller
+	repo           *mock_repository.MockCommentRepository
+	todoRepo       *mock_repository.MockTodoRepository
+	userRepo       *mock_repository.MockUserRepository
+	projectRepo    *mock_repository.MockProjectRepository
+	notifyRepo     *mock_repository.MockNotificationRepository
+	commentService service.CommentService
+}
+
+func (s *CommentTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.repo = mock_repository.NewMockCommentRepository(s.ctrl)
+	s.todoRepo = mock_repository.NewMockTodoRepository(s.ctrl)
+	s.userRepo = mock_repository.NewMockUserRepository(s.ctrl)
+	s.projectRepo = mock_repository.NewMockProjectRepository(s.ctrl)
+	s.notifyRepo = mock_repository.NewMockNotificationRepository(s.ctrl)
+	s.commentService = service.NewCommentService(s.repo, s.todoRepo, s.userRepo, s.projectRepo, s.notifyRepo)
+}
+
+func TestCommentService(t *testing.T) {
+	suite.Run(t, new(CommentTestSuite))
+}
+
+func (s *CommentTestSuite) TestGetComments() {
+	ownerID := uuid.NewString()
+	todo := &entity.Todo{UserID: uuid.MustParse(ownerID)}
+	todo.ID = uuid.New()
+	todoID := todo.ID.String()
+
+	s.Run("Stranger cannot read comments", func() {
+		var e *echo.HTTPError
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.todoRepo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+		result, meta, err := s.commentService.GetComments(context.Background(), dto.CommentQuery{TodoID: todoID}, uuid.NewString())
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusNotFound, e.Code)
+		s.Nil(result)
+		s.Nil(meta)
+	})
+
+	s.Run("Successfully get comments", func() {
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.todoRepo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+		s.repo.EXPECT().CountComments(gomock.Any(), gomock.Any(), todoID).Return(int64(1), nil)
+		s.repo.EXPECT().GetCommentsByTodoID(gomock.Any(), gomock.Any(), todoID, 10, 0).Return([]entity.Comment{{Body: "Hi"}}, nil)
+		result, meta, err := s.commentService.GetComments(context.Background(), dto.CommentQuery{TodoID: todoID}, ownerID)
+
+		s.Nil(err)
+		s.Len(result, 1)
+		s.Equal(1, meta.Total)
+	})
+}
+
+func (s *CommentTestSuite) TestCreateComment() {
+	ownerID := uuid.NewString()
+	project := &entity.Project{UserID: uuid.MustParse(ownerID)}
+	project.ID = uuid.New()
+	todo := &entity.Todo{Title: "Plan trip", UserID: uuid.MustParse(ownerID), ProjectID: &project.ID}
+	todo.ID = uuid.New()
+	todoID := todo.ID.String()
+	member := &entity.User{Username: "bob"}
+	member.ID = uuid.New()
+	outsider := &entity.User{Username: "eve"}
+	outsider.ID = uuid.New()
+
+	s.Run("Empty body", func() {
+		var e *echo.HTTPError
+		result, err := s.commentService.CreateComment(context.Background(), dto.CommentRequest{TodoID: todoID, Body: "   "}, ownerID)
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusUnprocessableEntity, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Successfully create comment with mentions", func() {
+		var notifications []entity.Notification
+		body := "@bob @eve can you check this? Mail owner@example.com @bob."
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.todoRepo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+			s.repo.EXPECT().CreateComment(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *gorm.DB, comment *entity.Comment) error {
+				comment.ID = uuid.New()
+				return nil
+			})
+			s.repo.EXPECT().GetCommentByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(&entity.Comment{
+				TodoID: todo.ID,
+				UserID: uuid.MustParse(ownerID),
+				Body:   body,
+				Author: &entity.UserProfile{ID: uuid.MustParse(ownerID), Username: "alice"},
+			}, nil)
+			s.userRepo.EXPECT().GetUsersByUsernames(gomock.Any(), gomock.Any(), []string{"bob", "eve"}).Return([]entity.User{*member, *outsider}, nil)
+			s.projectRepo.EXPECT().GetMemberUserIDs(gomock.Any(), gomock.Any(), project.ID.String()).Return([]string{member.ID.String()}, nil)
+			s.notifyRepo.EXPECT().CreateNotifications(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *gorm.DB, n []entity.Notification) error {
+				notifications = n
+				return nil
+			})
+			return f(&gorm.DB{})
+		})
+		result, err := s.commentService.CreateComment(context.Background(), dto.CommentRequest{TodoID: todoID, Body: body}, ownerID)
+
+		s.Nil(err)
+		s.Equal("alice", result.Author.Username)
+		s.Len(notifications, 1)
+		s.Equal(member.ID, notifications[0].UserID)
+		s.Equal(entity.NotificationCommentMention, notifications[0].Type)
+		s.Equal(`alice mentioned you on "Plan trip"`, notifications[0].Message)
+	})
+}
+
+func (s *CommentTestSuite) TestUpdateComment() {
+	ownerID := uuid.NewString()
+	authorID := uuid.NewString()
+	todo := &entity.Todo{UserID: uuid.MustParse(ownerID)}
+	todo.ID = uuid.New()
+	todoID := todo.ID.String()
+	newComment := func() *entity.Comment {
+		comment := &entity.Comment{TodoID: todo.ID, UserID: uuid.MustParse(authorID), Body: "First draft"}
+		comment.ID = uuid.New()
+		return comment
+	}
+
+	s.Run("Comment of another todo", func() {
+		var e *echo.HTTPError
+		comment := newComment()
+		comment.TodoID = uuid.New()
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetCommentByID(gomock.Any(), gomock.Any(), comment.ID.String()).Return(comment, nil)
+			return f(&gorm.DB{})
+		})
+		result, err := s.commentService.UpdateComment(context.Background(), dto.UpdateCommentRequest{
+			CommentRequest: dto.CommentRequest{TodoID: todoID, Body: "Edited"},
+			ID:             comment.ID.String(),
+		}, authorID)
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusNotFound, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Only the author can edit", func() {
+		var e *echo.HTTPError
+		comment := newComment()
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetCommentByID(gomock.Any(), gomock.Any(), comment.ID.String()).Return(comment, nil)
+			s.todoRepo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+			return f(&gorm.DB{})
+		})
+		result, err := s.commentService.UpdateComment(context.Background(), dto.UpdateCommentRequest{
+			CommentRequest: dto.CommentRequest{TodoID: todoID, Body: "Edited"},
+			ID:             comment.ID.String(),
+		}, ownerID)
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusForbidden, e.Code)
+		s.Nil(result)
+	})
+}
+
+func (s *CommentTestSuite) TestDeleteComment() {
+	ownerID := uuid.NewString()
+	authorID := uuid.NewString()
+	assignee := uuid.New()
+	todo := &entity.Todo{UserID: uuid.MustParse(ownerID), AssigneeID: &assignee}
+	todo.ID = uuid.New()
+	todoID := todo.ID.String()
+	comment := &entity.Comment{TodoID: todo.ID, UserID: uuid.MustParse(authorID)}
+	comment.ID = uuid.New()
+	commentID := comment.ID.String()
+
+	s.Run("Assignee cannot delete others' comments", func() {
+		var e *echo.HTTPError
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetCommentByID(gomock.Any(), gomock.Any(), commentID).Return(comment, nil)
+			s.todoRepo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+			return f(&gorm.DB{})
+		})
+		err := s.commentService.DeleteComment(context.Background(), todoID, commentID, assignee.String())
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusForbidden, e.Code)
+	})
+
+	s.Run("Todo owner deletes comment", func() {
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetCommentByID(gomock.Any(), gomock.Any(), commentID).Return(comment, nil)
+			s.todoRepo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+			s.repo.EXPECT().DeleteComment(gomock.Any(), gomock.Any(), comment).Return(nil)
+			return f(&gorm.DB{})
+		})
+		err := s.commentService.DeleteComment(context.Background(), todoID, commentID, ownerID)
+
+		s.Nil(err)
+	})
+}
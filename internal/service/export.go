@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"github.com/sherwin-77/golang-todos/pkg/ical"
+)
+
+const exportBatchSize = 200
+
+// todoField is one named value of an exported todo.
+type todoField struct {
+	key   string
+	value string
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+func formatUUID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+// todoFields lists every field of the todo in export order. Tags are exported
+// by name.
+func todoFields(todo *entity.Todo) []todoField {
+	var deletedAt *time.Time
+	if todo.DeletedAt.Valid {
+		deletedAt = &todo.DeletedAt.Time
+	}
+
+	tags := make([]string, len(todo.Tags))
+	for i, tag := range todo.Tags {
+		tags[i] = tag.Name
+	}
+
+	return []todoField{
+		{"id", todo.ID.String()},
+		{"title", todo.Title},
+		{"description", todo.Description},
+		{"is_completed", strconv.FormatBool(todo.IsCompleted)},
+		{"due_at", formatTime(todo.DueAt)},
+		{"remind_at", formatTime(todo.RemindAt)},
+		{"reminded_at", formatTime(todo.RemindedAt)},
+		{"project_id", formatUUID(todo.ProjectID)},
+		{"parent_id", formatUUID(todo.ParentID)},
+		{"priority", todo.Priority.String()},
+		{"position", strconv.FormatFloat(todo.Position, 'f', -1, 64)},
+		{"recurrence", todo.Recurrence},
+		{"recurrence_id", formatUUID(todo.RecurrenceID)},
+		{"occurrence", strconv.Itoa(todo.Occurrence)},
+		{"user_id", todo.UserID.String()},
+		{"version", strconv.Itoa(todo.Version)},
+		{"created_at", todo.CreatedAt.Format(time.RFC3339Nano)},
+		{"updated_at", todo.UpdatedAt.Format(time.RFC3339Nano)},
+		{"deleted_at", formatTime(deletedAt)},
+		{"tags", strings.Join(tags, ",")},
+	}
+}
+
+// todoEncoder writes todos in one export format.
+type todoEncoder interface {
+	Encode(todo *entity.Todo) error
+	Close() error
+}
+
+func newTodoEncoder(format string, w io.Writer) todoEncoder {
+	switch format {
+	case dto.FormatCSV:
+		return &csvEncoder{writer: csv.NewWriter(w)}
+	case dto.FormatMarkdown:
+		return &markdownEncoder{w: w}
+	case dto.FormatTodoTxt:
+		return &todoTxtEncoder{w: w}
+	case dto.FormatICS:
+		return &icsEncoder{encoder: ical.NewEncoder(w)}
+	default:
+		return &jsonEncoder{w: w}
+	}
+}
+
+// jsonEncoder writes a JSON array one element at a time.
+type jsonEncoder struct {
+	w     io.Writer
+	count int
+}
+
+func (e *jsonEncoder) Encode(todo *entity.Todo) error {
+	data, err := json.Marshal(todo)
+	if err != nil {
+		return err
+	}
+
+	separator := ",\n"
+	if e.count == 0 {
+		separator = "[\n"
+	}
+	e.count++
+
+	_, err = fmt.Fprintf(e.w, "%s%s", separator, data)
+	return err
+}
+
+func (e *jsonEncoder) Close() error {
+	if e.count == 0 {
+		_, err := io.WriteString(e.w, "[]\n")
+		return err
+	}
+
+	_, err := io.WriteString(e.w, "\n]\n")
+	return err
+}
+
+type csvEncoder struct {
+	writer *csv.Writer
+	header bool
+}
+
+func (e *csvEncoder) writeHeader() error {
+	e.header = true
+
+	fields := todoFields(&entity.Todo{})
+	keys := make([]string, len(fields))
+	for i, field := range fields {
+		keys[i] = field.key
+	}
+
+	return e.writer.Write(keys)
+}
+
+func (e *csvEncoder) Encode(todo *entity.Todo) error {
+	if !e.header {
+		if err := e.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	fields := todoFields(todo)
+	values := make([]string, len(fields))
+	for i, field := range fields {
+		values[i] = field.value
+	}
+
+	if err := e.writer.Write(values); err != nil {
+		return err
+	}
+
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func (e *csvEncoder) Close() error {
+	if !e.header {
+		if err := e.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// markdownEscaper keeps a value on a single line.
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, "\r", `\r`)
+
+// markdownEncoder writes a task list. Every other non-empty field follows as a
+// nested "key: value" item so the file can be imported again.
+type markdownEncoder struct {
+	w      io.Writer
+	header bool
+}
+
+func (e *markdownEncoder) writeHeader() error {
+	e.header = true
+	_, err := io.WriteString(e.w, "# Todos\n\n")
+	return err
+}
+
+func (e *markdownEncoder) Encode(todo *entity.Todo) error {
+	if !e.header {
+		if err := e.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	check := " "
+	if todo.IsCompleted {
+		check = "x"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "- [%s] %s\n", check, markdownEscaper.Replace(todo.Title))
+	for _, field := range todoFields(todo) {
+		if field.key == "title" || field.key == "is_completed" || field.value == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "  - %s: %s\n", field.key, markdownEscaper.Replace(field.value))
+	}
+
+	_, err := io.WriteString(e.w, b.String())
+	return err
+}
+
+func (e *markdownEncoder) Close() error {
+	if !e.header {
+		return e.writeHeader()
+	}
+	return nil
+}
+
+// todoTxtPriorities maps priorities to todo.txt's (A) to (D).
+var todoTxtPriorities = map[entity.Priority]string{
+	entity.PriorityUrgent: "A",
+	entity.PriorityHigh:   "B",
+	entity.PriorityMedium: "C",
+	entity.PriorityLow:    "D",
+}
+
+// todoTxtEncoder writes one line per todo in the todo.txt format. Tags become
+// @contexts, and fields todo.txt has no syntax for are added as key:value
+// pairs. Descriptions do not fit on the line and are left out.
+type todoTxtEncoder struct {
+	w io.Writer
+}
+
+func (e *todoTxtEncoder) Encode(todo *entity.Todo) error {
+	var parts []string
+	priority, hasPriority := todoTxtPriorities[todo.Priority]
+
+	if todo.IsCompleted {
+		parts = append(parts, "x", todo.UpdatedAt.Format(time.DateOnly))
+	} else if hasPriority {
+		parts = append(parts, "("+priority+")")
+	}
+	parts = append(parts, todo.CreatedAt.Format(time.DateOnly))
+	parts = append(parts, strings.Fields(todo.Title)...)
+
+	for _, tag := range todo.Tags {
+		parts = append(parts, "@"+strings.Join(strings.Fields(tag.Name), "_"))
+	}
+
+	if todo.IsCompleted && hasPriority {
+		parts = append(parts, "pri:"+priority)
+	}
+	if todo.DueAt != nil {
+		parts = append(parts, "due:"+formatTime(todo.DueAt))
+	}
+	if todo.RemindAt != nil {
+		parts = append(parts, "remind:"+formatTime(todo.RemindAt))
+	}
+	if todo.Recurrence != "" {
+		parts = append(parts, "rec:"+todo.Recurrence)
+	}
+	if todo.ProjectID != nil {
+		parts = append(parts, "project:"+todo.ProjectID.String())
+	}
+	if todo.ParentID != nil {
+		parts = append(parts, "parent:"+todo.ParentID.String())
+	}
+	parts = append(parts, "id:"+todo.ID.String())
+
+	_, err := io.WriteString(e.w, strings.Join(parts, " ")+"\n")
+	return err
+}
+
+func (e *todoTxtEncoder) Close() error {
+	return nil
+}
+
+// ExportTodos streams every todo the user can see to w, oldest first so that
+// a todo always comes before its subtasks. The todos are read in batches and
+// flushed as they go, so memory use does not grow with the number of todos.
+func (s *todoService) ExportTodos(ctx context.Context, userID string, format string, w io.Writer) error {
+	encoder := newTodoEncoder(format, w)
+	db := s.todoRepository.SingleTransaction()
+
+	for offset := 0; ; offset += exportBatchSize {
+		todos, err := s.todoRepository.GetTodosFiltered(ctx, db, exportBatchSize, offset, "created_at, id", repository.TodoAccessScope, userID, userID, userID)
+		if err != nil {
+			return err
+		}
+
+		for i := range todos {
+			if err := encoder.Encode(&todos[i]); err != nil {
+				return err
+			}
+		}
+
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+
+		if len(todos) < exportBatchSize {
+			break
+		}
+	}
+
+	return encoder.Close()
+}
@@ -0,0 +1,548 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/configs"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"gorm.io/gorm"
+)
+
+const importBatchSize = 100
+
+// importRow is a todo read from an import file, along with everything wrong
+// with it. Rows with errors are reported and skipped.
+type importRow struct {
+	number int
+	todo   entity.Todo
+	errs   []string
+}
+
+func parseTimeField(key string, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid RFC 3339 time", key)
+	}
+	return &t, nil
+}
+
+func parseUUIDField(key string, value string) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid UUID", key)
+	}
+	return &id, nil
+}
+
+// setTodoField sets an exported field by name. Fields that only describe the
+// exported todo, like its version or timestamps, are ignored.
+func setTodoField(todo *entity.Todo, key string, value string) error {
+	var err error
+
+	switch key {
+	case "id":
+		var id *uuid.UUID
+		if id, err = parseUUIDField(key, value); id != nil {
+			todo.ID = *id
+		}
+	case "title":
+		todo.Title = value
+	case "description":
+		todo.Description = value
+	case "is_completed":
+		if value != "" {
+			if todo.IsCompleted, err = strconv.ParseBool(value); err != nil {
+				err = fmt.Errorf("%s is not a valid boolean", key)
+			}
+		}
+	case "due_at":
+		todo.DueAt, err = parseTimeField(key, value)
+	case "remind_at":
+		todo.RemindAt, err = parseTimeField(key, value)
+	case "project_id":
+		todo.ProjectID, err = parseUUIDField(key, value)
+	case "parent_id":
+		todo.ParentID, err = parseUUIDField(key, value)
+	case "priority":
+		if todo.Priority, err = entity.ParsePriority(value); err != nil {
+			err = fmt.Errorf("%s is not a valid priority", key)
+		}
+	case "recurrence":
+		todo.Recurrence = value
+	case "tags":
+		todo.Tags = nil
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				todo.Tags = append(todo.Tags, &entity.Tag{Name: name})
+			}
+		}
+	}
+
+	return err
+}
+
+// decodeTodos reads every row of an import file. It fails only when the file
+// as a whole cannot be read; problems with single rows are kept on the rows.
+func decodeTodos(format string, r io.Reader) ([]importRow, error) {
+	switch format {
+	case dto.FormatCSV:
+		return decodeCSVTodos(r)
+	case dto.FormatMarkdown:
+		return decodeMarkdownTodos(r)
+	case dto.FormatTodoTxt:
+		return decodeTodoTxtTodos(r)
+	case dto.FormatICS:
+		return decodeICSTodos(r)
+	default:
+		return decodeJSONTodos(r)
+	}
+}
+
+func decodeJSONTodos(r io.Reader) ([]importRow, error) {
+	decoder := json.NewDecoder(r)
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	if token != json.Delim('[') {
+		return nil, errors.New("expected an array of todos")
+	}
+
+	var rows []importRow
+	for decoder.More() {
+		row := importRow{number: len(rows) + 1}
+
+		// A value of the wrong type spoils only its own row; broken JSON spoils the file.
+		if err := decoder.Decode(&row.todo); err != nil {
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil, err
+			}
+			row.errs = append(row.errs, err.Error())
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func decodeCSVTodos(r io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	hasTitle := false
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+		hasTitle = hasTitle || header[i] == "title"
+	}
+	if !hasTitle {
+		return nil, errors.New("missing title column")
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		row := importRow{number: len(rows) + 1}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			row.errs = append(row.errs, parseErr.Error())
+			rows = append(rows, row)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for i, value := range record {
+			if i >= len(header) {
+				break
+			}
+			if err := setTodoField(&row.todo, header[i], value); err != nil {
+				row.errs = append(row.errs, err.Error())
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+var (
+	markdownTaskPattern  = regexp.MustCompile(`^\s*[-*+] \[([ xX])\] ?(.*)$`)
+	markdownFieldPattern = regexp.MustCompile(`^\s+[-*+] ([a-z_]+): ?(.*)$`)
+)
+
+// unescapeMarkdown reverses markdownEscaper.
+func unescapeMarkdown(value string) string {
+	var b strings.Builder
+	escaped := false
+
+	for _, r := range value {
+		switch {
+		case escaped && r == 'n':
+			b.WriteRune('\n')
+		case escaped && r == 'r':
+			b.WriteRune('\r')
+		case escaped:
+			b.WriteRune(r)
+		case r == '\\':
+			escaped = true
+			continue
+		default:
+			b.WriteRune(r)
+		}
+		escaped = false
+	}
+
+	return b.String()
+}
+
+// decodeMarkdownTodos reads a task list. Each "- [ ]" or "- [x]" item is a todo,
+// and "key: value" items nested under it set its other fields. Everything else,
+// such as headings and prose, is skipped.
+func decodeMarkdownTodos(r io.Reader) ([]importRow, error) {
+	var rows []importRow
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if match := markdownTaskPattern.FindStringSubmatch(line); match != nil {
+			row := importRow{number: len(rows) + 1}
+			row.todo.IsCompleted = match[1] != " "
+			row.todo.Title = unescapeMarkdown(strings.TrimSpace(match[2]))
+			rows = append(rows, row)
+			continue
+		}
+
+		if match := markdownFieldPattern.FindStringSubmatch(line); match != nil && len(rows) > 0 {
+			row := &rows[len(rows)-1]
+			if err := setTodoField(&row.todo, match[1], unescapeMarkdown(match[2])); err != nil {
+				row.errs = append(row.errs, err.Error())
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+var (
+	todoTxtPriorityPattern = regexp.MustCompile(`^\(([A-Z])\)$`)
+
+	// todoTxtKeys maps the key:value pairs written by the todo.txt export to
+	// todo fields. Pairs with other keys are kept as part of the title.
+	todoTxtKeys = map[string]string{
+		"due":     "due_at",
+		"remind":  "remind_at",
+		"rec":     "recurrence",
+		"project": "project_id",
+		"parent":  "parent_id",
+		"id":      "id",
+	}
+)
+
+func todoTxtPriority(letter string) entity.Priority {
+	for priority, l := range todoTxtPriorities {
+		if l == letter {
+			return priority
+		}
+	}
+	return entity.PriorityLow
+}
+
+func isTodoTxtDate(word string) bool {
+	_, err := time.Parse(time.DateOnly, word)
+	return err == nil
+}
+
+// decodeTodoTxtTodos reads one todo per non-empty line. Completion and creation
+// dates are skipped since todos keep their own timestamps.
+func decodeTodoTxtTodos(r io.Reader) ([]importRow, error) {
+	var rows []importRow
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		words := strings.Fields(scanner.Text())
+		if len(words) == 0 {
+			continue
+		}
+
+		row := importRow{number: len(rows) + 1}
+
+		if words[0] == "x" {
+			row.todo.IsCompleted = true
+			words = words[1:]
+		} else if match := todoTxtPriorityPattern.FindStringSubmatch(words[0]); match != nil {
+			row.todo.Priority = todoTxtPriority(match[1])
+			words = words[1:]
+		}
+
+		for i := 0; i < 2 && len(words) > 0 && isTodoTxtDate(words[0]); i++ {
+			words = words[1:]
+		}
+
+		var title []string
+		for _, word := range words {
+			key, value, found := strings.Cut(word, ":")
+
+			switch {
+			case strings.HasPrefix(word, "@") && len(word) > 1:
+				row.todo.Tags = append(row.todo.Tags, &entity.Tag{Name: word[1:]})
+			case found && key == "pri" && todoTxtPriorityPattern.MatchString("("+value+")"):
+				row.todo.Priority = todoTxtPriority(value)
+			case found && todoTxtKeys[key] != "":
+				if err := setTodoField(&row.todo, todoTxtKeys[key], value); err != nil {
+					row.errs = append(row.errs, err.Error())
+				}
+			default:
+				title = append(title, word)
+			}
+		}
+
+		row.todo.Title = strings.Join(title, " ")
+		rows = append(rows, row)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// validateImportRow checks the row the same way a created todo is checked.
+func (s *todoService) validateImportRow(row *importRow) []string {
+	request := dto.TodoRequest{
+		Title:       row.todo.Title,
+		Description: row.todo.Description,
+		IsCompleted: row.todo.IsCompleted,
+		DueAt:       row.todo.DueAt,
+		RemindAt:    row.todo.RemindAt,
+		ProjectID:   formatUUID(row.todo.ProjectID),
+		Recurrence:  row.todo.Recurrence,
+		Priority:    row.todo.Priority.String(),
+	}
+
+	var errs []string
+	for _, err := range []error{s.validator.Validate(request), validateReminder(row.todo.DueAt, row.todo.RemindAt)} {
+		var validationErrs validator.ValidationErrors
+		var httpErr *echo.HTTPError
+
+		switch {
+		case err == nil:
+		case errors.As(err, &validationErrs):
+			for _, fieldErr := range validationErrs {
+				errs = append(errs, configs.ValidationMessage(fieldErr))
+			}
+		case errors.As(err, &httpErr):
+			errs = append(errs, fmt.Sprint(httpErr.Message))
+		default:
+			errs = append(errs, err.Error())
+		}
+	}
+
+	for _, tag := range row.todo.Tags {
+		if err := s.validator.Validate(dto.TagRequest{Name: tag.Name, Color: tag.Color}); err != nil {
+			errs = append(errs, "Tag "+strconv.Quote(tag.Name)+" is not valid")
+		}
+	}
+
+	return errs
+}
+
+// buildImportedTodo turns a valid row into a new todo. Imported todos get new
+// IDs; imported maps the IDs in the file to the todos created for them, so a
+// subtask is attached to its imported parent.
+func (s *todoService) buildImportedTodo(ctx context.Context, tx *gorm.DB, row *importRow, userID string, imported map[string]*entity.Todo, tags map[string]*entity.Tag) (*entity.Todo, error) {
+	recurrence, err := normalizeRecurrence(row.todo.Recurrence, row.todo.DueAt)
+	if err != nil {
+		return nil, err
+	}
+
+	todo := &entity.Todo{
+		Title:       row.todo.Title,
+		Description: row.todo.Description,
+		IsCompleted: row.todo.IsCompleted,
+		DueAt:       row.todo.DueAt,
+		RemindAt:    row.todo.RemindAt,
+		Recurrence:  recurrence,
+		Priority:    row.todo.Priority,
+		UserID:      uuid.MustParse(userID),
+	}
+
+	if row.todo.ParentID != nil {
+		parent, ok := imported[row.todo.ParentID.String()]
+		if !ok {
+			return nil, echo.NewHTTPError(http.StatusUnprocessableEntity, "Parent todo is not part of the import")
+		}
+		if parent.ParentID != nil {
+			return nil, echo.NewHTTPError(http.StatusUnprocessableEntity, "Subtasks cannot have subtasks")
+		}
+
+		todo.ParentID = &parent.ID
+		todo.ProjectID = parent.ProjectID
+	} else {
+		todo.ProjectID, err = s.resolveProject(ctx, tx, formatUUID(row.todo.ProjectID), userID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, echo.NewHTTPError(http.StatusNotFound, "Project not found")
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, rowTag := range row.todo.Tags {
+		tag, ok := tags[strings.ToLower(rowTag.Name)]
+		if !ok {
+			tag = &entity.Tag{Name: rowTag.Name, Color: rowTag.Color, UserID: todo.UserID}
+			if tag.Color == "" {
+				tag.Color = defaultTagColor
+			}
+			if err := s.tagRepository.CreateTag(ctx, tx, tag); err != nil {
+				return nil, err
+			}
+			tags[strings.ToLower(tag.Name)] = tag
+		}
+		todo.Tags = append(todo.Tags, tag)
+	}
+
+	todo.Position, err = s.todoRepository.NextPosition(ctx, tx, todo)
+	if err != nil {
+		return nil, err
+	}
+
+	if recurrence != "" {
+		todo.ID, err = uuid.NewV7()
+		if err != nil {
+			return nil, err
+		}
+		seriesID := todo.ID
+		todo.RecurrenceID = &seriesID
+	}
+
+	return todo, nil
+}
+
+// ImportTodos reads the whole file first, so a malformed file is rejected
+// before anything is written, then creates the valid rows in batches of
+// importBatchSize, each in its own transaction. Tags are matched by name and
+// created when the user has none by that name.
+func (s *todoService) ImportTodos(ctx context.Context, userID string, format string, r io.Reader) (*dto.ImportResult, error) {
+	rows, err := decodeTodos(format, r)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, echo.NewHTTPError(http.StatusRequestEntityTooLarge, "Import file is too large")
+		}
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Malformed import file: "+err.Error())
+	}
+
+	userTags, err := s.tagRepository.GetTagsByUserID(ctx, s.tagRepository.SingleTransaction(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[string]*entity.Tag, len(userTags))
+	for i := range userTags {
+		tags[strings.ToLower(userTags[i].Name)] = &userTags[i]
+	}
+
+	result := &dto.ImportResult{Errors: []dto.ImportRowError{}}
+	imported := map[string]*entity.Todo{}
+
+	for start := 0; start < len(rows); start += importBatchSize {
+		batch := rows[start:min(start+importBatchSize, len(rows))]
+
+		if err := s.todoRepository.WithTransaction(func(tx *gorm.DB) error {
+			for i := range batch {
+				row := &batch[i]
+				if len(row.errs) == 0 {
+					row.errs = s.validateImportRow(row)
+				}
+				if len(row.errs) > 0 {
+					continue
+				}
+
+				todo, err := s.buildImportedTodo(ctx, tx, row, userID, imported, tags)
+				var httpErr *echo.HTTPError
+				if errors.As(err, &httpErr) {
+					row.errs = append(row.errs, fmt.Sprint(httpErr.Message))
+					continue
+				}
+				if err != nil {
+					return err
+				}
+
+				if err := s.todoRepository.CreateTodo(ctx, tx, todo); err != nil {
+					return err
+				}
+
+				if row.todo.ID != uuid.Nil {
+					imported[row.todo.ID.String()] = todo
+				}
+			}
+
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+
+		for _, row := range batch {
+			if len(row.errs) == 0 {
+				result.Imported++
+				continue
+			}
+
+			result.Failed++
+			result.Errors = append(result.Errors, dto.ImportRowError{Row: row.number, Errors: row.errs})
+		}
+	}
+
+	if err := s.cache.Del("todos:all:"+userID, "tags:all:"+userID); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"github.com/sherwin-77/golang-todos/pkg/caches"
+	"gorm.io/gorm"
+)
+
+// projectRole resolves what userID may do in project. Its creator owns it;
+// anyone else needs an accepted membership. The empty role means no access.
+func projectRole(ctx context.Context, tx *gorm.DB, projectRepository repository.ProjectRepository, project *entity.Project, userID string) (entity.ProjectRole, error) {
+	if project.UserID.String() == userID {
+		return entity.ProjectOwner, nil
+	}
+
+	member, err := projectRepository.GetMember(ctx, tx, project.ID.String(), userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if member.AcceptedAt == nil {
+		return "", nil
+	}
+
+	return member.Role, nil
+}
+
+// delUserKeys deletes the cache key prefix+userID once for every distinct user.
+func delUserKeys(cache caches.Cache, prefix string, userIDs []string) error {
+	seen := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		if err := cache.Del(prefix + userID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *projectService) GetMembers(ctx context.Context, projectID string, userID string) ([]entity.ProjectMember, error) {
+	db := s.projectRepository.SingleTransaction()
+
+	if _, err := s.projectWithRole(ctx, db, projectID, userID, entity.ProjectViewer); err != nil {
+		return nil, err
+	}
+
+	return s.projectRepository.GetMembers(ctx, db, projectID)
+}
+
+func (s *projectService) GetInvitations(ctx context.Context, userID string) ([]entity.ProjectMember, error) {
+	db := s.projectRepository.SingleTransaction()
+
+	return s.projectRepository.GetInvitations(ctx, db, userID)
+}
+
+// InviteMember invites the user with the given email to the project. Inviting
+// someone who is already a member changes their role instead.
+func (s *projectService) InviteMember(ctx context.Context, request dto.InviteMemberRequest, userID string) (*entity.ProjectMember, error) {
+	var member *entity.ProjectMember
+
+	if err := s.projectRepository.WithTransaction(func(tx *gorm.DB) error {
+		project, err := s.projectWithRole(ctx, tx, request.ID, userID, entity.ProjectOwner)
+		if err != nil {
+			return err
+		}
+
+		invitee, err := s.userRepository.GetUserByEmail(ctx, tx, request.Email)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "User not found")
+		}
+		if err != nil {
+			return err
+		}
+
+		if invitee.ID == project.UserID {
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "User already owns this project")
+		}
+
+		member, err = s.projectRepository.GetMember(ctx, tx, request.ID, invitee.ID.String())
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			member = &entity.ProjectMember{
+				ProjectID: project.ID,
+				UserID:    invitee.ID,
+				Role:      entity.ProjectRole(request.Role),
+				InvitedBy: uuid.MustParse(userID),
+			}
+
+			return s.projectRepository.CreateMember(ctx, tx, member)
+		}
+		if err != nil {
+			return err
+		}
+
+		member.Role = entity.ProjectRole(request.Role)
+
+		return s.projectRepository.UpdateMember(ctx, tx, member)
+	}); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (s *projectService) AcceptInvitation(ctx context.Context, projectID string, userID string) (*entity.ProjectMember, error) {
+	db := s.projectRepository.SingleTransaction()
+
+	member, err := s.projectRepository.GetMember(ctx, db, projectID, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Invitation not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if member.AcceptedAt != nil {
+		return member, nil
+	}
+
+	now := time.Now()
+	member.AcceptedAt = &now
+
+	if err := s.projectRepository.UpdateMember(ctx, db, member); err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.Del("projects:all:" + userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.Del("todos:all:" + userID); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// RevokeMember removes a member or a pending invitation. Owners may remove
+// anyone; other members may only remove themselves, to leave or decline.
+func (s *projectService) RevokeMember(ctx context.Context, projectID string, memberID string, userID string) error {
+	if err := s.projectRepository.WithTransaction(func(tx *gorm.DB) error {
+		if memberID != userID {
+			if _, err := s.projectWithRole(ctx, tx, projectID, userID, entity.ProjectOwner); err != nil {
+				return err
+			}
+		}
+
+		member, err := s.projectRepository.GetMember(ctx, tx, projectID, memberID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "Member not found")
+		}
+		if err != nil {
+			return err
+		}
+
+		return s.projectRepository.DeleteMember(ctx, tx, member)
+	}); err != nil {
+		return err
+	}
+
+	if err := s.cache.Del("projects:all:" + memberID); err != nil {
+		return err
+	}
+
+	return s.cache.Del("todos:all:" + memberID)
+}
+
+// projectWithRole loads a project the user holds at least the required role in.
+// Projects the user cannot see at all are reported as not found.
+func (s *projectService) projectWithRole(ctx context.Context, tx *gorm.DB, projectID string, userID string, required entity.ProjectRole) (*entity.Project, error) {
+	project, err := s.projectRepository.GetProjectByID(ctx, tx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := projectRole(ctx, tx, s.projectRepository, project, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if role == "" {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Project not found")
+	}
+
+	if !role.Allows(required) {
+		return nil, echo.NewHTTPError(http.StatusForbidden, "Only project owners can manage members")
+	}
+
+	return project, nil
+}
@@ -0,0 +1,245 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/pkg/patch"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func (s *ProjectTestSuite) TestInviteMember() {
+	ownerID := uuid.NewString()
+	project := &entity.Project{UserID: uuid.MustParse(ownerID)}
+	project.ID = uuid.New()
+	projectID := project.ID.String()
+	invitee := &entity.User{Email: "friend@example.com"}
+	invitee.ID = uuid.New()
+	request := dto.InviteMemberRequest{ID: projectID, Email: invitee.Email, Role: "editor"}
+
+	s.Run("Only owners can invite", func() {
+		var e *echo.HTTPError
+		editorID := uuid.NewString()
+		acceptedAt := time.Now()
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), projectID).Return(project, nil)
+			s.repo.EXPECT().GetMember(gomock.Any(), gomock.Any(), projectID, editorID).Return(&entity.ProjectMember{Role: entity.ProjectEditor, AcceptedAt: &acceptedAt}, nil)
+			return f(&gorm.DB{})
+		})
+		result, err := s.projectService.InviteMember(context.Background(), request, editorID)
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusForbidden, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Unknown email", func() {
+		var e *echo.HTTPError
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), projectID).Return(project, nil)
+			s.userRepo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any(), invitee.Email).Return(nil, gorm.ErrRecordNotFound)
+			return f(&gorm.DB{})
+		})
+		result, err := s.projectService.InviteMember(context.Background(), request, ownerID)
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusNotFound, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Successfully invite member", func() {
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), projectID).Return(project, nil)
+			s.userRepo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any(), invitee.Email).Return(invitee, nil)
+			s.repo.EXPECT().GetMember(gomock.Any(), gomock.Any(), projectID, invitee.ID.String()).Return(nil, gorm.ErrRecordNotFound)
+			s.repo.EXPECT().CreateMember(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			return f(&gorm.DB{})
+		})
+		result, err := s.projectService.InviteMember(context.Background(), request, ownerID)
+
+		s.Nil(err)
+		s.Equal(invitee.ID, result.UserID)
+		s.Equal(entity.ProjectEditor, result.Role)
+		s.Nil(result.AcceptedAt)
+	})
+
+	s.Run("Inviting a member again changes their role", func() {
+		acceptedAt := time.Now()
+		member := &entity.ProjectMember{ProjectID: project.ID, UserID: invitee.ID, Role: entity.ProjectViewer, AcceptedAt: &acceptedAt}
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), projectID).Return(project, nil)
+			s.userRepo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any(), invitee.Email).Return(invitee, nil)
+			s.repo.EXPECT().GetMember(gomock.Any(), gomock.Any(), projectID, invitee.ID.String()).Return(member, nil)
+			s.repo.EXPECT().UpdateMember(gomock.Any(), gomock.Any(), member).Return(nil)
+			return f(&gorm.DB{})
+		})
+		result, err := s.projectService.InviteMember(context.Background(), request, ownerID)
+
+		s.Nil(err)
+		s.Equal(entity.ProjectEditor, result.Role)
+		s.NotNil(result.AcceptedAt)
+	})
+}
+
+func (s *ProjectTestSuite) TestAcceptInvitation() {
+	projectID := uuid.NewString()
+	userID := uuid.NewString()
+
+	s.Run("No invitation", func() {
+		var e *echo.HTTPError
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetMember(gomock.Any(), gomock.Any(), projectID, userID).Return(nil, gorm.ErrRecordNotFound)
+		result, err := s.projectService.AcceptInvitation(context.Background(), projectID, userID)
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusNotFound, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Successfully accept invitation", func() {
+		member := &entity.ProjectMember{Role: entity.ProjectViewer}
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetMember(gomock.Any(), gomock.Any(), projectID, userID).Return(member, nil)
+		s.repo.EXPECT().UpdateMember(gomock.Any(), gomock.Any(), member).Return(nil)
+		s.cache.EXPECT().Del("projects:all:" + userID).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + userID).Return(nil)
+		result, err := s.projectService.AcceptInvitation(context.Background(), projectID, userID)
+
+		s.Nil(err)
+		s.NotNil(result.AcceptedAt)
+	})
+}
+
+func (s *ProjectTestSuite) TestRevokeMember() {
+	ownerID := uuid.NewString()
+	memberID := uuid.NewString()
+	project := &entity.Project{UserID: uuid.MustParse(ownerID)}
+	project.ID = uuid.New()
+	projectID := project.ID.String()
+	member := &entity.ProjectMember{ProjectID: project.ID, UserID: uuid.MustParse(memberID), Role: entity.ProjectViewer}
+
+	s.Run("Strangers cannot revoke", func() {
+		var e *echo.HTTPError
+		strangerID := uuid.NewString()
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), projectID).Return(project, nil)
+			s.repo.EXPECT().GetMember(gomock.Any(), gomock.Any(), projectID, strangerID).Return(nil, gorm.ErrRecordNotFound)
+			return f(&gorm.DB{})
+		})
+		err := s.projectService.RevokeMember(context.Background(), projectID, memberID, strangerID)
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusNotFound, e.Code)
+	})
+
+	s.Run("Owner revokes member", func() {
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), projectID).Return(project, nil)
+			s.repo.EXPECT().GetMember(gomock.Any(), gomock.Any(), projectID, memberID).Return(member, nil)
+			s.repo.EXPECT().DeleteMember(gomock.Any(), gomock.Any(), member).Return(nil)
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("projects:all:" + memberID).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + memberID).Return(nil)
+		err := s.projectService.RevokeMember(context.Background(), projectID, memberID, ownerID)
+
+		s.Nil(err)
+	})
+
+	s.Run("Member leaves", func() {
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetMember(gomock.Any(), gomock.Any(), projectID, memberID).Return(member, nil)
+			s.repo.EXPECT().DeleteMember(gomock.Any(), gomock.Any(), member).Return(nil)
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("projects:all:" + memberID).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + memberID).Return(nil)
+		err := s.projectService.RevokeMember(context.Background(), projectID, memberID, memberID)
+
+		s.Nil(err)
+	})
+}
+
+func (s *TodoTestSuite) TestSharedTodos() {
+	ownerID := uuid.NewString()
+	memberID := uuid.NewString()
+	acceptedAt := time.Now()
+	project := &entity.Project{UserID: uuid.MustParse(ownerID)}
+	project.ID = uuid.New()
+	todo := &entity.Todo{UserID: uuid.MustParse(ownerID), ProjectID: &project.ID}
+	todo.ID = uuid.New()
+	todoID := todo.ID.String()
+	member := func(role entity.ProjectRole) *entity.ProjectMember {
+		return &entity.ProjectMember{ProjectID: project.ID, UserID: uuid.MustParse(memberID), Role: role, AcceptedAt: &acceptedAt}
+	}
+
+	s.Run("Pending invitation grants nothing", func() {
+		var e *echo.HTTPError
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+			s.projectRepo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), project.ID.String()).Return(project, nil)
+			s.projectRepo.EXPECT().GetMember(gomock.Any(), gomock.Any(), project.ID.String(), memberID).Return(&entity.ProjectMember{Role: entity.ProjectEditor}, nil)
+			return f(&gorm.DB{})
+		})
+		err := s.todoService.DeleteTodo(context.Background(), todoID, memberID, 0)
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusNotFound, e.Code)
+	})
+
+	s.Run("Viewer cannot update", func() {
+		var e *echo.HTTPError
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			todoRet := *todo
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(&todoRet, nil)
+			s.projectRepo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), project.ID.String()).Return(project, nil)
+			s.projectRepo.EXPECT().GetMember(gomock.Any(), gomock.Any(), project.ID.String(), memberID).Return(member(entity.ProjectViewer), nil)
+			return f(&gorm.DB{})
+		})
+		result, err := s.todoService.UpdateTodo(context.Background(), dto.UpdateTodoRequest{ID: todoID, Title: patch.Value("Shared")}, memberID)
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusForbidden, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Viewer can read", func() {
+		marshalledData, _ := json.Marshal(todo)
+		s.cache.EXPECT().Get("todos:" + todoID).Return(string(marshalledData))
+		s.projectRepo.EXPECT().SingleTransaction().Return(nil)
+		s.projectRepo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), project.ID.String()).Return(project, nil)
+		s.projectRepo.EXPECT().GetMember(gomock.Any(), gomock.Any(), project.ID.String(), memberID).Return(member(entity.ProjectViewer), nil)
+		result, err := s.todoService.GetTodoByID(context.Background(), todoID, memberID)
+
+		s.Nil(err)
+		s.Equal(todo.ID, result.ID)
+	})
+
+	s.Run("Editor update invalidates every member's list", func() {
+		otherID := uuid.NewString()
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			todoRet := *todo
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(&todoRet, nil)
+			s.projectRepo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), project.ID.String()).Return(project, nil)
+			s.projectRepo.EXPECT().GetMember(gomock.Any(), gomock.Any(), project.ID.String(), memberID).Return(member(entity.ProjectEditor), nil)
+			s.projectRepo.EXPECT().GetMemberUserIDs(gomock.Any(), gomock.Any(), project.ID.String()).Return([]string{ownerID, memberID, otherID}, nil)
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("todos:" + todoID).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + ownerID).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + memberID).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + otherID).Return(nil)
+		result, err := s.todoService.UpdateTodo(context.Background(), dto.UpdateTodoRequest{ID: todoID, Title: patch.Value("Shared")}, memberID)
+
+		s.Nil(err)
+		s.Equal("Shared", result.Title)
+	})
+}
@@ -0,0 +1,355 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/configs"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"github.com/sherwin-77/golang-todos/pkg/caches"
+	"github.com/sherwin-77/golang-todos/pkg/tokens"
+	"github.com/sherwin-77/golang-todos/pkg/totp"
+	"gorm.io/gorm"
+)
+
+const (
+	// recoveryCodeCount is how many recovery codes a user holds at a time.
+	recoveryCodeCount = 10
+
+	// maxMFAAttempts wrong codes lock the second factor of a user for
+	// mfaAttemptWindow, which bounds guessing of TOTP codes.
+	maxMFAAttempts   = 5
+	mfaAttemptWindow = 15 * time.Minute
+)
+
+type MFAService interface {
+	Enroll(ctx context.Context, userID string) (*dto.MFAEnrollResponse, error)
+	Confirm(ctx context.Context, request dto.MFACodeRequest, userID string) (*dto.RecoveryCodesResponse, error)
+	Disable(ctx context.Context, request dto.MFACodeRequest, userID string) error
+	RegenerateRecoveryCodes(ctx context.Context, request dto.MFACodeRequest, userID string) (*dto.RecoveryCodesResponse, error)
+	GetPolicy(ctx context.Context) (*dto.MFAPolicy, error)
+	UpdatePolicy(ctx context.Context, request dto.MFAPolicy) (*dto.MFAPolicy, error)
+}
+
+type mfaService struct {
+	userRepository         repository.UserRepository
+	recoveryCodeRepository repository.RecoveryCodeRepository
+	settingRepository      repository.SettingRepository
+	cache                  caches.Cache
+	config                 configs.AuthConfig
+}
+
+func NewMFAService(
+	userRepository repository.UserRepository,
+	recoveryCodeRepository repository.RecoveryCodeRepository,
+	settingRepository repository.SettingRepository,
+	cache caches.Cache,
+	config configs.AuthConfig,
+) MFAService {
+	return &mfaService{userRepository, recoveryCodeRepository, settingRepository, cache, config}
+}
+
+// Enroll gives the user a new TOTP secret. Enrolling again before confirming
+// replaces the secret.
+func (s *mfaService) Enroll(ctx context.Context, userID string) (*dto.MFAEnrollResponse, error) {
+	db := s.userRepository.SingleTransaction()
+	user, err := s.userRepository.GetUserByID(ctx, db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabledAt != nil {
+		return nil, echo.NewHTTPError(http.StatusConflict, "Two-factor authentication is already enabled")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	user.TOTPSecret = &secret
+	if err := s.userRepository.UpdateUser(ctx, db, user); err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.Del("users:"+userID, "users:all"); err != nil {
+		return nil, err
+	}
+
+	return &dto.MFAEnrollResponse{
+		Secret: secret,
+		URI:    totp.URI(s.config.MFAIssuer, user.Email, secret),
+	}, nil
+}
+
+// Confirm enables two-factor authentication once the user proves their
+// authenticator works, and returns the first recovery codes. The code is not
+// used up, so a login waiting on enrollment can be finished with it.
+func (s *mfaService) Confirm(ctx context.Context, request dto.MFACodeRequest, userID string) (*dto.RecoveryCodesResponse, error) {
+	var codes []string
+
+	if err := s.userRepository.WithTransaction(func(tx *gorm.DB) error {
+		user, err := s.userRepository.GetUserByID(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+
+		if user.TOTPEnabledAt != nil {
+			return echo.NewHTTPError(http.StatusConflict, "Two-factor authentication is already enabled")
+		}
+		if user.TOTPSecret == nil {
+			return echo.NewHTTPError(http.StatusConflict, "Enroll in two-factor authentication first")
+		}
+
+		if _, ok := totp.Validate(*user.TOTPSecret, request.Code, time.Now()); !ok {
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "Invalid authentication code")
+		}
+
+		now := time.Now()
+		user.TOTPEnabledAt = &now
+		if err := s.userRepository.UpdateUser(ctx, tx, user); err != nil {
+			return err
+		}
+
+		codes, err = replaceRecoveryCodes(ctx, tx, s.recoveryCodeRepository, user.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.Del("users:"+userID, "users:all"); err != nil {
+		return nil, err
+	}
+
+	return &dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// Disable turns two-factor authentication off after checking a code, unless
+// the policy requires it of the user.
+func (s *mfaService) Disable(ctx context.Context, request dto.MFACodeRequest, userID string) error {
+	if err := s.userRepository.WithTransaction(func(tx *gorm.DB) error {
+		user, err := s.enabledUser(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+
+		required, err := mfaRequired(ctx, tx, s.userRepository, s.settingRepository, userID)
+		if err != nil {
+			return err
+		}
+		if required {
+			return echo.NewHTTPError(http.StatusForbidden, "Two-factor authentication is required for your role")
+		}
+
+		if err := verifySecondFactor(ctx, tx, s.userRepository, s.recoveryCodeRepository, s.cache, user, request.Code); err != nil {
+			return err
+		}
+
+		user.TOTPSecret = nil
+		user.TOTPEnabledAt = nil
+		user.TOTPLastCounter = 0
+		if err := s.userRepository.UpdateUser(ctx, tx, user); err != nil {
+			return err
+		}
+
+		return s.recoveryCodeRepository.DeleteRecoveryCodes(ctx, tx, userID)
+	}); err != nil {
+		return err
+	}
+
+	return s.cache.Del("users:"+userID, "users:all")
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes after checking a
+// code.
+func (s *mfaService) RegenerateRecoveryCodes(ctx context.Context, request dto.MFACodeRequest, userID string) (*dto.RecoveryCodesResponse, error) {
+	var codes []string
+
+	if err := s.userRepository.WithTransaction(func(tx *gorm.DB) error {
+		user, err := s.enabledUser(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := verifySecondFactor(ctx, tx, s.userRepository, s.recoveryCodeRepository, s.cache, user, request.Code); err != nil {
+			return err
+		}
+
+		codes, err = replaceRecoveryCodes(ctx, tx, s.recoveryCodeRepository, user.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (s *mfaService) GetPolicy(ctx context.Context) (*dto.MFAPolicy, error) {
+	level, err := mfaRequiredLevel(ctx, s.settingRepository.SingleTransaction(), s.settingRepository)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.MFAPolicy{RequiredLevel: level}, nil
+}
+
+// UpdatePolicy changes the auth level from which two-factor authentication is
+// required. Affected users without it have to set it up on their next login
+// or refresh.
+func (s *mfaService) UpdatePolicy(ctx context.Context, request dto.MFAPolicy) (*dto.MFAPolicy, error) {
+	db := s.settingRepository.SingleTransaction()
+	if err := s.settingRepository.SaveSetting(ctx, db, &entity.Setting{
+		Key:   entity.SettingMFARequiredLevel,
+		Value: strconv.Itoa(request.RequiredLevel),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+// enabledUser loads a user who has two-factor authentication enabled.
+func (s *mfaService) enabledUser(ctx context.Context, tx *gorm.DB, userID string) (*entity.User, error) {
+	user, err := s.userRepository.GetUserByID(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabledAt == nil {
+		return nil, echo.NewHTTPError(http.StatusConflict, "Two-factor authentication is not enabled")
+	}
+
+	return user, nil
+}
+
+// mfaRequiredLevel returns the lowest auth level that has to use two-factor
+// authentication, or zero if no one has to.
+func mfaRequiredLevel(ctx context.Context, tx *gorm.DB, settingRepository repository.SettingRepository) (int, error) {
+	setting, err := settingRepository.GetSetting(ctx, tx, entity.SettingMFARequiredLevel)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(setting.Value)
+}
+
+// mfaRequired reports whether the policy requires two-factor authentication
+// of the user.
+func mfaRequired(ctx context.Context, tx *gorm.DB, userRepository repository.UserRepository, settingRepository repository.SettingRepository, userID string) (bool, error) {
+	required, err := mfaRequiredLevel(ctx, tx, settingRepository)
+	if err != nil || required == 0 {
+		return false, err
+	}
+
+	level, err := userRepository.GetAuthLevel(ctx, tx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return level >= required, nil
+}
+
+// verifySecondFactor accepts a TOTP code that was not used before, or one of
+// the user's recovery codes, which is used up. After maxMFAAttempts wrong
+// codes every code is refused until mfaAttemptWindow has passed since the
+// first of them.
+func verifySecondFactor(ctx context.Context, tx *gorm.DB, userRepository repository.UserRepository, recoveryCodeRepository repository.RecoveryCodeRepository, cache caches.Cache, user *entity.User, code string) error {
+	// The attempt is counted before the code is checked, so concurrent
+	// requests cannot all slip in under the limit.
+	key := "mfa:attempts:" + user.ID.String()
+	attempts, err := cache.Incr(key, mfaAttemptWindow)
+	if err != nil {
+		return err
+	}
+	if attempts > maxMFAAttempts {
+		return echo.NewHTTPError(http.StatusTooManyRequests, "Too many invalid codes, try again later")
+	}
+
+	ok, err := checkSecondFactor(ctx, tx, userRepository, recoveryCodeRepository, user, code)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid authentication code")
+	}
+
+	return cache.Del(key)
+}
+
+func checkSecondFactor(ctx context.Context, tx *gorm.DB, userRepository repository.UserRepository, recoveryCodeRepository repository.RecoveryCodeRepository, user *entity.User, code string) (bool, error) {
+	code = strings.Join(strings.Fields(code), "")
+
+	if user.TOTPSecret != nil {
+		if counter, ok := totp.Validate(*user.TOTPSecret, code, time.Now()); ok {
+			return userRepository.UseTOTPCounter(ctx, tx, user.ID.String(), counter)
+		}
+	}
+
+	recoveryCode, err := recoveryCodeRepository.GetRecoveryCode(ctx, tx, user.ID.String(), hashRecoveryCode(code))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, recoveryCodeRepository.DeleteRecoveryCode(ctx, tx, recoveryCode)
+}
+
+// replaceRecoveryCodes discards the user's recovery codes and returns new
+// ones, of which only the hashes are stored.
+func replaceRecoveryCodes(ctx context.Context, tx *gorm.DB, recoveryCodeRepository repository.RecoveryCodeRepository, userID uuid.UUID) ([]string, error) {
+	if err := recoveryCodeRepository.DeleteRecoveryCodes(ctx, tx, userID.String()); err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	stored := make([]entity.RecoveryCode, recoveryCodeCount)
+	for i := range codes {
+		code, err := newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+
+		codes[i] = code
+		stored[i] = entity.RecoveryCode{UserID: userID, CodeHash: hashRecoveryCode(code)}
+	}
+
+	if err := recoveryCodeRepository.CreateRecoveryCodes(ctx, tx, stored); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// newRecoveryCode returns a random code of 50 bits such as "k3jd7-2mx4q".
+func newRecoveryCode() (string, error) {
+	secret := make([]byte, 7)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	code := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(secret))[:10]
+
+	return code[:5] + "-" + code[5:], nil
+}
+
+// hashRecoveryCode ignores case and dashes, so codes can be typed as shown or
+// not.
+func hashRecoveryCode(code string) string {
+	return tokens.HashToken(strings.ToLower(strings.ReplaceAll(code, "-", "")))
+}
@@ -0,0 +1,237 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/configs"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/service"
+	"github.com/sherwin-77/golang-todos/pkg/tokens"
+	"github.com/sherwin-77/golang-todos/pkg/totp"
+	mock_caches "github.com/sherwin-77/golang-todos/test/mock/pkg/caches"
+	mock_repository "github.com/sherwin-77/golang-todos/test/mock/repository"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type MFATestSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	userRepo     *mock_repository.MockUserRepository
+	recoveryRepo *mock_repository.MockRecoveryCodeRepository
+	settingRepo  *mock_repository.MockSettingRepository
+	cache        *mock_caches.MockCache
+	mfaService   service.MFAService
+}
+
+func (s *MFATestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.userRepo = mock_repository.NewMockUserRepository(s.ctrl)
+	s.recoveryRepo = mock_repository.NewMockRecoveryCodeRepository(s.ctrl)
+	s.settingRepo = mock_repository.NewMockSettingRepository(s.ctrl)
+	s.cache = mock_caches.NewMockCache(s.ctrl)
+	s.mfaService = service.NewMFAService(s.userRepo, s.recoveryRepo, s.settingRepo, s.cache, configs.AuthConfig{
+		MFAIssuer: "Golang Todos",
+	})
+}
+
+func TestMFAService(t *testing.T) {
+	suite.Run(t, new(MFATestSuite))
+}
+
+func (s *MFATestSuite) TestEnroll() {
+	s.Run("Already enabled", func() {
+		var e *echo.HTTPError
+		enabledAt := time.Now()
+		user := &entity.User{TOTPEnabledAt: &enabledAt}
+		user.ID = uuid.New()
+		s.userRepo.EXPECT().SingleTransaction().Return(nil)
+		s.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), user.ID.String()).Return(user, nil)
+		result, err := s.mfaService.Enroll(context.Background(), user.ID.String())
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusConflict, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Successfully enroll", func() {
+		user := &entity.User{Email: "alice@example.com"}
+		user.ID = uuid.New()
+		userID := user.ID.String()
+		s.userRepo.EXPECT().SingleTransaction().Return(nil)
+		s.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), userID).Return(user, nil)
+		s.userRepo.EXPECT().UpdateUser(gomock.Any(), gomock.Any(), user).Return(nil)
+		s.cache.EXPECT().Del("users:"+userID, "users:all").Return(nil)
+		result, err := s.mfaService.Enroll(context.Background(), userID)
+
+		s.Nil(err)
+		s.Equal(result.Secret, *user.TOTPSecret)
+		s.Nil(user.TOTPEnabledAt)
+		u, _ := url.Parse(result.URI)
+		s.Equal("/Golang Todos:alice@example.com", u.Path)
+		s.Equal(result.Secret, u.Query().Get("secret"))
+	})
+}
+
+func (s *MFATestSuite) TestConfirm() {
+	secret, _ := totp.GenerateSecret()
+	newUser := func() *entity.User {
+		user := &entity.User{TOTPSecret: &secret}
+		user.ID = uuid.New()
+		return user
+	}
+
+	s.Run("Not enrolled", func() {
+		var e *echo.HTTPError
+		user := newUser()
+		user.TOTPSecret = nil
+		s.userRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), user.ID.String()).Return(user, nil)
+			return f(&gorm.DB{})
+		})
+		result, err := s.mfaService.Confirm(context.Background(), dto.MFACodeRequest{Code: "123456"}, user.ID.String())
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusConflict, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Invalid code", func() {
+		var e *echo.HTTPError
+		user := newUser()
+		code, _ := totp.Code(secret, time.Now().Add(-time.Hour))
+		s.userRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), user.ID.String()).Return(user, nil)
+			return f(&gorm.DB{})
+		})
+		result, err := s.mfaService.Confirm(context.Background(), dto.MFACodeRequest{Code: code}, user.ID.String())
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusUnprocessableEntity, e.Code)
+		s.Nil(user.TOTPEnabledAt)
+		s.Nil(result)
+	})
+
+	s.Run("Successfully confirm", func() {
+		var stored []entity.RecoveryCode
+		user := newUser()
+		userID := user.ID.String()
+		code, _ := totp.Code(secret, time.Now())
+		s.userRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), userID).Return(user, nil)
+			s.userRepo.EXPECT().UpdateUser(gomock.Any(), gomock.Any(), user).Return(nil)
+			s.recoveryRepo.EXPECT().DeleteRecoveryCodes(gomock.Any(), gomock.Any(), userID).Return(nil)
+			s.recoveryRepo.EXPECT().CreateRecoveryCodes(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *gorm.DB, codes []entity.RecoveryCode) error {
+				stored = codes
+				return nil
+			})
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("users:"+userID, "users:all").Return(nil)
+		result, err := s.mfaService.Confirm(context.Background(), dto.MFACodeRequest{Code: code}, userID)
+
+		s.Nil(err)
+		s.NotNil(user.TOTPEnabledAt)
+		s.Len(result.RecoveryCodes, 10)
+		s.Len(stored, 10)
+		for i, recoveryCode := range result.RecoveryCodes {
+			s.Regexp(`^[a-z2-7]{5}-[a-z2-7]{5}$`, recoveryCode)
+			s.Equal(user.ID, stored[i].UserID)
+			s.Equal(tokens.HashToken(recoveryCode[:5]+recoveryCode[6:]), stored[i].CodeHash)
+		}
+	})
+}
+
+func (s *MFATestSuite) TestDisable() {
+	secret, _ := totp.GenerateSecret()
+	newUser := func() *entity.User {
+		enabledAt := time.Now()
+		user := &entity.User{TOTPSecret: &secret, TOTPEnabledAt: &enabledAt, TOTPLastCounter: 1}
+		user.ID = uuid.New()
+		return user
+	}
+
+	s.Run("Required by policy", func() {
+		var e *echo.HTTPError
+		user := newUser()
+		s.userRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), user.ID.String()).Return(user, nil)
+			s.settingRepo.EXPECT().GetSetting(gomock.Any(), gomock.Any(), entity.SettingMFARequiredLevel).Return(&entity.Setting{Value: "2"}, nil)
+			s.userRepo.EXPECT().GetAuthLevel(gomock.Any(), gomock.Any(), user.ID.String()).Return(2, nil)
+			return f(&gorm.DB{})
+		})
+		err := s.mfaService.Disable(context.Background(), dto.MFACodeRequest{Code: "123456"}, user.ID.String())
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusForbidden, e.Code)
+	})
+
+	s.Run("Successfully disable", func() {
+		user := newUser()
+		userID := user.ID.String()
+		code, _ := totp.Code(secret, time.Now())
+		s.userRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), userID).Return(user, nil)
+			s.settingRepo.EXPECT().GetSetting(gomock.Any(), gomock.Any(), entity.SettingMFARequiredLevel).Return(&entity.Setting{Value: "2"}, nil)
+			s.userRepo.EXPECT().GetAuthLevel(gomock.Any(), gomock.Any(), userID).Return(1, nil)
+			s.cache.EXPECT().Incr("mfa:attempts:"+userID, 15*time.Minute).Return(int64(1), nil)
+			s.userRepo.EXPECT().UseTOTPCounter(gomock.Any(), gomock.Any(), userID, gomock.Any()).Return(true, nil)
+			s.cache.EXPECT().Del("mfa:attempts:" + userID).Return(nil)
+			s.userRepo.EXPECT().UpdateUser(gomock.Any(), gomock.Any(), user).Return(nil)
+			s.recoveryRepo.EXPECT().DeleteRecoveryCodes(gomock.Any(), gomock.Any(), userID).Return(nil)
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("users:"+userID, "users:all").Return(nil)
+		err := s.mfaService.Disable(context.Background(), dto.MFACodeRequest{Code: code}, userID)
+
+		s.Nil(err)
+		s.Nil(user.TOTPSecret)
+		s.Nil(user.TOTPEnabledAt)
+		s.Zero(user.TOTPLastCounter)
+	})
+}
+
+func (s *MFATestSuite) TestRegenerateRecoveryCodes() {
+	s.Run("Not enabled", func() {
+		var e *echo.HTTPError
+		user := &entity.User{}
+		user.ID = uuid.New()
+		s.userRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), user.ID.String()).Return(user, nil)
+			return f(&gorm.DB{})
+		})
+		result, err := s.mfaService.RegenerateRecoveryCodes(context.Background(), dto.MFACodeRequest{Code: "123456"}, user.ID.String())
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusConflict, e.Code)
+		s.Nil(result)
+	})
+}
+
+func (s *MFATestSuite) TestPolicy() {
+	s.Run("No policy", func() {
+		s.settingRepo.EXPECT().SingleTransaction().Return(nil)
+		s.settingRepo.EXPECT().GetSetting(gomock.Any(), gomock.Any(), entity.SettingMFARequiredLevel).Return(nil, gorm.ErrRecordNotFound)
+		result, err := s.mfaService.GetPolicy(context.Background())
+
+		s.Nil(err)
+		s.Zero(result.RequiredLevel)
+	})
+
+	s.Run("Successfully update policy", func() {
+		s.settingRepo.EXPECT().SingleTransaction().Return(nil)
+		s.settingRepo.EXPECT().SaveSetting(gomock.Any(), gomock.Any(), &entity.Setting{Key: entity.SettingMFARequiredLevel, Value: "2"}).Return(nil)
+		result, err := s.mfaService.UpdatePolicy(context.Background(), dto.MFAPolicy{RequiredLevel: 2})
+
+		s.Nil(err)
+		s.Equal(2, result.RequiredLevel)
+	})
+}
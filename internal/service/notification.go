@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"github.com/sherwin-77/golang-todos/pkg/response"
+)
+
+type NotificationService interface {
+	GetNotifications(ctx context.Context, userID string, query dto.NotificationQuery) ([]entity.Notification, *response.Meta, error)
+	MarkNotificationsRead(ctx context.Context, request dto.MarkNotificationsReadRequest, userID string) error
+}
+
+type notificationService struct {
+	notificationRepository repository.NotificationRepository
+}
+
+func NewNotificationService(notificationRepository repository.NotificationRepository) NotificationService {
+	return &notificationService{notificationRepository}
+}
+
+// GetNotifications lists the user's notifications, newest first. They change
+// whenever another user acts, so they are not cached.
+func (s *notificationService) GetNotifications(ctx context.Context, userID string, query dto.NotificationQuery) ([]entity.Notification, *response.Meta, error) {
+	page, perPage := normalizePage(query.Page, query.PerPage)
+	db := s.notificationRepository.SingleTransaction()
+
+	total, err := s.notificationRepository.CountNotifications(ctx, db, userID, query.Unread)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	notifications, err := s.notificationRepository.GetNotificationsByUserID(ctx, db, userID, query.Unread, perPage, (page-1)*perPage)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return notifications, response.NewMeta(page, perPage, int(total)), nil
+}
+
+func (s *notificationService) MarkNotificationsRead(ctx context.Context, request dto.MarkNotificationsReadRequest, userID string) error {
+	db := s.notificationRepository.SingleTransaction()
+
+	return s.notificationRepository.MarkRead(ctx, db, userID, request.IDs, time.Now())
+}
@@ -0,0 +1,71 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/service"
+	mock_repository "github.com/sherwin-77/golang-todos/test/mock/repository"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type NotificationTestSuite struct {
+	suite.Suite
+	ctrl                *gomock.Controller
+	repo                *mock_repository.MockNotificationRepository
+	notificationService service.NotificationService
+}
+
+func (s *NotificationTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.repo = mock_repository.NewMockNotificationRepository(s.ctrl)
+	s.notificationService = service.NewNotificationService(s.repo)
+}
+
+func TestNotificationService(t *testing.T) {
+	suite.Run(t, new(NotificationTestSuite))
+}
+
+func (s *NotificationTestSuite) TestGetNotifications() {
+	userID := uuid.NewString()
+
+	s.Run("Failed to count notifications", func() {
+		errorTest := errors.New("count error")
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().CountNotifications(gomock.Any(), gomock.Any(), userID, true).Return(int64(0), errorTest)
+		result, meta, err := s.notificationService.GetNotifications(context.Background(), userID, dto.NotificationQuery{Unread: true})
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+		s.Nil(meta)
+	})
+
+	s.Run("Successfully get notifications", func() {
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().CountNotifications(gomock.Any(), gomock.Any(), userID, false).Return(int64(12), nil)
+		s.repo.EXPECT().GetNotificationsByUserID(gomock.Any(), gomock.Any(), userID, false, 10, 10).Return([]entity.Notification{{}, {}}, nil)
+		result, meta, err := s.notificationService.GetNotifications(context.Background(), userID, dto.NotificationQuery{Page: 2})
+
+		s.Nil(err)
+		s.Len(result, 2)
+		s.Equal(12, meta.Total)
+	})
+}
+
+func (s *NotificationTestSuite) TestMarkNotificationsRead() {
+	userID := uuid.NewString()
+	ids := []string{uuid.NewString()}
+
+	s.Run("Successfully mark notifications read", func() {
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().MarkRead(gomock.Any(), gomock.Any(), userID, ids, gomock.Any()).Return(nil)
+		err := s.notificationService.MarkNotificationsRead(context.Background(), dto.MarkNotificationsReadRequest{IDs: ids}, userID)
+
+		s.Nil(err)
+	})
+}
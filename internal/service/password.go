@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/configs"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"github.com/sherwin-77/golang-todos/pkg/caches"
+	"github.com/sherwin-77/golang-todos/pkg/notifier"
+	"github.com/sherwin-77/golang-todos/pkg/tokens"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+type PasswordService interface {
+	ForgotPassword(ctx context.Context, request dto.ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, request dto.ResetPasswordRequest) error
+}
+
+type passwordService struct {
+	userRepository         repository.UserRepository
+	userTokenRepository    repository.UserTokenRepository
+	refreshTokenRepository repository.RefreshTokenRepository
+	cache                  caches.Cache
+	mailer                 notifier.Notifier
+	config                 configs.AuthConfig
+}
+
+func NewPasswordService(
+	userRepository repository.UserRepository,
+	userTokenRepository repository.UserTokenRepository,
+	refreshTokenRepository repository.RefreshTokenRepository,
+	cache caches.Cache,
+	mailer notifier.Notifier,
+	config configs.AuthConfig,
+) PasswordService {
+	return &passwordService{userRepository, userTokenRepository, refreshTokenRepository, cache, mailer, config}
+}
+
+// ForgotPassword mails a reset link if the email belongs to a user, replacing
+// any link sent before. The result is the same for unknown emails, and the
+// mail is sent in the background, so neither the response nor its timing
+// tells whether an account exists.
+func (s *passwordService) ForgotPassword(ctx context.Context, request dto.ForgotPasswordRequest) error {
+	var user *entity.User
+	var token string
+
+	if err := s.userTokenRepository.WithTransaction(func(tx *gorm.DB) error {
+		var err error
+		user, err = s.userRepository.GetUserByEmail(ctx, tx, request.Email)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			user = nil
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		token, err = issueUserToken(ctx, tx, s.userTokenRepository, user.ID, entity.UserTokenPasswordReset, s.config.PasswordResetTTL)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if user == nil {
+		return nil
+	}
+
+	link := s.config.PasswordResetURL + "?token=" + url.QueryEscape(token)
+	deliver(s.mailer, notifier.Message{
+		Recipient: user.Email,
+		Subject:   "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nUse the link below to choose a new password. It expires in %s.\n\n%s\n\nIf you did not ask to reset your password, you can ignore this email.",
+			user.Username, s.config.PasswordResetTTL, link),
+		Metadata: map[string]string{"type": entity.UserTokenPasswordReset},
+	})
+
+	return nil
+}
+
+// ResetPassword sets a new password with a token from ForgotPassword. The
+// token works once, and every session of the user is ended.
+func (s *passwordService) ResetPassword(ctx context.Context, request dto.ResetPasswordRequest) error {
+	// Hash first, so an invalid token is answered as slowly as a valid one.
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	var userID string
+	if err := s.userTokenRepository.WithTransaction(func(tx *gorm.DB) error {
+		token, err := s.userTokenRepository.GetUserTokenByHash(ctx, tx, entity.UserTokenPasswordReset, tokens.HashToken(request.Token))
+		if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && !time.Now().Before(token.ExpiresAt)) {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid or expired reset token")
+		}
+		if err != nil {
+			return err
+		}
+
+		userID = token.UserID.String()
+		user, err := s.userRepository.GetUserByID(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+
+		user.Password = string(hashedPassword)
+		if err := s.userRepository.UpdateUser(ctx, tx, user); err != nil {
+			return err
+		}
+
+		if err := s.userTokenRepository.DeleteUserTokens(ctx, tx, userID, entity.UserTokenPasswordReset); err != nil {
+			return err
+		}
+
+		return revokeSessions(ctx, tx, s.userRepository, s.refreshTokenRepository, userID)
+	}); err != nil {
+		return err
+	}
+
+	return s.cache.Del("users:"+userID, "users:all")
+}
+
+// deliver sends the message in the background and logs failures. It is used
+// where waiting for the mail server would reveal whether an account exists.
+func deliver(mailer notifier.Notifier, message notifier.Message) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		if err := mailer.Notify(ctx, message); err != nil {
+			log.Printf("[mail] %s to %s: %v", message.Subject, message.Recipient, err)
+		}
+	}()
+}
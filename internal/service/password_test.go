@@ -0,0 +1,168 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/configs"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/service"
+	"github.com/sherwin-77/golang-todos/pkg/notifier"
+	"github.com/sherwin-77/golang-todos/pkg/tokens"
+	mock_caches "github.com/sherwin-77/golang-todos/test/mock/pkg/caches"
+	mock_notifier "github.com/sherwin-77/golang-todos/test/mock/pkg/notifier"
+	mock_repository "github.com/sherwin-77/golang-todos/test/mock/repository"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+type PasswordTestSuite struct {
+	suite.Suite
+	ctrl            *gomock.Controller
+	userRepo        *mock_repository.MockUserRepository
+	tokenRepo       *mock_repository.MockUserTokenRepository
+	refreshRepo     *mock_repository.MockRefreshTokenRepository
+	cache           *mock_caches.MockCache
+	mailer          *mock_notifier.MockNotifier
+	passwordService service.PasswordService
+}
+
+func (s *PasswordTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.userRepo = mock_repository.NewMockUserRepository(s.ctrl)
+	s.tokenRepo = mock_repository.NewMockUserTokenRepository(s.ctrl)
+	s.refreshRepo = mock_repository.NewMockRefreshTokenRepository(s.ctrl)
+	s.cache = mock_caches.NewMockCache(s.ctrl)
+	s.mailer = mock_notifier.NewMockNotifier(s.ctrl)
+	s.passwordService = service.NewPasswordService(s.userRepo, s.tokenRepo, s.refreshRepo, s.cache, s.mailer, configs.AuthConfig{
+		PasswordResetTTL: time.Hour,
+		PasswordResetURL: "https://todos.example.com/reset-password",
+	})
+}
+
+func TestPasswordService(t *testing.T) {
+	suite.Run(t, new(PasswordTestSuite))
+}
+
+func (s *PasswordTestSuite) TestForgotPassword() {
+	user := &entity.User{Username: "alice", Email: "alice@example.com"}
+	user.ID = uuid.New()
+
+	s.Run("Unknown email is not reported", func() {
+		s.tokenRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.userRepo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any(), "eve@example.com").Return(nil, gorm.ErrRecordNotFound)
+			return f(&gorm.DB{})
+		})
+		err := s.passwordService.ForgotPassword(context.Background(), dto.ForgotPasswordRequest{Email: "eve@example.com"})
+
+		s.Nil(err)
+	})
+
+	s.Run("Failed to get user", func() {
+		errorTest := errors.New("get user error")
+		s.tokenRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.userRepo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any(), user.Email).Return(nil, errorTest)
+			return f(&gorm.DB{})
+		})
+		err := s.passwordService.ForgotPassword(context.Background(), dto.ForgotPasswordRequest{Email: user.Email})
+
+		s.ErrorIs(err, errorTest)
+	})
+
+	s.Run("Successfully send reset link", func() {
+		var stored *entity.UserToken
+		sent := make(chan notifier.Message, 1)
+		s.tokenRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.userRepo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any(), user.Email).Return(user, nil)
+			s.tokenRepo.EXPECT().DeleteUserTokens(gomock.Any(), gomock.Any(), user.ID.String(), entity.UserTokenPasswordReset).Return(nil)
+			s.tokenRepo.EXPECT().CreateUserToken(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *gorm.DB, token *entity.UserToken) error {
+				stored = token
+				return nil
+			})
+			return f(&gorm.DB{})
+		})
+		s.mailer.EXPECT().Notify(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, message notifier.Message) error {
+			sent <- message
+			return nil
+		})
+		err := s.passwordService.ForgotPassword(context.Background(), dto.ForgotPasswordRequest{Email: user.Email})
+
+		s.Nil(err)
+		message := <-sent
+		s.Equal(user.Email, message.Recipient)
+		_, token, found := strings.Cut(message.Body, "https://todos.example.com/reset-password?token=")
+		s.True(found)
+		token = strings.Fields(token)[0]
+		s.Equal(tokens.HashToken(token), stored.TokenHash)
+		s.Equal(user.ID, stored.UserID)
+		s.WithinDuration(time.Now().Add(time.Hour), stored.ExpiresAt, time.Minute)
+	})
+}
+
+func (s *PasswordTestSuite) TestResetPassword() {
+	user := &entity.User{Username: "alice", Password: "old"}
+	user.ID = uuid.New()
+	userID := user.ID.String()
+	request := dto.ResetPasswordRequest{Token: "reset", Password: "new-secret"}
+	newToken := func() *entity.UserToken {
+		return &entity.UserToken{
+			UserID:    user.ID,
+			Purpose:   entity.UserTokenPasswordReset,
+			TokenHash: tokens.HashToken("reset"),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
+
+	s.Run("Unknown token", func() {
+		var e *echo.HTTPError
+		s.tokenRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.tokenRepo.EXPECT().GetUserTokenByHash(gomock.Any(), gomock.Any(), entity.UserTokenPasswordReset, tokens.HashToken("reset")).Return(nil, gorm.ErrRecordNotFound)
+			return f(&gorm.DB{})
+		})
+		err := s.passwordService.ResetPassword(context.Background(), request)
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusBadRequest, e.Code)
+	})
+
+	s.Run("Expired token", func() {
+		var e *echo.HTTPError
+		token := newToken()
+		token.ExpiresAt = time.Now().Add(-time.Minute)
+		s.tokenRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.tokenRepo.EXPECT().GetUserTokenByHash(gomock.Any(), gomock.Any(), entity.UserTokenPasswordReset, token.TokenHash).Return(token, nil)
+			return f(&gorm.DB{})
+		})
+		err := s.passwordService.ResetPassword(context.Background(), request)
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusBadRequest, e.Code)
+	})
+
+	s.Run("Successfully reset password", func() {
+		token := newToken()
+		s.tokenRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.tokenRepo.EXPECT().GetUserTokenByHash(gomock.Any(), gomock.Any(), entity.UserTokenPasswordReset, token.TokenHash).Return(token, nil)
+			s.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), userID).Return(user, nil)
+			s.userRepo.EXPECT().UpdateUser(gomock.Any(), gomock.Any(), user).Return(nil)
+			s.tokenRepo.EXPECT().DeleteUserTokens(gomock.Any(), gomock.Any(), userID, entity.UserTokenPasswordReset).Return(nil)
+			s.refreshRepo.EXPECT().RevokeUserRefreshTokens(gomock.Any(), gomock.Any(), userID, gomock.Any()).Return(nil)
+			s.userRepo.EXPECT().ChangeTokenVersion(gomock.Any(), gomock.Any(), userID).Return(nil)
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("users:"+userID, "users:all").Return(nil)
+		err := s.passwordService.ResetPassword(context.Background(), request)
+
+		s.Nil(err)
+		s.Nil(bcrypt.CompareHashAndPassword([]byte(user.Password), []byte("new-secret")))
+	})
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/pkg/patch"
+)
+
+// requireValue rejects a merge patch that would clear a field which must always have a value.
+func requireValue[T comparable](field patch.Field[T], name string) error {
+	var zero T
+	if field.Set && (field.Null || field.Value == zero) {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, name+" cannot be empty")
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"gorm.io/gorm"
+)
+
+// minPositionGap is the smallest gap between neighbours that can still be split
+// without running into float precision; below it the siblings are rebalanced.
+const minPositionGap = 1e-6
+
+// positionBetween picks a position after `after` and before `before`. A missing
+// neighbour means there is no sibling on that side, so the todo moves to that
+// end of the list.
+func positionBetween(after *entity.Todo, before *entity.Todo) (float64, bool) {
+	switch {
+	case after == nil:
+		return before.Position - 1, true
+	case before == nil:
+		return after.Position + 1, true
+	case before.Position-after.Position < minPositionGap:
+		return 0, false
+	default:
+		return (after.Position + before.Position) / 2, true
+	}
+}
+
+func (s *todoService) MoveTodo(ctx context.Context, request dto.MoveTodoRequest, userID string) (*entity.Todo, error) {
+	if request.AfterID == request.ID || request.BeforeID == request.ID {
+		return nil, echo.NewHTTPError(http.StatusUnprocessableEntity, "Todo cannot be its own neighbour")
+	}
+
+	var todo *entity.Todo
+	var rebalancedIDs []string
+	var audience []string
+
+	if err := s.todoRepository.WithTransaction(func(tx *gorm.DB) error {
+		var err error
+		todo, err = s.todoRepository.GetTodoByID(ctx, tx, request.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkTodoAccess(ctx, tx, s.projectRepository, todo, userID, entity.ProjectEditor); err != nil {
+			return err
+		}
+
+		audience, err = todoAudience(ctx, tx, s.projectRepository, todo)
+		if err != nil {
+			return err
+		}
+
+		after, before, err := s.getNeighbours(ctx, tx, todo, request, userID)
+		if err != nil {
+			return err
+		}
+
+		position, ok := positionBetween(after, before)
+		if !ok {
+			rebalancedIDs, err = s.todoRepository.RebalancePositions(ctx, tx, todo)
+			if err != nil {
+				return err
+			}
+
+			// Top-level siblings share a creator, not a project, so each may
+			// appear in different lists.
+			rebalanced, err := s.todoRepository.GetTodosByIDs(ctx, tx, rebalancedIDs)
+			if err != nil {
+				return err
+			}
+			for i := range rebalanced {
+				siblingAudience, err := todoAudience(ctx, tx, s.projectRepository, &rebalanced[i])
+				if err != nil {
+					return err
+				}
+				audience = append(audience, siblingAudience...)
+			}
+
+			after, before, err = s.getNeighbours(ctx, tx, todo, request, userID)
+			if err != nil {
+				return err
+			}
+
+			if position, ok = positionBetween(after, before); !ok {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, "AfterID must come before BeforeID")
+			}
+		}
+
+		todo.Position = position
+
+		return s.todoRepository.UpdateTodo(ctx, tx, todo)
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.Del("todos:" + todo.ID.String()); err != nil {
+		return nil, err
+	}
+
+	for _, id := range rebalancedIDs {
+		if err := s.cache.Del("todos:" + id); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := delUserKeys(s.cache, "todos:all:", audience); err != nil {
+		return nil, err
+	}
+
+	return todo, nil
+}
+
+// getNeighbours loads the requested neighbours, which must be siblings of todo.
+// When only one is given the other is the sibling right next to it, so the todo
+// lands between the two instead of tying with one of them.
+func (s *todoService) getNeighbours(ctx context.Context, tx *gorm.DB, todo *entity.Todo, request dto.MoveTodoRequest, userID string) (*entity.Todo, *entity.Todo, error) {
+	var neighbours [2]*entity.Todo
+
+	for i, id := range []string{request.AfterID, request.BeforeID} {
+		if id == "" {
+			continue
+		}
+
+		neighbour, err := s.todoRepository.GetTodoByID(ctx, tx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if err := checkTodoAccess(ctx, tx, s.projectRepository, neighbour, userID, entity.ProjectViewer); err != nil {
+			return nil, nil, err
+		}
+
+		if !sameID(neighbour.ParentID, todo.ParentID) {
+			return nil, nil, echo.NewHTTPError(http.StatusUnprocessableEntity, "Neighbours must share the todo's parent")
+		}
+
+		// Top-level todos are ordered per creator, subtasks per parent.
+		if todo.ParentID == nil && neighbour.UserID != todo.UserID {
+			return nil, nil, echo.NewHTTPError(http.StatusUnprocessableEntity, "Neighbours must belong to the todo's list")
+		}
+
+		neighbours[i] = neighbour
+	}
+
+	var err error
+	switch {
+	case neighbours[1] == nil:
+		neighbours[1], err = s.todoRepository.GetSiblingAfter(ctx, tx, todo, neighbours[0])
+	case neighbours[0] == nil:
+		neighbours[0], err = s.todoRepository.GetSiblingBefore(ctx, tx, todo, neighbours[1])
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return neighbours[0], neighbours[1], nil
+}
@@ -0,0 +1,172 @@
+package service_test
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func (s *TodoTestSuite) TestMoveTodo() {
+	userID := uuid.MustParse(uuid.NewString())
+	newTodo := func(position float64) *entity.Todo {
+		todo := &entity.Todo{UserID: userID, Position: position}
+		todo.ID = uuid.New()
+		return todo
+	}
+
+	s.Run("Todo is its own neighbour", func() {
+		var e *echo.HTTPError
+		todoID := uuid.NewString()
+		result, err := s.todoService.MoveTodo(context.Background(), dto.MoveTodoRequest{ID: todoID, AfterID: todoID}, userID.String())
+
+		s.ErrorAs(err, &e)
+		s.Nil(result)
+	})
+
+	s.Run("Neighbour is not a sibling", func() {
+		var e *echo.HTTPError
+		todo := newTodo(1)
+		subtask := newTodo(2)
+		subtask.ParentID = &todo.ID
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todo.ID.String()).Return(todo, nil)
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), subtask.ID.String()).Return(subtask, nil)
+
+			return f(&gorm.DB{})
+		})
+		result, err := s.todoService.MoveTodo(context.Background(), dto.MoveTodoRequest{ID: todo.ID.String(), AfterID: subtask.ID.String()}, userID.String())
+
+		s.ErrorAs(err, &e)
+		s.Nil(result)
+	})
+
+	s.Run("Successfully move todo between neighbours", func() {
+		todo, after, before := newTodo(5), newTodo(1), newTodo(2)
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todo.ID.String()).Return(todo, nil)
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), after.ID.String()).Return(after, nil)
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), before.ID.String()).Return(before, nil)
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), todo).Return(nil)
+
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("todos:" + todo.ID.String()).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + userID.String()).Return(nil)
+		result, err := s.todoService.MoveTodo(context.Background(), dto.MoveTodoRequest{
+			ID:       todo.ID.String(),
+			AfterID:  after.ID.String(),
+			BeforeID: before.ID.String(),
+		}, userID.String())
+
+		s.Nil(err)
+		s.Equal(1.5, result.Position)
+	})
+
+	s.Run("Successfully move todo to the top", func() {
+		todo, before := newTodo(5), newTodo(1)
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todo.ID.String()).Return(todo, nil)
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), before.ID.String()).Return(before, nil)
+			s.repo.EXPECT().GetSiblingBefore(gomock.Any(), gomock.Any(), todo, before).Return(nil, nil)
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), todo).Return(nil)
+
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("todos:" + todo.ID.String()).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + userID.String()).Return(nil)
+		result, err := s.todoService.MoveTodo(context.Background(), dto.MoveTodoRequest{ID: todo.ID.String(), BeforeID: before.ID.String()}, userID.String())
+
+		s.Nil(err)
+		s.Equal(float64(0), result.Position)
+	})
+
+	s.Run("Successfully move todo before a todo in the middle", func() {
+		todo, previous, before := newTodo(5), newTodo(1), newTodo(2)
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todo.ID.String()).Return(todo, nil)
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), before.ID.String()).Return(before, nil)
+			s.repo.EXPECT().GetSiblingBefore(gomock.Any(), gomock.Any(), todo, before).Return(previous, nil)
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), todo).Return(nil)
+
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("todos:" + todo.ID.String()).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + userID.String()).Return(nil)
+		result, err := s.todoService.MoveTodo(context.Background(), dto.MoveTodoRequest{ID: todo.ID.String(), BeforeID: before.ID.String()}, userID.String())
+
+		s.Nil(err)
+		s.Equal(1.5, result.Position)
+	})
+
+	s.Run("Successfully move todo after a todo in the middle", func() {
+		todo, after, next := newTodo(0), newTodo(1), newTodo(2)
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todo.ID.String()).Return(todo, nil)
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), after.ID.String()).Return(after, nil)
+			s.repo.EXPECT().GetSiblingAfter(gomock.Any(), gomock.Any(), todo, after).Return(next, nil)
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), todo).Return(nil)
+
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("todos:" + todo.ID.String()).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + userID.String()).Return(nil)
+		result, err := s.todoService.MoveTodo(context.Background(), dto.MoveTodoRequest{ID: todo.ID.String(), AfterID: after.ID.String()}, userID.String())
+
+		s.Nil(err)
+		s.Equal(1.5, result.Position)
+	})
+
+	s.Run("Successfully rebalance when the gap runs out", func() {
+		todo, after, before := newTodo(5), newTodo(1), newTodo(1)
+		rebalancedAfter, rebalancedBefore := *after, *before
+		rebalancedAfter.Position, rebalancedBefore.Position = 1, 2
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todo.ID.String()).Return(todo, nil)
+			gomock.InOrder(
+				s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), after.ID.String()).Return(after, nil),
+				s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), after.ID.String()).Return(&rebalancedAfter, nil),
+			)
+			gomock.InOrder(
+				s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), before.ID.String()).Return(before, nil),
+				s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), before.ID.String()).Return(&rebalancedBefore, nil),
+			)
+			s.repo.EXPECT().RebalancePositions(gomock.Any(), gomock.Any(), todo).Return([]string{after.ID.String()}, nil)
+			s.repo.EXPECT().GetTodosByIDs(gomock.Any(), gomock.Any(), []string{after.ID.String()}).Return([]entity.Todo{rebalancedAfter}, nil)
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), todo).Return(nil)
+
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("todos:" + todo.ID.String()).Return(nil)
+		s.cache.EXPECT().Del("todos:" + after.ID.String()).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + userID.String()).Return(nil)
+		result, err := s.todoService.MoveTodo(context.Background(), dto.MoveTodoRequest{
+			ID:       todo.ID.String(),
+			AfterID:  after.ID.String(),
+			BeforeID: before.ID.String(),
+		}, userID.String())
+
+		s.Nil(err)
+		s.Equal(1.5, result.Position)
+	})
+}
+
+func (s *TodoTestSuite) TestCreateTodoWithPriority() {
+	userID := uuid.NewString()
+
+	s.Run("Successfully create todo with priority", func() {
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().NextPosition(gomock.Any(), gomock.Any(), gomock.Any()).Return(float64(3), nil)
+		s.repo.EXPECT().CreateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + userID).Return(nil)
+		result, err := s.todoService.CreateTodo(context.Background(), dto.TodoRequest{Title: "Todo", Priority: "urgent"}, userID)
+
+		s.Nil(err)
+		s.Equal(entity.PriorityUrgent, result.Priority)
+		s.Equal(float64(3), result.Position)
+	})
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"github.com/sherwin-77/golang-todos/pkg/caches"
+	"gorm.io/gorm"
+)
+
+type ProjectService interface {
+	GetProjectsByUserID(ctx context.Context, userID string, query dto.ProjectQuery) ([]entity.Project, error)
+	GetProjectByID(ctx context.Context, id string, userID string) (*entity.Project, error)
+	CreateProject(ctx context.Context, request dto.ProjectRequest, userID string) (*entity.Project, error)
+	UpdateProject(ctx context.Context, request dto.UpdateProjectRequest, userID string) (*entity.Project, error)
+	ArchiveProject(ctx context.Context, id string, userID string) (*entity.Project, error)
+	UnarchiveProject(ctx context.Context, id string, userID string) (*entity.Project, error)
+	DeleteProject(ctx context.Context, request dto.DeleteProjectRequest, userID string) error
+	GetMembers(ctx context.Context, projectID string, userID string) ([]entity.ProjectMember, error)
+	GetInvitations(ctx context.Context, userID string) ([]entity.ProjectMember, error)
+	InviteMember(ctx context.Context, request dto.InviteMemberRequest, userID string) (*entity.ProjectMember, error)
+	AcceptInvitation(ctx context.Context, projectID string, userID string) (*entity.ProjectMember, error)
+	RevokeMember(ctx context.Context, projectID string, memberID string, userID string) error
+}
+
+type projectService struct {
+	projectRepository repository.ProjectRepository
+	userRepository    repository.UserRepository
+	cache             caches.Cache
+}
+
+func NewProjectService(projectRepository repository.ProjectRepository, userRepository repository.UserRepository, cache caches.Cache) ProjectService {
+	return &projectService{projectRepository, userRepository, cache}
+}
+
+func (s *projectService) GetProjectsByUserID(ctx context.Context, userID string, query dto.ProjectQuery) ([]entity.Project, error) {
+	projectKey := "projects:all:" + userID
+	var projects []entity.Project
+	cachedData := s.cache.Get(projectKey)
+	if cachedData != "" {
+		if err := json.Unmarshal([]byte(cachedData), &projects); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		db := s.projectRepository.SingleTransaction()
+		projects, err = s.projectRepository.GetProjectsByUserID(ctx, db, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		data, _ := json.Marshal(projects)
+
+		if err := s.cache.Set(projectKey, string(data), 5*time.Minute); err != nil {
+			return nil, err
+		}
+	}
+
+	filtered := make([]entity.Project, 0, len(projects))
+	for _, project := range projects {
+		if (project.ArchivedAt != nil) == query.Archived {
+			filtered = append(filtered, project)
+		}
+	}
+
+	return filtered, nil
+}
+
+func (s *projectService) GetProjectByID(ctx context.Context, id string, userID string) (*entity.Project, error) {
+	projectKey := "projects:" + id
+	project := &entity.Project{}
+	cachedData := s.cache.Get(projectKey)
+	if cachedData != "" {
+		if err := json.Unmarshal([]byte(cachedData), project); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		db := s.projectRepository.SingleTransaction()
+		project, err = s.projectRepository.GetProjectByID(ctx, db, id)
+		if err != nil {
+			return nil, err
+		}
+
+		data, _ := json.Marshal(project)
+
+		if err := s.cache.Set(projectKey, string(data), 5*time.Minute); err != nil {
+			return nil, err
+		}
+	}
+
+	// Only shared projects need the membership lookup.
+	if project.UserID.String() != userID {
+		role, err := projectRole(ctx, s.projectRepository.SingleTransaction(), s.projectRepository, project, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		if role == "" {
+			return nil, echo.NewHTTPError(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		}
+	}
+
+	return project, nil
+}
+
+func (s *projectService) CreateProject(ctx context.Context, request dto.ProjectRequest, userID string) (*entity.Project, error) {
+	db := s.projectRepository.SingleTransaction()
+
+	project := &entity.Project{
+		Name:        request.Name,
+		Description: request.Description,
+		UserID:      uuid.MustParse(userID),
+	}
+
+	if err := s.projectRepository.CreateProject(ctx, db, project); err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.Del("projects:all:" + userID); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (s *projectService) UpdateProject(ctx context.Context, request dto.UpdateProjectRequest, userID string) (*entity.Project, error) {
+	return s.modifyProject(ctx, request.ID, userID, func(project *entity.Project) {
+		project.Name = request.Name
+		project.Description = request.Description
+	})
+}
+
+func (s *projectService) ArchiveProject(ctx context.Context, id string, userID string) (*entity.Project, error) {
+	return s.modifyProject(ctx, id, userID, func(project *entity.Project) {
+		if project.ArchivedAt == nil {
+			now := time.Now()
+			project.ArchivedAt = &now
+		}
+	})
+}
+
+func (s *projectService) UnarchiveProject(ctx context.Context, id string, userID string) (*entity.Project, error) {
+	return s.modifyProject(ctx, id, userID, func(project *entity.Project) {
+		project.ArchivedAt = nil
+	})
+}
+
+func (s *projectService) modifyProject(ctx context.Context, id string, userID string, apply func(project *entity.Project)) (*entity.Project, error) {
+	db := s.projectRepository.SingleTransaction()
+
+	project, err := s.projectRepository.GetProjectByID(ctx, db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if project.UserID.String() != userID {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Project not found")
+	}
+
+	apply(project)
+
+	if err := s.projectRepository.UpdateProject(ctx, db, project); err != nil {
+		return nil, err
+	}
+
+	memberIDs, err := s.projectRepository.GetMemberUserIDs(ctx, db, project.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.Del("projects:" + project.ID.String()); err != nil {
+		return nil, err
+	}
+
+	if err := delUserKeys(s.cache, "projects:all:", memberIDs); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// DeleteProject removes a project and applies the requested policy to its todos:
+// "inbox" (the default) detaches them, "delete" removes them with the project.
+func (s *projectService) DeleteProject(ctx context.Context, request dto.DeleteProjectRequest, userID string) error {
+	var todoIDs []string
+	var memberIDs []string
+
+	if err := s.projectRepository.WithTransaction(func(tx *gorm.DB) error {
+		project, err := s.projectRepository.GetProjectByID(ctx, tx, request.ID)
+		if err != nil {
+			return err
+		}
+
+		if project.UserID.String() != userID {
+			return echo.NewHTTPError(http.StatusNotFound, "Project not found")
+		}
+
+		todoIDs, err = s.projectRepository.GetProjectTodoIDs(ctx, tx, project)
+		if err != nil {
+			return err
+		}
+
+		// Members lose the project and its todos too, so collect them before the memberships cascade away.
+		memberIDs, err = s.projectRepository.GetMemberUserIDs(ctx, tx, request.ID)
+		if err != nil {
+			return err
+		}
+
+		if request.Todos == "delete" {
+			if err := s.projectRepository.DeleteProjectTodos(ctx, tx, project); err != nil {
+				return err
+			}
+		} else {
+			if err := s.projectRepository.MoveTodosToInbox(ctx, tx, project); err != nil {
+				return err
+			}
+		}
+
+		return s.projectRepository.DeleteProject(ctx, tx, project)
+	}); err != nil {
+		return err
+	}
+
+	if err := s.cache.Del("projects:" + request.ID); err != nil {
+		return err
+	}
+
+	if err := delUserKeys(s.cache, "projects:all:", memberIDs); err != nil {
+		return err
+	}
+
+	for _, todoID := range todoIDs {
+		if err := s.cache.Del("todos:" + todoID); err != nil {
+			return err
+		}
+	}
+
+	return delUserKeys(s.cache, "todos:all:", memberIDs)
+}
@@ -0,0 +1,296 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/service"
+	mock_caches "github.com/sherwin-77/golang-todos/test/mock/pkg/caches"
+	mock_repository "github.com/sherwin-77/golang-todos/test/mock/repository"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type ProjectTestSuite struct {
+	suite.Suite
+	ctrl           *gomock.Controller
+	repo           *mock_repository.MockProjectRepository
+	userRepo       *mock_repository.MockUserRepository
+	cache          *mock_caches.MockCache
+	projectService service.ProjectService
+}
+
+func (s *ProjectTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.repo = mock_repository.NewMockProjectRepository(s.ctrl)
+	s.userRepo = mock_repository.NewMockUserRepository(s.ctrl)
+	s.cache = mock_caches.NewMockCache(s.ctrl)
+	s.projectService = service.NewProjectService(s.repo, s.userRepo, s.cache)
+}
+
+func TestProjectService(t *testing.T) {
+	suite.Run(t, new(ProjectTestSuite))
+}
+
+func (s *ProjectTestSuite) TestGetProjectsByUserID() {
+	userID := uuid.NewString()
+	keyFindAll := "projects:all:" + userID
+	archivedAt := time.Now()
+	active := entity.Project{Name: "Active"}
+	archived := entity.Project{Name: "Archived", ArchivedAt: &archivedAt}
+	projects := []entity.Project{active, archived}
+	marshalledData, _ := json.Marshal(projects)
+
+	s.Run("Failed unmarshal", func() {
+		s.cache.EXPECT().Get(keyFindAll).Return("invalid")
+		result, err := s.projectService.GetProjectsByUserID(context.Background(), userID, dto.ProjectQuery{})
+
+		s.Error(err)
+		s.Nil(result)
+	})
+
+	s.Run("Failed to get projects", func() {
+		errorTest := errors.New("get projects error")
+		s.cache.EXPECT().Get(keyFindAll).Return("")
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetProjectsByUserID(gomock.Any(), gomock.Any(), userID).Return(nil, errorTest)
+		result, err := s.projectService.GetProjectsByUserID(context.Background(), userID, dto.ProjectQuery{})
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+	})
+
+	s.Run("Successfully get active projects", func() {
+		s.cache.EXPECT().Get(keyFindAll).Return("")
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetProjectsByUserID(gomock.Any(), gomock.Any(), userID).Return(projects, nil)
+		s.cache.EXPECT().Set(keyFindAll, string(marshalledData), gomock.Any()).Return(nil)
+		result, err := s.projectService.GetProjectsByUserID(context.Background(), userID, dto.ProjectQuery{})
+
+		s.Nil(err)
+		s.Equal([]entity.Project{active}, result)
+	})
+
+	s.Run("Successfully get archived projects from cache", func() {
+		s.cache.EXPECT().Get(keyFindAll).Return(string(marshalledData))
+		result, err := s.projectService.GetProjectsByUserID(context.Background(), userID, dto.ProjectQuery{Archived: true})
+
+		s.Nil(err)
+		s.Len(result, 1)
+		s.Equal("Archived", result[0].Name)
+	})
+}
+
+func (s *ProjectTestSuite) TestGetProjectByID() {
+	projectID := uuid.NewString()
+	userID := uuid.NewString()
+	keyFindProject := "projects:" + projectID
+	project := &entity.Project{UserID: uuid.MustParse(userID)}
+	project.ID = uuid.MustParse(projectID)
+	marshalledData, _ := json.Marshal(project)
+
+	s.Run("Failed to get project", func() {
+		errorTest := errors.New("get project error")
+		s.cache.EXPECT().Get(keyFindProject).Return("")
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), projectID).Return(nil, errorTest)
+		result, err := s.projectService.GetProjectByID(context.Background(), projectID, userID)
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+	})
+
+	s.Run("User ID mismatch", func() {
+		var e *echo.HTTPError
+		s.cache.EXPECT().Get(keyFindProject).Return(string(marshalledData))
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetMember(gomock.Any(), gomock.Any(), projectID, gomock.Any()).Return(nil, gorm.ErrRecordNotFound)
+		result, err := s.projectService.GetProjectByID(context.Background(), projectID, uuid.NewString())
+
+		s.ErrorAs(err, &e)
+		s.Nil(result)
+	})
+
+	s.Run("Successfully get project", func() {
+		s.cache.EXPECT().Get(keyFindProject).Return("")
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), projectID).Return(project, nil)
+		s.cache.EXPECT().Set(keyFindProject, string(marshalledData), gomock.Any()).Return(nil)
+		result, err := s.projectService.GetProjectByID(context.Background(), projectID, userID)
+
+		s.Nil(err)
+		s.Equal(project, result)
+	})
+}
+
+func (s *ProjectTestSuite) TestCreateProject() {
+	userID := uuid.NewString()
+	keyFindAll := "projects:all:" + userID
+
+	s.Run("Failed to create project", func() {
+		errorTest := errors.New("create project error")
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().CreateProject(gomock.Any(), gomock.Any(), gomock.Any()).Return(errorTest)
+		result, err := s.projectService.CreateProject(context.Background(), dto.ProjectRequest{Name: "Work"}, userID)
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+	})
+
+	s.Run("Successfully create project", func() {
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().CreateProject(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		s.cache.EXPECT().Del(keyFindAll).Return(nil)
+		result, err := s.projectService.CreateProject(context.Background(), dto.ProjectRequest{Name: "Work"}, userID)
+
+		s.Nil(err)
+		s.Equal("Work", result.Name)
+		s.Equal(userID, result.UserID.String())
+	})
+}
+
+func (s *ProjectTestSuite) TestUpdateProject() {
+	projectID := uuid.NewString()
+	userID := uuid.NewString()
+	project := &entity.Project{UserID: uuid.MustParse(userID)}
+	project.ID = uuid.MustParse(projectID)
+
+	s.Run("User ID mismatch", func() {
+		var e *echo.HTTPError
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), projectID).Return(project, nil)
+		result, err := s.projectService.UpdateProject(context.Background(), dto.UpdateProjectRequest{ID: projectID}, uuid.NewString())
+
+		s.ErrorAs(err, &e)
+		s.Nil(result)
+	})
+
+	s.Run("Successfully update project", func() {
+		projectRet := *project
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), projectID).Return(&projectRet, nil)
+		s.repo.EXPECT().UpdateProject(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		s.repo.EXPECT().GetMemberUserIDs(gomock.Any(), gomock.Any(), projectID).Return([]string{userID}, nil)
+		s.cache.EXPECT().Del("projects:" + projectID).Return(nil)
+		s.cache.EXPECT().Del("projects:all:" + userID).Return(nil)
+		result, err := s.projectService.UpdateProject(context.Background(), dto.UpdateProjectRequest{
+			ProjectRequest: dto.ProjectRequest{Name: "Renamed"},
+			ID:             projectID,
+		}, userID)
+
+		s.Nil(err)
+		s.Equal("Renamed", result.Name)
+	})
+}
+
+func (s *ProjectTestSuite) TestArchiveProject() {
+	projectID := uuid.NewString()
+	userID := uuid.NewString()
+	project := &entity.Project{UserID: uuid.MustParse(userID)}
+	project.ID = uuid.MustParse(projectID)
+
+	s.Run("Failed to update project", func() {
+		errorTest := errors.New("update project error")
+		projectRet := *project
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), projectID).Return(&projectRet, nil)
+		s.repo.EXPECT().UpdateProject(gomock.Any(), gomock.Any(), gomock.Any()).Return(errorTest)
+		result, err := s.projectService.ArchiveProject(context.Background(), projectID, userID)
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+	})
+
+	s.Run("Successfully archive project", func() {
+		projectRet := *project
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), projectID).Return(&projectRet, nil)
+		s.repo.EXPECT().UpdateProject(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		s.repo.EXPECT().GetMemberUserIDs(gomock.Any(), gomock.Any(), projectID).Return([]string{userID}, nil)
+		s.cache.EXPECT().Del("projects:" + projectID).Return(nil)
+		s.cache.EXPECT().Del("projects:all:" + userID).Return(nil)
+		result, err := s.projectService.ArchiveProject(context.Background(), projectID, userID)
+
+		s.Nil(err)
+		s.NotNil(result.ArchivedAt)
+	})
+
+	s.Run("Successfully unarchive project", func() {
+		archivedAt := time.Now()
+		projectRet := *project
+		projectRet.ArchivedAt = &archivedAt
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), projectID).Return(&projectRet, nil)
+		s.repo.EXPECT().UpdateProject(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		s.repo.EXPECT().GetMemberUserIDs(gomock.Any(), gomock.Any(), projectID).Return([]string{userID}, nil)
+		s.cache.EXPECT().Del("projects:" + projectID).Return(nil)
+		s.cache.EXPECT().Del("projects:all:" + userID).Return(nil)
+		result, err := s.projectService.UnarchiveProject(context.Background(), projectID, userID)
+
+		s.Nil(err)
+		s.Nil(result.ArchivedAt)
+	})
+}
+
+func (s *ProjectTestSuite) TestDeleteProject() {
+	projectID := uuid.NewString()
+	userID := uuid.NewString()
+	todoID := uuid.NewString()
+	project := &entity.Project{UserID: uuid.MustParse(userID)}
+	project.ID = uuid.MustParse(projectID)
+
+	s.Run("User ID mismatch", func() {
+		var e *echo.HTTPError
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), projectID).Return(project, nil)
+			return f(&gorm.DB{})
+		})
+		err := s.projectService.DeleteProject(context.Background(), dto.DeleteProjectRequest{ID: projectID}, uuid.NewString())
+
+		s.ErrorAs(err, &e)
+	})
+
+	s.Run("Successfully delete project and move todos to inbox", func() {
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), projectID).Return(project, nil)
+			s.repo.EXPECT().GetProjectTodoIDs(gomock.Any(), gomock.Any(), project).Return([]string{todoID}, nil)
+			s.repo.EXPECT().GetMemberUserIDs(gomock.Any(), gomock.Any(), projectID).Return([]string{userID}, nil)
+			s.repo.EXPECT().MoveTodosToInbox(gomock.Any(), gomock.Any(), project).Return(nil)
+			s.repo.EXPECT().DeleteProject(gomock.Any(), gomock.Any(), project).Return(nil)
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("projects:" + projectID).Return(nil)
+		s.cache.EXPECT().Del("projects:all:" + userID).Return(nil)
+		s.cache.EXPECT().Del("todos:" + todoID).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + userID).Return(nil)
+		err := s.projectService.DeleteProject(context.Background(), dto.DeleteProjectRequest{ID: projectID}, userID)
+
+		s.Nil(err)
+	})
+
+	s.Run("Successfully delete project with todos", func() {
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), projectID).Return(project, nil)
+			s.repo.EXPECT().GetProjectTodoIDs(gomock.Any(), gomock.Any(), project).Return([]string{todoID}, nil)
+			s.repo.EXPECT().GetMemberUserIDs(gomock.Any(), gomock.Any(), projectID).Return([]string{userID}, nil)
+			s.repo.EXPECT().DeleteProjectTodos(gomock.Any(), gomock.Any(), project).Return(nil)
+			s.repo.EXPECT().DeleteProject(gomock.Any(), gomock.Any(), project).Return(nil)
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("projects:" + projectID).Return(nil)
+		s.cache.EXPECT().Del("projects:all:" + userID).Return(nil)
+		s.cache.EXPECT().Del("todos:" + todoID).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + userID).Return(nil)
+		err := s.projectService.DeleteProject(context.Background(), dto.DeleteProjectRequest{ID: projectID, Todos: "delete"}, userID)
+
+		s.Nil(err)
+	})
+}
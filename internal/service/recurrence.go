@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/pkg/rrule"
+	"gorm.io/gorm"
+)
+
+// normalizeRecurrence validates an RRULE and returns it in canonical form.
+// Occurrences are scheduled from the due date, so one is required.
+func normalizeRecurrence(value string, dueAt *time.Time) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	if dueAt == nil {
+		return "", echo.NewHTTPError(http.StatusUnprocessableEntity, "Recurring todos require DueAt")
+	}
+
+	rule, err := rrule.Parse(value)
+	if err != nil {
+		return "", echo.NewHTTPError(http.StatusUnprocessableEntity, "Invalid recurrence: "+err.Error())
+	}
+
+	return rule.String(), nil
+}
+
+// nextOccurrence builds the todo that follows a completed recurring todo. It
+// returns nil when the series has ended or the next occurrence already exists,
+// e.g. because the todo was completed, reopened and completed again.
+func (s *todoService) nextOccurrence(ctx context.Context, tx *gorm.DB, todo *entity.Todo) (*entity.Todo, error) {
+	if todo.Recurrence == "" || todo.RecurrenceID == nil || todo.DueAt == nil {
+		return nil, nil
+	}
+
+	rule, err := rrule.Parse(todo.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	later, err := s.todoRepository.CountTodosFiltered(ctx, tx, "recurrence_id = ? AND occurrence > ?", todo.RecurrenceID, todo.Occurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	if later > 0 {
+		return nil, nil
+	}
+
+	location, err := s.userLocation(ctx, todo.UserID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	dueAt, ok := rule.Next(todo.DueAt.In(location), todo.Occurrence)
+	if !ok {
+		return nil, nil
+	}
+
+	next := &entity.Todo{
+		Title:        todo.Title,
+		Description:  todo.Description,
+		DueAt:        &dueAt,
+		ProjectID:    todo.ProjectID,
+		Recurrence:   todo.Recurrence,
+		RecurrenceID: todo.RecurrenceID,
+		Occurrence:   todo.Occurrence + 1,
+		UserID:       todo.UserID,
+		Tags:         todo.Tags,
+	}
+
+	if todo.RemindAt != nil {
+		remindAt := dueAt.Add(todo.RemindAt.Sub(*todo.DueAt))
+		next.RemindAt = &remindAt
+	}
+
+	return next, nil
+}
+
+func (s *todoService) GetOccurrences(ctx context.Context, todoID string, userID string) ([]entity.Todo, error) {
+	db := s.todoRepository.SingleTransaction()
+
+	todo, err := s.todoRepository.GetTodoByID(ctx, db, todoID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkTodoAccess(ctx, db, s.projectRepository, todo, userID, entity.ProjectViewer); err != nil {
+		return nil, err
+	}
+
+	if todo.RecurrenceID == nil {
+		return []entity.Todo{*todo}, nil
+	}
+
+	return s.todoRepository.GetOccurrences(ctx, db, todo.RecurrenceID.String())
+}
@@ -0,0 +1,175 @@
+package service_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/pkg/patch"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func (s *TodoTestSuite) TestCreateRecurringTodo() {
+	userID := uuid.NewString()
+	dueAt := time.Date(2024, 12, 2, 9, 0, 0, 0, time.UTC)
+
+	s.Run("Recurrence without due date", func() {
+		var e *echo.HTTPError
+		result, err := s.todoService.CreateTodo(context.Background(), dto.TodoRequest{Recurrence: "FREQ=WEEKLY"}, userID)
+
+		s.ErrorAs(err, &e)
+		s.Nil(result)
+	})
+
+	s.Run("Invalid recurrence", func() {
+		var e *echo.HTTPError
+		result, err := s.todoService.CreateTodo(context.Background(), dto.TodoRequest{DueAt: &dueAt, Recurrence: "FREQ=HOURLY"}, userID)
+
+		s.ErrorAs(err, &e)
+		s.Nil(result)
+	})
+
+	s.Run("Successfully create recurring todo", func() {
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().NextPosition(gomock.Any(), gomock.Any(), gomock.Any()).Return(float64(1), nil)
+		s.repo.EXPECT().CreateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + userID).Return(nil)
+		result, err := s.todoService.CreateTodo(context.Background(), dto.TodoRequest{DueAt: &dueAt, Recurrence: "RRULE:freq=weekly;byday=mo"}, userID)
+
+		s.Nil(err)
+		s.Equal("FREQ=WEEKLY;BYDAY=MO", result.Recurrence)
+		s.Equal(result.ID, *result.RecurrenceID)
+	})
+}
+
+func (s *TodoTestSuite) TestCompleteRecurringTodo() {
+	userID := uuid.NewString()
+	todoID := uuid.NewString()
+	seriesID := uuid.MustParse(todoID)
+	dueAt := time.Date(2024, 12, 2, 9, 0, 0, 0, time.UTC)
+	remindAt := dueAt.Add(-time.Hour)
+	todo := entity.Todo{
+		DueAt:        &dueAt,
+		RemindAt:     &remindAt,
+		Recurrence:   "FREQ=WEEKLY;COUNT=2",
+		RecurrenceID: &seriesID,
+		Occurrence:   1,
+		UserID:       uuid.MustParse(userID),
+	}
+	todo.ID = uuid.MustParse(todoID)
+	request := dto.UpdateTodoRequest{
+		ID:          todoID,
+		IsCompleted: patch.Value(true),
+		DueAt:       patch.Value(dueAt),
+		RemindAt:    patch.Value(remindAt),
+		Recurrence:  patch.Value("FREQ=WEEKLY;COUNT=2"),
+	}
+
+	s.Run("Successfully create next occurrence", func() {
+		todoRet := todo
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(&todoRet, nil)
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			s.repo.EXPECT().CountTodosFiltered(gomock.Any(), gomock.Any(), "recurrence_id = ? AND occurrence > ?", &seriesID, 1).Return(int64(0), nil)
+			s.userRepo.EXPECT().SingleTransaction().Return(nil)
+			s.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), userID).Return(&entity.User{Timezone: "UTC"}, nil)
+			s.repo.EXPECT().CreateTodo(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *gorm.DB, next *entity.Todo) error {
+				s.Equal(2, next.Occurrence)
+				s.Equal(seriesID, *next.RecurrenceID)
+				s.True(next.DueAt.Equal(dueAt.AddDate(0, 0, 7)))
+				s.True(next.RemindAt.Equal(remindAt.AddDate(0, 0, 7)))
+				s.False(next.IsCompleted)
+				return nil
+			})
+
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("todos:" + todoID).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + userID).Return(nil)
+		result, err := s.todoService.UpdateTodo(context.Background(), request, userID)
+
+		s.Nil(err)
+		s.True(result.IsCompleted)
+	})
+
+	s.Run("Next occurrence already exists", func() {
+		todoRet := todo
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(&todoRet, nil)
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			s.repo.EXPECT().CountTodosFiltered(gomock.Any(), gomock.Any(), "recurrence_id = ? AND occurrence > ?", &seriesID, 1).Return(int64(1), nil)
+
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("todos:" + todoID).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + userID).Return(nil)
+		result, err := s.todoService.UpdateTodo(context.Background(), request, userID)
+
+		s.Nil(err)
+		s.True(result.IsCompleted)
+	})
+
+	s.Run("Series has ended", func() {
+		todoRet := todo
+		todoRet.Occurrence = 2
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(&todoRet, nil)
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			s.repo.EXPECT().CountTodosFiltered(gomock.Any(), gomock.Any(), "recurrence_id = ? AND occurrence > ?", &seriesID, 2).Return(int64(0), nil)
+			s.userRepo.EXPECT().SingleTransaction().Return(nil)
+			s.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), userID).Return(&entity.User{Timezone: "UTC"}, nil)
+
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("todos:" + todoID).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + userID).Return(nil)
+		result, err := s.todoService.UpdateTodo(context.Background(), request, userID)
+
+		s.Nil(err)
+		s.True(result.IsCompleted)
+	})
+}
+
+func (s *TodoTestSuite) TestGetOccurrences() {
+	userID := uuid.NewString()
+	todoID := uuid.NewString()
+	seriesID := uuid.New()
+	todo := &entity.Todo{UserID: uuid.MustParse(userID), RecurrenceID: &seriesID}
+	todo.ID = uuid.MustParse(todoID)
+
+	s.Run("User ID mismatch", func() {
+		var e *echo.HTTPError
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+		result, err := s.todoService.GetOccurrences(context.Background(), todoID, uuid.NewString())
+
+		s.ErrorAs(err, &e)
+		s.Nil(result)
+	})
+
+	s.Run("Todo without recurrence", func() {
+		single := *todo
+		single.RecurrenceID = nil
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(&single, nil)
+		result, err := s.todoService.GetOccurrences(context.Background(), todoID, userID)
+
+		s.Nil(err)
+		s.Equal([]entity.Todo{single}, result)
+	})
+
+	s.Run("Successfully get occurrences", func() {
+		occurrences := []entity.Todo{{Occurrence: 1, IsCompleted: true}, {Occurrence: 2}}
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+		s.repo.EXPECT().GetOccurrences(gomock.Any(), gomock.Any(), seriesID.String()).Return(occurrences, nil)
+		result, err := s.todoService.GetOccurrences(context.Background(), todoID, userID)
+
+		s.Nil(err)
+		s.Equal(occurrences, result)
+	})
+}
@@ -16,7 +16,7 @@ type RoleService interface {
 	GetRoleByID(ctx context.Context, id string) (*entity.Role, error)
 	CreateRole(ctx context.Context, request dto.RoleRequest) (*entity.Role, error)
 	UpdateRole(ctx context.Context, request dto.UpdateRoleRequest) (*entity.Role, error)
-	DeleteRole(ctx context.Context, id string) error
+	DeleteRole(ctx context.Context, id string, version int) error
 }
 
 type roleService struct {
@@ -99,14 +99,25 @@ func (s *roleService) CreateRole(ctx context.Context, request dto.RoleRequest) (
 }
 
 func (s *roleService) UpdateRole(ctx context.Context, request dto.UpdateRoleRequest) (*entity.Role, error) {
+	if err := requireValue(request.Name, "Name"); err != nil {
+		return nil, err
+	}
+	if err := requireValue(request.AuthLevel, "AuthLevel"); err != nil {
+		return nil, err
+	}
+
 	db := s.roleRepository.SingleTransaction()
 	role, err := s.roleRepository.GetRoleByID(ctx, db, request.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	role.Name = request.Name
-	role.AuthLevel = request.AuthLevel
+	if err := checkVersion(request.Version, role.Version); err != nil {
+		return nil, err
+	}
+
+	request.Name.Apply(&role.Name)
+	request.AuthLevel.Apply(&role.AuthLevel)
 
 	if err := s.roleRepository.UpdateRole(ctx, db, role); err != nil {
 		return nil, err
@@ -123,13 +134,17 @@ func (s *roleService) UpdateRole(ctx context.Context, request dto.UpdateRoleRequ
 	return role, err
 }
 
-func (s *roleService) DeleteRole(ctx context.Context, id string) error {
+func (s *roleService) DeleteRole(ctx context.Context, id string, version int) error {
 	db := s.roleRepository.SingleTransaction()
 	role, err := s.roleRepository.GetRoleByID(ctx, db, id)
 	if err != nil {
 		return err
 	}
 
+	if err := checkVersion(version, role.Version); err != nil {
+		return err
+	}
+
 	if err := s.roleRepository.DeleteRole(ctx, db, role); err != nil {
 		return err
 	}
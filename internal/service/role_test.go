@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
 	"github.com/sherwin-77/golang-todos/internal/entity"
 	"github.com/sherwin-77/golang-todos/internal/http/dto"
 	"testing"
 
 	"github.com/sherwin-77/golang-todos/internal/service"
+	"github.com/sherwin-77/golang-todos/pkg/patch"
 	mock_caches "github.com/sherwin-77/golang-todos/test/mock/pkg/caches"
 	mock_repository "github.com/sherwin-77/golang-todos/test/mock/repository"
 	"github.com/stretchr/testify/suite"
@@ -208,6 +210,17 @@ func (s *RoleTestSuite) TestUpdateRole() {
 		s.Nil(result)
 	})
 
+	s.Run("Clear name", func() {
+		var e *echo.HTTPError
+		result, err := s.roleService.UpdateRole(context.Background(), dto.UpdateRoleRequest{
+			ID:   roleID,
+			Name: patch.Null[string](),
+		})
+
+		s.ErrorAs(err, &e)
+		s.Nil(result)
+	})
+
 	s.Run("Failed to update role", func() {
 		roleRet := *emptyRole
 		errorTest := errors.New("update role error")
@@ -216,11 +229,9 @@ func (s *RoleTestSuite) TestUpdateRole() {
 		s.repo.EXPECT().GetRoleByID(gomock.Any(), gomock.Any(), roleID).Return(&roleRet, nil)
 		s.repo.EXPECT().UpdateRole(gomock.Any(), gomock.Any(), gomock.Any()).Return(errorTest)
 		result, err := s.roleService.UpdateRole(context.Background(), dto.UpdateRoleRequest{
-			ID: roleID,
-			RoleRequest: dto.RoleRequest{
-				Name:      "Admin",
-				AuthLevel: 3,
-			},
+			ID:        roleID,
+			Name:      patch.Value("Admin"),
+			AuthLevel: patch.Value(3),
 		})
 
 		s.ErrorIs(err, errorTest)
@@ -236,11 +247,9 @@ func (s *RoleTestSuite) TestUpdateRole() {
 		s.repo.EXPECT().UpdateRole(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		s.cache.EXPECT().Del("roles:" + roleID).Return(errorTest)
 		result, err := s.roleService.UpdateRole(context.Background(), dto.UpdateRoleRequest{
-			ID: roleID,
-			RoleRequest: dto.RoleRequest{
-				Name:      "Admin",
-				AuthLevel: 3,
-			},
+			ID:        roleID,
+			Name:      patch.Value("Admin"),
+			AuthLevel: patch.Value(3),
 		})
 
 		s.ErrorIs(err, errorTest)
@@ -257,11 +266,9 @@ func (s *RoleTestSuite) TestUpdateRole() {
 		s.cache.EXPECT().Del("roles:" + roleID).Return(nil)
 		s.cache.EXPECT().Del("roles:all").Return(errorTest)
 		result, err := s.roleService.UpdateRole(context.Background(), dto.UpdateRoleRequest{
-			ID: roleID,
-			RoleRequest: dto.RoleRequest{
-				Name:      "Admin",
-				AuthLevel: 3,
-			},
+			ID:        roleID,
+			Name:      patch.Value("Admin"),
+			AuthLevel: patch.Value(3),
 		})
 
 		s.ErrorIs(err, errorTest)
@@ -277,15 +284,34 @@ func (s *RoleTestSuite) TestUpdateRole() {
 		s.cache.EXPECT().Del("roles:" + roleID).Return(nil)
 		s.cache.EXPECT().Del("roles:all").Return(nil)
 		result, err := s.roleService.UpdateRole(context.Background(), dto.UpdateRoleRequest{
-			ID: roleID,
-			RoleRequest: dto.RoleRequest{
-				Name:      "Admin",
-				AuthLevel: 3,
-			}})
+			ID:        roleID,
+			Name:      patch.Value("Admin"),
+			AuthLevel: patch.Value(3),
+		})
 
 		s.Nil(err)
 		s.NotEqual(emptyRole, result)
 	})
+
+	s.Run("Omitted fields are left untouched", func() {
+		roleRet := *emptyRole
+		roleRet.Name = "Admin"
+		roleRet.AuthLevel = 3
+
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetRoleByID(gomock.Any(), gomock.Any(), roleID).Return(&roleRet, nil)
+		s.repo.EXPECT().UpdateRole(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		s.cache.EXPECT().Del("roles:" + roleID).Return(nil)
+		s.cache.EXPECT().Del("roles:all").Return(nil)
+		result, err := s.roleService.UpdateRole(context.Background(), dto.UpdateRoleRequest{
+			ID:        roleID,
+			AuthLevel: patch.Value(2),
+		})
+
+		s.Nil(err)
+		s.Equal("Admin", result.Name)
+		s.Equal(2, result.AuthLevel)
+	})
 }
 
 func (s *RoleTestSuite) TestDeleteRole() {
@@ -297,7 +323,7 @@ func (s *RoleTestSuite) TestDeleteRole() {
 		errorTest := errors.New("get role error")
 		s.repo.EXPECT().SingleTransaction().Return(nil)
 		s.repo.EXPECT().GetRoleByID(gomock.Any(), gomock.Any(), roleID).Return(nil, errorTest)
-		err := s.roleService.DeleteRole(context.Background(), roleID)
+		err := s.roleService.DeleteRole(context.Background(), roleID, 0)
 
 		s.ErrorIs(err, errorTest)
 	})
@@ -307,7 +333,7 @@ func (s *RoleTestSuite) TestDeleteRole() {
 		s.repo.EXPECT().SingleTransaction().Return(nil)
 		s.repo.EXPECT().GetRoleByID(gomock.Any(), gomock.Any(), roleID).Return(emptyRole, nil)
 		s.repo.EXPECT().DeleteRole(gomock.Any(), gomock.Any(), emptyRole).Return(errorTest)
-		err := s.roleService.DeleteRole(context.Background(), roleID)
+		err := s.roleService.DeleteRole(context.Background(), roleID, 0)
 
 		s.ErrorIs(err, errorTest)
 	})
@@ -318,7 +344,7 @@ func (s *RoleTestSuite) TestDeleteRole() {
 		s.repo.EXPECT().GetRoleByID(gomock.Any(), gomock.Any(), roleID).Return(emptyRole, nil)
 		s.repo.EXPECT().DeleteRole(gomock.Any(), gomock.Any(), emptyRole).Return(nil)
 		s.cache.EXPECT().Del("roles:" + roleID).Return(errorTest)
-		err := s.roleService.DeleteRole(context.Background(), roleID)
+		err := s.roleService.DeleteRole(context.Background(), roleID, 0)
 
 		s.ErrorIs(err, errorTest)
 	})
@@ -330,7 +356,7 @@ func (s *RoleTestSuite) TestDeleteRole() {
 		s.repo.EXPECT().DeleteRole(gomock.Any(), gomock.Any(), emptyRole).Return(nil)
 		s.cache.EXPECT().Del("roles:" + roleID).Return(nil)
 		s.cache.EXPECT().Del("roles:all").Return(errorTest)
-		err := s.roleService.DeleteRole(context.Background(), roleID)
+		err := s.roleService.DeleteRole(context.Background(), roleID, 0)
 
 		s.ErrorIs(err, errorTest)
 	})
@@ -341,7 +367,7 @@ func (s *RoleTestSuite) TestDeleteRole() {
 		s.repo.EXPECT().DeleteRole(gomock.Any(), gomock.Any(), emptyRole).Return(nil)
 		s.cache.EXPECT().Del("roles:" + roleID).Return(nil)
 		s.cache.EXPECT().Del("roles:all").Return(nil)
-		err := s.roleService.DeleteRole(context.Background(), roleID)
+		err := s.roleService.DeleteRole(context.Background(), roleID, 0)
 
 		s.Nil(err)
 	})
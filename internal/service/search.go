@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/pkg/response"
+)
+
+// buildSearchQuery turns free text into a to_tsquery expression that requires
+// every word, each as a prefix, e.g. "buy mil" becomes "buy:* & mil:*".
+// Anything but letters and digits is dropped so user input cannot break the
+// tsquery syntax.
+func buildSearchQuery(text string) string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for i, word := range words {
+		words[i] = strings.ToLower(word) + ":*"
+	}
+
+	return strings.Join(words, " & ")
+}
+
+func (s *todoService) SearchTodos(ctx context.Context, userID string, query dto.TodoSearchQuery) ([]entity.Todo, *response.Meta, error) {
+	tsQuery := buildSearchQuery(query.Q)
+	if tsQuery == "" {
+		return nil, nil, echo.NewHTTPError(http.StatusUnprocessableEntity, "Search query must contain a word")
+	}
+
+	page, perPage := normalizePage(query.Page, query.PerPage)
+	db := s.todoRepository.SingleTransaction()
+
+	total, err := s.todoRepository.CountSearchTodos(ctx, db, userID, tsQuery)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	matches, err := s.todoRepository.SearchTodos(ctx, db, userID, tsQuery, perPage, (page-1)*perPage)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	todos := []entity.Todo{}
+	if len(matches) > 0 {
+		ids := make([]string, len(matches))
+		for i, match := range matches {
+			ids[i] = match.TodoID.String()
+		}
+
+		found, err := s.todoRepository.GetTodosByIDs(ctx, db, ids)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		byID := make(map[string]entity.Todo, len(found))
+		for _, todo := range found {
+			byID[todo.ID.String()] = todo
+		}
+
+		// Keep the ranking order; a todo deleted in between is simply left out.
+		for i := range matches {
+			if todo, ok := byID[ids[i]]; ok {
+				todo.Match = &matches[i]
+				todos = append(todos, todo)
+			}
+		}
+
+		if err := s.attachProgress(ctx, db, todos); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return todos, response.NewMeta(page, perPage, int(total)), nil
+}
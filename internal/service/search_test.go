@@ -0,0 +1,76 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"go.uber.org/mock/gomock"
+)
+
+func (s *TodoTestSuite) TestSearchTodos() {
+	userID := uuid.NewString()
+
+	s.Run("Query without words", func() {
+		var e *echo.HTTPError
+		result, meta, err := s.todoService.SearchTodos(context.Background(), userID, dto.TodoSearchQuery{Q: "&|!:*"})
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusUnprocessableEntity, e.Code)
+		s.Nil(result)
+		s.Nil(meta)
+	})
+
+	s.Run("Failed to search", func() {
+		errorTest := errors.New("search error")
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().CountSearchTodos(gomock.Any(), gomock.Any(), userID, "buy:* & milk:*").Return(int64(1), nil)
+		s.repo.EXPECT().SearchTodos(gomock.Any(), gomock.Any(), userID, "buy:* & milk:*", 10, 0).Return(nil, errorTest)
+		result, meta, err := s.todoService.SearchTodos(context.Background(), userID, dto.TodoSearchQuery{Q: "Buy  milk!"})
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+		s.Nil(meta)
+	})
+
+	s.Run("No match", func() {
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().CountSearchTodos(gomock.Any(), gomock.Any(), userID, "groceries:*").Return(int64(0), nil)
+		s.repo.EXPECT().SearchTodos(gomock.Any(), gomock.Any(), userID, "groceries:*", 10, 0).Return(nil, nil)
+		result, meta, err := s.todoService.SearchTodos(context.Background(), userID, dto.TodoSearchQuery{Q: "groceries"})
+
+		s.Nil(err)
+		s.Empty(result)
+		s.Equal(0, meta.Total)
+	})
+
+	s.Run("Results keep the ranking order", func() {
+		first := entity.Todo{Title: "Buy milk"}
+		first.ID = uuid.New()
+		second := entity.Todo{Title: "Milk the cow"}
+		second.ID = uuid.New()
+		matches := []entity.TodoMatch{
+			{TodoID: first.ID, Rank: 0.9, Title: "Buy <mark>milk</mark>"},
+			{TodoID: second.ID, Rank: 0.5, Title: "<mark>Milk</mark> the cow"},
+		}
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().CountSearchTodos(gomock.Any(), gomock.Any(), userID, "milk:*").Return(int64(12), nil)
+		s.repo.EXPECT().SearchTodos(gomock.Any(), gomock.Any(), userID, "milk:*", 10, 10).Return(matches, nil)
+		s.repo.EXPECT().GetTodosByIDs(gomock.Any(), gomock.Any(), []string{first.ID.String(), second.ID.String()}).
+			Return([]entity.Todo{second, first}, nil)
+		s.repo.EXPECT().GetSubtaskProgress(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+		result, meta, err := s.todoService.SearchTodos(context.Background(), userID, dto.TodoSearchQuery{Q: "milk", Page: 2})
+
+		s.Nil(err)
+		s.Len(result, 2)
+		s.Equal(first.ID, result[0].ID)
+		s.Equal(&matches[0], result[0].Match)
+		s.Equal(second.ID, result[1].ID)
+		s.Equal(2, meta.Page)
+		s.Equal(12, meta.Total)
+	})
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"gorm.io/gorm"
+)
+
+// attachProgress fills the subtask progress of each todo that has subtasks.
+func (s *todoService) attachProgress(ctx context.Context, tx *gorm.DB, todos []entity.Todo) error {
+	if len(todos) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(todos))
+	for i := range todos {
+		ids[i] = todos[i].ID.String()
+	}
+
+	progress, err := s.todoRepository.GetSubtaskProgress(ctx, tx, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range todos {
+		if p, ok := progress[todos[i].ID.String()]; ok {
+			todos[i].Progress = &p
+		}
+	}
+
+	return nil
+}
+
+func (s *todoService) GetSubtasks(ctx context.Context, todoID string, userID string) ([]entity.Todo, error) {
+	db := s.todoRepository.SingleTransaction()
+
+	todo, err := s.todoRepository.GetTodoByID(ctx, db, todoID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkTodoAccess(ctx, db, s.projectRepository, todo, userID, entity.ProjectViewer); err != nil {
+		return nil, err
+	}
+
+	return s.todoRepository.GetSubtasks(ctx, db, todoID)
+}
+
+func (s *todoService) CreateSubtask(ctx context.Context, request dto.SubtaskRequest, userID string) (*entity.Todo, error) {
+	var subtask *entity.Todo
+	var audience []string
+
+	if err := s.todoRepository.WithTransaction(func(tx *gorm.DB) error {
+		parent, err := s.todoRepository.GetTodoByID(ctx, tx, request.TodoID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkTodoAccess(ctx, tx, s.projectRepository, parent, userID, entity.ProjectEditor); err != nil {
+			return err
+		}
+
+		if parent.ParentID != nil {
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "Subtasks cannot have subtasks")
+		}
+
+		subtask = &entity.Todo{
+			Title:       request.Title,
+			Description: request.Description,
+			IsCompleted: request.IsCompleted,
+			ProjectID:   parent.ProjectID,
+			ParentID:    &parent.ID,
+			UserID:      uuid.MustParse(userID),
+		}
+
+		subtask.Position, err = s.todoRepository.NextPosition(ctx, tx, subtask)
+		if err != nil {
+			return err
+		}
+
+		if err := s.todoRepository.CreateTodo(ctx, tx, subtask); err != nil {
+			return err
+		}
+
+		audience, err = todoAudience(ctx, tx, s.projectRepository, parent)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.Del("todos:" + request.TodoID); err != nil {
+		return nil, err
+	}
+
+	if err := delUserKeys(s.cache, "todos:all:", audience); err != nil {
+		return nil, err
+	}
+
+	return subtask, nil
+}
+
+func (s *todoService) UpdateSubtask(ctx context.Context, request dto.UpdateSubtaskRequest, userID string) (*entity.Todo, error) {
+	db := s.todoRepository.SingleTransaction()
+
+	subtask, err := s.todoRepository.GetTodoByID(ctx, db, request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if subtask.ParentID == nil || subtask.ParentID.String() != request.TodoID {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Subtask not found")
+	}
+
+	if err := checkTodoAccess(ctx, db, s.projectRepository, subtask, userID, entity.ProjectEditor); err != nil {
+		return nil, err
+	}
+
+	audience, err := todoAudience(ctx, db, s.projectRepository, subtask)
+	if err != nil {
+		return nil, err
+	}
+
+	subtask.Title = request.Title
+	subtask.Description = request.Description
+	subtask.IsCompleted = request.IsCompleted
+	if request.Position != nil {
+		subtask.Position = *request.Position
+	}
+
+	if err := s.todoRepository.UpdateTodo(ctx, db, subtask); err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.Del("todos:" + subtask.ID.String()); err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.Del("todos:" + request.TodoID); err != nil {
+		return nil, err
+	}
+
+	if err := delUserKeys(s.cache, "todos:all:", audience); err != nil {
+		return nil, err
+	}
+
+	return subtask, nil
+}
+
+func (s *todoService) DeleteSubtask(ctx context.Context, todoID string, subtaskID string, userID string) error {
+	db := s.todoRepository.SingleTransaction()
+
+	subtask, err := s.todoRepository.GetTodoByID(ctx, db, subtaskID)
+	if err != nil {
+		return err
+	}
+
+	if subtask.ParentID == nil || subtask.ParentID.String() != todoID {
+		return echo.NewHTTPError(http.StatusNotFound, "Subtask not found")
+	}
+
+	if err := checkTodoAccess(ctx, db, s.projectRepository, subtask, userID, entity.ProjectEditor); err != nil {
+		return err
+	}
+
+	audience, err := todoAudience(ctx, db, s.projectRepository, subtask)
+	if err != nil {
+		return err
+	}
+
+	if err := s.todoRepository.DeleteTodo(ctx, db, subtask); err != nil {
+		return err
+	}
+
+	if err := s.cache.Del("todos:" + subtaskID); err != nil {
+		return err
+	}
+
+	if err := s.cache.Del("todos:" + todoID); err != nil {
+		return err
+	}
+
+	return delUserKeys(s.cache, "todos:all:", audience)
+}
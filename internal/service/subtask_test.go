@@ -0,0 +1,184 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func (s *TodoTestSuite) TestGetSubtasks() {
+	userID := uuid.NewString()
+	todoID := uuid.NewString()
+	todo := &entity.Todo{UserID: uuid.MustParse(userID)}
+	todo.ID = uuid.MustParse(todoID)
+
+	s.Run("User ID mismatch", func() {
+		var e *echo.HTTPError
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+		result, err := s.todoService.GetSubtasks(context.Background(), todoID, uuid.NewString())
+
+		s.ErrorAs(err, &e)
+		s.Nil(result)
+	})
+
+	s.Run("Successfully get subtasks", func() {
+		subtasks := []entity.Todo{{Title: "Step 1"}, {Title: "Step 2"}}
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+		s.repo.EXPECT().GetSubtasks(gomock.Any(), gomock.Any(), todoID).Return(subtasks, nil)
+		result, err := s.todoService.GetSubtasks(context.Background(), todoID, userID)
+
+		s.Nil(err)
+		s.Equal(subtasks, result)
+	})
+}
+
+func (s *TodoTestSuite) TestCreateSubtask() {
+	userID := uuid.NewString()
+	todoID := uuid.NewString()
+	parent := &entity.Todo{UserID: uuid.MustParse(userID)}
+	parent.ID = uuid.MustParse(todoID)
+	request := dto.SubtaskRequest{TodoID: todoID, Title: "Step"}
+
+	s.Run("User ID mismatch", func() {
+		var e *echo.HTTPError
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(parent, nil)
+
+			return f(&gorm.DB{})
+		})
+		result, err := s.todoService.CreateSubtask(context.Background(), request, uuid.NewString())
+
+		s.ErrorAs(err, &e)
+		s.Nil(result)
+	})
+
+	s.Run("Nested subtask", func() {
+		var e *echo.HTTPError
+		grandparentID := uuid.New()
+		nested := *parent
+		nested.ParentID = &grandparentID
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(&nested, nil)
+
+			return f(&gorm.DB{})
+		})
+		result, err := s.todoService.CreateSubtask(context.Background(), request, userID)
+
+		s.ErrorAs(err, &e)
+		s.Nil(result)
+	})
+
+	s.Run("Failed to create subtask", func() {
+		errorTest := errors.New("create subtask error")
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(parent, nil)
+			s.repo.EXPECT().NextPosition(gomock.Any(), gomock.Any(), gomock.Any()).Return(float64(2), nil)
+			s.repo.EXPECT().CreateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(errorTest)
+
+			return f(&gorm.DB{})
+		})
+		result, err := s.todoService.CreateSubtask(context.Background(), request, userID)
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+	})
+
+	s.Run("Successfully create subtask", func() {
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(parent, nil)
+			s.repo.EXPECT().NextPosition(gomock.Any(), gomock.Any(), gomock.Any()).Return(float64(2), nil)
+			s.repo.EXPECT().CreateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("todos:" + todoID).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + userID).Return(nil)
+		result, err := s.todoService.CreateSubtask(context.Background(), request, userID)
+
+		s.Nil(err)
+		s.Equal(parent.ID, *result.ParentID)
+		s.Equal(float64(2), result.Position)
+	})
+}
+
+func (s *TodoTestSuite) TestUpdateSubtask() {
+	userID := uuid.NewString()
+	todoID := uuid.NewString()
+	subtaskID := uuid.NewString()
+	parentID := uuid.MustParse(todoID)
+	subtask := &entity.Todo{UserID: uuid.MustParse(userID), ParentID: &parentID}
+	subtask.ID = uuid.MustParse(subtaskID)
+
+	s.Run("Subtask of another todo", func() {
+		var e *echo.HTTPError
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), subtaskID).Return(subtask, nil)
+		result, err := s.todoService.UpdateSubtask(context.Background(), dto.UpdateSubtaskRequest{
+			TodoID: uuid.NewString(),
+			ID:     subtaskID,
+		}, userID)
+
+		s.ErrorAs(err, &e)
+		s.Nil(result)
+	})
+
+	s.Run("Successfully update subtask", func() {
+		position := 3.5
+		subtaskRet := *subtask
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), subtaskID).Return(&subtaskRet, nil)
+		s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		s.cache.EXPECT().Del("todos:" + subtaskID).Return(nil)
+		s.cache.EXPECT().Del("todos:" + todoID).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + userID).Return(nil)
+		result, err := s.todoService.UpdateSubtask(context.Background(), dto.UpdateSubtaskRequest{
+			TodoID:      todoID,
+			ID:          subtaskID,
+			Title:       "Step",
+			IsCompleted: true,
+			Position:    &position,
+		}, userID)
+
+		s.Nil(err)
+		s.True(result.IsCompleted)
+		s.Equal(3.5, result.Position)
+	})
+}
+
+func (s *TodoTestSuite) TestDeleteSubtask() {
+	userID := uuid.NewString()
+	todoID := uuid.NewString()
+	subtaskID := uuid.NewString()
+	parentID := uuid.MustParse(todoID)
+	subtask := &entity.Todo{UserID: uuid.MustParse(userID), ParentID: &parentID}
+	subtask.ID = uuid.MustParse(subtaskID)
+
+	s.Run("User ID mismatch", func() {
+		var e *echo.HTTPError
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), subtaskID).Return(subtask, nil)
+		err := s.todoService.DeleteSubtask(context.Background(), todoID, subtaskID, uuid.NewString())
+
+		s.ErrorAs(err, &e)
+	})
+
+	s.Run("Successfully delete subtask", func() {
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), subtaskID).Return(subtask, nil)
+		s.repo.EXPECT().DeleteTodo(gomock.Any(), gomock.Any(), subtask).Return(nil)
+		s.cache.EXPECT().Del("todos:" + subtaskID).Return(nil)
+		s.cache.EXPECT().Del("todos:" + todoID).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + userID).Return(nil)
+		err := s.todoService.DeleteSubtask(context.Background(), todoID, subtaskID, userID)
+
+		s.Nil(err)
+	})
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"gorm.io/gorm"
+)
+
+const defaultChangesLimit = 100
+
+// encodeCursor hides the change position behind an opaque token so clients do
+// not depend on its format.
+func encodeCursor(cursor repository.ChangeCursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(cursor.XID, 10) + "." + strconv.FormatInt(cursor.Seq, 10)))
+}
+
+func decodeCursor(cursor string) (repository.ChangeCursor, error) {
+	if cursor == "" {
+		return repository.ChangeCursor{}, nil
+	}
+
+	invalid := echo.NewHTTPError(http.StatusBadRequest, "Invalid cursor")
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return repository.ChangeCursor{}, invalid
+	}
+
+	rawXID, rawSeq, ok := strings.Cut(string(raw), ".")
+	if !ok {
+		return repository.ChangeCursor{}, invalid
+	}
+
+	xid, err := strconv.ParseInt(rawXID, 10, 64)
+	if err != nil || xid < 0 {
+		return repository.ChangeCursor{}, invalid
+	}
+
+	seq, err := strconv.ParseInt(rawSeq, 10, 64)
+	if err != nil || seq < 0 {
+		return repository.ChangeCursor{}, invalid
+	}
+
+	return repository.ChangeCursor{XID: xid, Seq: seq}, nil
+}
+
+// GetTodoChanges returns the todos created, updated and trashed after the cursor.
+// Without a cursor it returns every live todo, which is how a client does its
+// first full sync. Todos purged from the trash leave no tombstone, so a client
+// that stays offline longer than the trash retention should resync from scratch.
+func (s *todoService) GetTodoChanges(ctx context.Context, userID string, query dto.TodoChangesQuery) (*dto.TodoChanges, error) {
+	since, err := decodeCursor(query.Since)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultChangesLimit
+	}
+
+	db := s.todoRepository.SingleTransaction()
+
+	// One extra row tells whether another page follows.
+	todos, err := s.todoRepository.GetTodoChanges(ctx, db, userID, since, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := &dto.TodoChanges{
+		Created: []entity.Todo{},
+		Updated: []entity.Todo{},
+		Deleted: []dto.TodoTombstone{},
+		Cursor:  encodeCursor(since),
+	}
+
+	if len(todos) > limit {
+		todos = todos[:limit]
+		changes.HasMore = true
+	}
+
+	for _, todo := range todos {
+		switch {
+		case todo.DeletedAt.Valid:
+			if since != (repository.ChangeCursor{}) {
+				changes.Deleted = append(changes.Deleted, dto.TodoTombstone{ID: todo.ID, DeletedAt: todo.DeletedAt.Time})
+			}
+		case repository.ChangeCursor{XID: todo.CreatedXID, Seq: todo.CreatedSeq}.After(since):
+			changes.Created = append(changes.Created, todo)
+		default:
+			changes.Updated = append(changes.Updated, todo)
+		}
+
+		changes.Cursor = encodeCursor(repository.ChangeCursor{XID: todo.ChangeXID, Seq: todo.ChangeSeq})
+	}
+
+	return changes, nil
+}
+
+// SyncTodos applies a batch of offline mutations in order. Each mutation runs on
+// its own, so a conflict or a rejected mutation does not hold back the others.
+func (s *todoService) SyncTodos(ctx context.Context, request dto.SyncTodosRequest, userID string) ([]dto.SyncResult, error) {
+	results := make([]dto.SyncResult, 0, len(request.Mutations))
+
+	for i, mutation := range request.Mutations {
+		result, err := s.applyMutation(ctx, mutation, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Index = i
+		results = append(results, *result)
+	}
+
+	return results, nil
+}
+
+func (s *todoService) applyMutation(ctx context.Context, mutation dto.SyncMutation, userID string) (*dto.SyncResult, error) {
+	var todo *entity.Todo
+	var err error
+
+	switch mutation.Op {
+	case dto.SyncOpCreate:
+		todo, err = s.syncCreate(ctx, mutation, userID)
+	case dto.SyncOpUpdate:
+		changes := *mutation.Changes
+		changes.ID = mutation.ID
+		changes.Version = mutation.Version
+		todo, err = s.UpdateTodo(ctx, changes, userID)
+	case dto.SyncOpDelete:
+		err = s.DeleteTodo(ctx, mutation.ID, userID, mutation.Version)
+		// Deleting a todo that is already gone is what the client wanted anyway.
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = nil
+		}
+	default:
+		err = echo.NewHTTPError(http.StatusBadRequest, "Unknown operation")
+	}
+
+	if errors.Is(err, repository.ErrVersionConflict) {
+		todo, err = s.todoRepository.GetTodoByID(ctx, s.todoRepository.SingleTransaction(), mutation.ID)
+		if err == nil {
+			return &dto.SyncResult{
+				ID:      mutation.ID,
+				Status:  dto.SyncStatusConflict,
+				Code:    http.StatusPreconditionFailed,
+				Message: "Todo has been modified on the server",
+				Todo:    todo,
+			}, nil
+		}
+	}
+
+	result := &dto.SyncResult{ID: mutation.ID}
+	var httpErr *echo.HTTPError
+
+	switch {
+	case err == nil:
+		result.Status = dto.SyncStatusApplied
+		result.Todo = todo
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		result.Status = dto.SyncStatusRejected
+		result.Code = http.StatusNotFound
+		result.Message = "Todo not found"
+	case errors.As(err, &httpErr):
+		result.Status = dto.SyncStatusRejected
+		result.Code = httpErr.Code
+		result.Message = fmt.Sprint(httpErr.Message)
+	default:
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// syncCreate creates the todo under the client's ID. A create that already went
+// through, e.g. when the client retries after a lost response, returns the
+// existing todo instead of failing.
+func (s *todoService) syncCreate(ctx context.Context, mutation dto.SyncMutation, userID string) (*entity.Todo, error) {
+	existing, err := s.todoRepository.GetTodoByID(ctx, s.todoRepository.SingleTransaction(), mutation.ID)
+	if err == nil {
+		if existing.UserID.String() != userID {
+			return nil, echo.NewHTTPError(http.StatusConflict, "Todo ID is already in use")
+		}
+		return existing, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	request := *mutation.Todo
+	request.ID = mutation.ID
+
+	return s.CreateTodo(ctx, request, userID)
+}
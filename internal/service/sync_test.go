@@ -0,0 +1,207 @@
+package service_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"github.com/sherwin-77/golang-todos/pkg/patch"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func cursor(position string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(position))
+}
+
+func (s *TodoTestSuite) TestGetTodoChanges() {
+	userID := uuid.NewString()
+	since := repository.ChangeCursor{XID: 5, Seq: 10}
+
+	s.Run("Invalid cursor", func() {
+		var e *echo.HTTPError
+		result, err := s.todoService.GetTodoChanges(context.Background(), userID, dto.TodoChangesQuery{Since: "not a cursor"})
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusBadRequest, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Cursor without a transaction", func() {
+		var e *echo.HTTPError
+		result, err := s.todoService.GetTodoChanges(context.Background(), userID, dto.TodoChangesQuery{Since: cursor("10")})
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusBadRequest, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Failed to get changes", func() {
+		errorTest := errors.New("get changes error")
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTodoChanges(gomock.Any(), gomock.Any(), userID, since, 101).Return(nil, errorTest)
+		result, err := s.todoService.GetTodoChanges(context.Background(), userID, dto.TodoChangesQuery{Since: cursor("5.10")})
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+	})
+
+	s.Run("No changes keeps the cursor", func() {
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTodoChanges(gomock.Any(), gomock.Any(), userID, since, 101).Return(nil, nil)
+		result, err := s.todoService.GetTodoChanges(context.Background(), userID, dto.TodoChangesQuery{Since: cursor("5.10")})
+
+		s.Nil(err)
+		s.Empty(result.Created)
+		s.Empty(result.Updated)
+		s.Empty(result.Deleted)
+		s.Equal(cursor("5.10"), result.Cursor)
+		s.False(result.HasMore)
+	})
+
+	s.Run("Split changes into created, updated and deleted", func() {
+		// A later transaction can draw a lower sequence than an earlier one.
+		created := entity.Todo{ChangeXID: 6, ChangeSeq: 9, CreatedXID: 6, CreatedSeq: 9}
+		updated := entity.Todo{ChangeXID: 6, ChangeSeq: 12, CreatedXID: 2, CreatedSeq: 3}
+		deleted := entity.Todo{ChangeXID: 7, ChangeSeq: 13, CreatedXID: 2, CreatedSeq: 4, DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}}
+		deleted.ID = uuid.New()
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTodoChanges(gomock.Any(), gomock.Any(), userID, since, 101).
+			Return([]entity.Todo{created, updated, deleted}, nil)
+		result, err := s.todoService.GetTodoChanges(context.Background(), userID, dto.TodoChangesQuery{Since: cursor("5.10")})
+
+		s.Nil(err)
+		s.Equal([]entity.Todo{created}, result.Created)
+		s.Equal([]entity.Todo{updated}, result.Updated)
+		s.Equal([]dto.TodoTombstone{{ID: deleted.ID, DeletedAt: deleted.DeletedAt.Time}}, result.Deleted)
+		s.Equal(cursor("7.13"), result.Cursor)
+		s.False(result.HasMore)
+	})
+
+	s.Run("Full sync skips tombstones and pages", func() {
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTodoChanges(gomock.Any(), gomock.Any(), userID, repository.ChangeCursor{}, 3).Return([]entity.Todo{
+			{ChangeXID: 1, ChangeSeq: 1, CreatedXID: 1, CreatedSeq: 1, DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}},
+			{ChangeXID: 2, ChangeSeq: 2, CreatedXID: 2, CreatedSeq: 2},
+			{ChangeXID: 3, ChangeSeq: 3, CreatedXID: 3, CreatedSeq: 3},
+		}, nil)
+		result, err := s.todoService.GetTodoChanges(context.Background(), userID, dto.TodoChangesQuery{Limit: 2})
+
+		s.Nil(err)
+		s.Len(result.Created, 1)
+		s.Empty(result.Deleted)
+		s.Equal(cursor("2.2"), result.Cursor)
+		s.True(result.HasMore)
+	})
+}
+
+func (s *TodoTestSuite) TestSyncTodos() {
+	userID := uuid.NewString()
+	todoID := uuid.NewString()
+	todo := &entity.Todo{Title: "Todo", UserID: uuid.MustParse(userID)}
+	todo.ID = uuid.MustParse(todoID)
+	todo.Version = 3
+
+	s.Run("Create with client ID", func() {
+		s.repo.EXPECT().SingleTransaction().Return(nil).Times(2)
+		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(nil, gorm.ErrRecordNotFound)
+		s.repo.EXPECT().NextPosition(gomock.Any(), gomock.Any(), gomock.Any()).Return(float64(1), nil)
+		s.repo.EXPECT().CreateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + userID).Return(nil)
+		result, err := s.todoService.SyncTodos(context.Background(), dto.SyncTodosRequest{Mutations: []dto.SyncMutation{
+			{Op: dto.SyncOpCreate, ID: todoID, Todo: &dto.TodoRequest{Title: "Todo"}},
+		}}, userID)
+
+		s.Nil(err)
+		s.Len(result, 1)
+		s.Equal(dto.SyncStatusApplied, result[0].Status)
+		s.Equal(todoID, result[0].Todo.ID.String())
+	})
+
+	s.Run("Replayed create returns the existing todo", func() {
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+		result, err := s.todoService.SyncTodos(context.Background(), dto.SyncTodosRequest{Mutations: []dto.SyncMutation{
+			{Op: dto.SyncOpCreate, ID: todoID, Todo: &dto.TodoRequest{Title: "Todo"}},
+		}}, userID)
+
+		s.Nil(err)
+		s.Equal(dto.SyncStatusApplied, result[0].Status)
+		s.Equal(todo, result[0].Todo)
+	})
+
+	s.Run("Update conflict returns the server copy", func() {
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+
+			return f(&gorm.DB{})
+		})
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+		result, err := s.todoService.SyncTodos(context.Background(), dto.SyncTodosRequest{Mutations: []dto.SyncMutation{
+			{Op: dto.SyncOpUpdate, ID: todoID, Version: 2, Changes: &dto.UpdateTodoRequest{Title: patch.Value("Offline")}},
+		}}, userID)
+
+		s.Nil(err)
+		s.Equal(dto.SyncStatusConflict, result[0].Status)
+		s.Equal(http.StatusPreconditionFailed, result[0].Code)
+		s.Equal(todo, result[0].Todo)
+	})
+
+	s.Run("Delete of a missing todo is applied and rejections do not stop the batch", func() {
+		otherID := uuid.NewString()
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(nil, gorm.ErrRecordNotFound)
+
+			return f(&gorm.DB{})
+		})
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), otherID).Return(todo, nil)
+
+			return f(&gorm.DB{})
+		})
+		result, err := s.todoService.SyncTodos(context.Background(), dto.SyncTodosRequest{Mutations: []dto.SyncMutation{
+			{Op: dto.SyncOpDelete, ID: todoID, Version: 3},
+			{Op: dto.SyncOpDelete, ID: otherID},
+		}}, uuid.NewString())
+
+		s.Nil(err)
+		s.Len(result, 2)
+		s.Equal(dto.SyncStatusApplied, result[0].Status)
+		s.Equal(1, result[1].Index)
+		s.Equal(dto.SyncStatusRejected, result[1].Status)
+		s.Equal(http.StatusNotFound, result[1].Code)
+	})
+
+	s.Run("Unexpected errors abort the sync", func() {
+		errorTest := errors.New("get todo error")
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(nil, errorTest)
+		result, err := s.todoService.SyncTodos(context.Background(), dto.SyncTodosRequest{Mutations: []dto.SyncMutation{
+			{Op: dto.SyncOpCreate, ID: todoID, Todo: &dto.TodoRequest{Title: "Todo"}},
+		}}, userID)
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+	})
+
+	s.Run("Conflicting todo deleted meanwhile", func() {
+		s.repo.EXPECT().WithTransaction(gomock.Any()).Return(repository.ErrVersionConflict)
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(nil, gorm.ErrRecordNotFound)
+		result, err := s.todoService.SyncTodos(context.Background(), dto.SyncTodosRequest{Mutations: []dto.SyncMutation{
+			{Op: dto.SyncOpDelete, ID: todoID, Version: 1},
+		}}, userID)
+
+		s.Nil(err)
+		s.Equal(dto.SyncStatusRejected, result[0].Status)
+		s.Equal(http.StatusNotFound, result[0].Code)
+	})
+}
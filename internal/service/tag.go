@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"github.com/sherwin-77/golang-todos/pkg/caches"
+	"gorm.io/gorm"
+)
+
+const defaultTagColor = "#808080"
+
+type TagService interface {
+	GetTagsByUserID(ctx context.Context, userID string) ([]entity.Tag, error)
+	GetTagByID(ctx context.Context, id string, userID string) (*entity.Tag, error)
+	CreateTag(ctx context.Context, request dto.TagRequest, userID string) (*entity.Tag, error)
+	UpdateTag(ctx context.Context, request dto.UpdateTagRequest, userID string) (*entity.Tag, error)
+	DeleteTag(ctx context.Context, id string, userID string) error
+}
+
+type tagService struct {
+	tagRepository     repository.TagRepository
+	todoRepository    repository.TodoRepository
+	projectRepository repository.ProjectRepository
+	cache             caches.Cache
+}
+
+func NewTagService(tagRepository repository.TagRepository, todoRepository repository.TodoRepository, projectRepository repository.ProjectRepository, cache caches.Cache) TagService {
+	return &tagService{tagRepository, todoRepository, projectRepository, cache}
+}
+
+func (s *tagService) GetTagsByUserID(ctx context.Context, userID string) ([]entity.Tag, error) {
+	tagKey := "tags:all:" + userID
+	var tags []entity.Tag
+	cachedData := s.cache.Get(tagKey)
+	if cachedData != "" {
+		if err := json.Unmarshal([]byte(cachedData), &tags); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		db := s.tagRepository.SingleTransaction()
+		tags, err = s.tagRepository.GetTagsByUserID(ctx, db, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		data, _ := json.Marshal(tags)
+
+		if err := s.cache.Set(tagKey, string(data), 5*time.Minute); err != nil {
+			return nil, err
+		}
+	}
+
+	return tags, nil
+}
+
+func (s *tagService) GetTagByID(ctx context.Context, id string, userID string) (*entity.Tag, error) {
+	db := s.tagRepository.SingleTransaction()
+	tag, err := s.tagRepository.GetTagByID(ctx, db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if tag.UserID.String() != userID {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Tag not found")
+	}
+
+	return tag, nil
+}
+
+func (s *tagService) CreateTag(ctx context.Context, request dto.TagRequest, userID string) (*entity.Tag, error) {
+	db := s.tagRepository.SingleTransaction()
+	tag := &entity.Tag{
+		Name:   request.Name,
+		Color:  request.Color,
+		UserID: uuid.MustParse(userID),
+	}
+	if tag.Color == "" {
+		tag.Color = defaultTagColor
+	}
+
+	if err := s.tagRepository.CreateTag(ctx, db, tag); err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.Del("tags:all:" + userID); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (s *tagService) UpdateTag(ctx context.Context, request dto.UpdateTagRequest, userID string) (*entity.Tag, error) {
+	var tag *entity.Tag
+	var todoIDs, audience []string
+
+	if err := s.tagRepository.WithTransaction(func(tx *gorm.DB) error {
+		var err error
+		tag, err = s.tagRepository.GetTagByID(ctx, tx, request.ID)
+		if err != nil {
+			return err
+		}
+
+		if tag.UserID.String() != userID {
+			return echo.NewHTTPError(http.StatusNotFound, "Tag not found")
+		}
+
+		tag.Name = request.Name
+		if request.Color != "" {
+			tag.Color = request.Color
+		}
+
+		if err := s.tagRepository.UpdateTag(ctx, tx, tag); err != nil {
+			return err
+		}
+
+		if err := s.tagRepository.TouchTaggedTodos(ctx, tx, tag); err != nil {
+			return err
+		}
+
+		todoIDs, audience, err = s.taggedTodos(ctx, tx, tag)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := s.invalidateTodos(userID, todoIDs, audience); err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (s *tagService) DeleteTag(ctx context.Context, id string, userID string) error {
+	var tag *entity.Tag
+	var todoIDs, audience []string
+
+	if err := s.tagRepository.WithTransaction(func(tx *gorm.DB) error {
+		var err error
+		tag, err = s.tagRepository.GetTagByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if tag.UserID.String() != userID {
+			return echo.NewHTTPError(http.StatusNotFound, "Tag not found")
+		}
+
+		// Collect and touch tagged todos before the join rows cascade away with the tag.
+		todoIDs, audience, err = s.taggedTodos(ctx, tx, tag)
+		if err != nil {
+			return err
+		}
+
+		if err := s.tagRepository.TouchTaggedTodos(ctx, tx, tag); err != nil {
+			return err
+		}
+
+		return s.tagRepository.DeleteTag(ctx, tx, tag)
+	}); err != nil {
+		return err
+	}
+
+	return s.invalidateTodos(userID, todoIDs, audience)
+}
+
+// taggedTodos returns the IDs of the todos carrying the tag and the users who
+// see them in their lists. Tagged todos may sit in projects shared with others.
+func (s *tagService) taggedTodos(ctx context.Context, tx *gorm.DB, tag *entity.Tag) ([]string, []string, error) {
+	todoIDs, err := s.tagRepository.GetTaggedTodoIDs(ctx, tx, tag)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	todos, err := s.todoRepository.GetTodosByIDs(ctx, tx, todoIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	audience := []string{tag.UserID.String()}
+	for i := range todos {
+		users, err := todoAudience(ctx, tx, s.projectRepository, &todos[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		audience = append(audience, users...)
+	}
+
+	return todoIDs, audience, nil
+}
+
+// invalidateTodos drops the tag list and every cached todo embedding the tag,
+// along with the todo lists of everyone who sees those todos.
+func (s *tagService) invalidateTodos(userID string, todoIDs []string, audience []string) error {
+	if err := s.cache.Del("tags:all:" + userID); err != nil {
+		return err
+	}
+
+	for _, todoID := range todoIDs {
+		if err := s.cache.Del("todos:" + todoID); err != nil {
+			return err
+		}
+	}
+
+	return delUserKeys(s.cache, "todos:all:", audience)
+}
@@ -0,0 +1,327 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/service"
+	mock_caches "github.com/sherwin-77/golang-todos/test/mock/pkg/caches"
+	mock_repository "github.com/sherwin-77/golang-todos/test/mock/repository"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type TagTestSuite struct {
+	suite.Suite
+	ctrl        *gomock.Controller
+	repo        *mock_repository.MockTagRepository
+	todoRepo    *mock_repository.MockTodoRepository
+	projectRepo *mock_repository.MockProjectRepository
+	cache       *mock_caches.MockCache
+	tagService  service.TagService
+}
+
+func (s *TagTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.repo = mock_repository.NewMockTagRepository(s.ctrl)
+	s.todoRepo = mock_repository.NewMockTodoRepository(s.ctrl)
+	s.projectRepo = mock_repository.NewMockProjectRepository(s.ctrl)
+	s.cache = mock_caches.NewMockCache(s.ctrl)
+	s.tagService = service.NewTagService(s.repo, s.todoRepo, s.projectRepo, s.cache)
+}
+
+func TestTagService(t *testing.T) {
+	suite.Run(t, new(TagTestSuite))
+}
+
+func (s *TagTestSuite) TestGetTagsByUserID() {
+	userID := uuid.NewString()
+	keyFindAll := "tags:all:" + userID
+	tags := make([]entity.Tag, 0)
+	marshalledData, _ := json.Marshal(tags)
+
+	s.Run("Failed unmarshal", func() {
+		s.cache.EXPECT().Get(keyFindAll).Return("invalid")
+		result, err := s.tagService.GetTagsByUserID(context.Background(), userID)
+
+		s.Error(err)
+		s.Nil(result)
+	})
+
+	s.Run("Failed to get tags", func() {
+		errorTest := errors.New("get tags error")
+		s.cache.EXPECT().Get(keyFindAll).Return("")
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTagsByUserID(gomock.Any(), gomock.Any(), userID).Return(nil, errorTest)
+		result, err := s.tagService.GetTagsByUserID(context.Background(), userID)
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+	})
+
+	s.Run("Successfully get tags", func() {
+		s.cache.EXPECT().Get(keyFindAll).Return("")
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTagsByUserID(gomock.Any(), gomock.Any(), userID).Return(tags, nil)
+		s.cache.EXPECT().Set(keyFindAll, string(marshalledData), gomock.Any()).Return(nil)
+		result, err := s.tagService.GetTagsByUserID(context.Background(), userID)
+
+		s.Nil(err)
+		s.Equal(tags, result)
+	})
+
+	s.Run("Successfully get tags from cache", func() {
+		s.cache.EXPECT().Get(keyFindAll).Return(string(marshalledData))
+		result, err := s.tagService.GetTagsByUserID(context.Background(), userID)
+
+		s.Nil(err)
+		s.Equal(tags, result)
+	})
+}
+
+func (s *TagTestSuite) TestGetTagByID() {
+	tagID := uuid.NewString()
+	userID := uuid.NewString()
+	tag := &entity.Tag{UserID: uuid.MustParse(userID)}
+	tag.ID = uuid.MustParse(tagID)
+
+	s.Run("Failed to get tag", func() {
+		errorTest := errors.New("get tag error")
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTagByID(gomock.Any(), gomock.Any(), tagID).Return(nil, errorTest)
+		result, err := s.tagService.GetTagByID(context.Background(), tagID, userID)
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+	})
+
+	s.Run("User ID mismatch", func() {
+		var e *echo.HTTPError
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTagByID(gomock.Any(), gomock.Any(), tagID).Return(tag, nil)
+		result, err := s.tagService.GetTagByID(context.Background(), tagID, uuid.NewString())
+
+		s.ErrorAs(err, &e)
+		s.Nil(result)
+	})
+
+	s.Run("Successfully get tag", func() {
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTagByID(gomock.Any(), gomock.Any(), tagID).Return(tag, nil)
+		result, err := s.tagService.GetTagByID(context.Background(), tagID, userID)
+
+		s.Nil(err)
+		s.Equal(tag, result)
+	})
+}
+
+func (s *TagTestSuite) TestCreateTag() {
+	userID := uuid.NewString()
+	keyFindAll := "tags:all:" + userID
+
+	s.Run("Failed to create tag", func() {
+		errorTest := errors.New("create tag error")
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().CreateTag(gomock.Any(), gomock.Any(), gomock.Any()).Return(errorTest)
+		result, err := s.tagService.CreateTag(context.Background(), dto.TagRequest{Name: "work"}, userID)
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+	})
+
+	s.Run("Failed to delete cache", func() {
+		errorTest := errors.New("delete cache error")
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().CreateTag(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		s.cache.EXPECT().Del(keyFindAll).Return(errorTest)
+		result, err := s.tagService.CreateTag(context.Background(), dto.TagRequest{Name: "work"}, userID)
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+	})
+
+	s.Run("Successfully create tag", func() {
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().CreateTag(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		s.cache.EXPECT().Del(keyFindAll).Return(nil)
+		result, err := s.tagService.CreateTag(context.Background(), dto.TagRequest{Name: "work"}, userID)
+
+		s.Nil(err)
+		s.Equal("work", result.Name)
+		s.Equal("#808080", result.Color)
+	})
+}
+
+func (s *TagTestSuite) TestUpdateTag() {
+	tagID := uuid.NewString()
+	userID := uuid.NewString()
+	todoID := uuid.NewString()
+	emptyTag := &entity.Tag{UserID: uuid.MustParse(userID)}
+	emptyTag.ID = uuid.MustParse(tagID)
+	memberID := uuid.NewString()
+	projectID := uuid.New()
+	sharedTodo := entity.Todo{UserID: uuid.MustParse(userID), ProjectID: &projectID}
+	sharedTodo.ID = uuid.MustParse(todoID)
+	request := dto.UpdateTagRequest{ID: tagID, TagRequest: dto.TagRequest{Name: "work", Color: "#ff0000"}}
+
+	s.Run("Failed to get tag", func() {
+		errorTest := errors.New("get tag error")
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTagByID(gomock.Any(), gomock.Any(), tagID).Return(nil, errorTest)
+
+			return f(&gorm.DB{})
+		})
+		result, err := s.tagService.UpdateTag(context.Background(), request, userID)
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+	})
+
+	s.Run("User ID mismatch", func() {
+		var e *echo.HTTPError
+		tagRet := *emptyTag
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTagByID(gomock.Any(), gomock.Any(), tagID).Return(&tagRet, nil)
+
+			return f(&gorm.DB{})
+		})
+		result, err := s.tagService.UpdateTag(context.Background(), request, uuid.NewString())
+
+		s.ErrorAs(err, &e)
+		s.Nil(result)
+	})
+
+	s.Run("Failed to update tag", func() {
+		errorTest := errors.New("update tag error")
+		tagRet := *emptyTag
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTagByID(gomock.Any(), gomock.Any(), tagID).Return(&tagRet, nil)
+			s.repo.EXPECT().UpdateTag(gomock.Any(), gomock.Any(), gomock.Any()).Return(errorTest)
+
+			return f(&gorm.DB{})
+		})
+		result, err := s.tagService.UpdateTag(context.Background(), request, userID)
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+	})
+
+	s.Run("Failed to touch tagged todos", func() {
+		errorTest := errors.New("touch todos error")
+		tagRet := *emptyTag
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTagByID(gomock.Any(), gomock.Any(), tagID).Return(&tagRet, nil)
+			s.repo.EXPECT().UpdateTag(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			s.repo.EXPECT().TouchTaggedTodos(gomock.Any(), gomock.Any(), &tagRet).Return(errorTest)
+
+			return f(&gorm.DB{})
+		})
+		result, err := s.tagService.UpdateTag(context.Background(), request, userID)
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+	})
+
+	s.Run("Successfully update tag", func() {
+		tagRet := *emptyTag
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTagByID(gomock.Any(), gomock.Any(), tagID).Return(&tagRet, nil)
+			s.repo.EXPECT().UpdateTag(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			s.repo.EXPECT().TouchTaggedTodos(gomock.Any(), gomock.Any(), &tagRet).Return(nil)
+			s.repo.EXPECT().GetTaggedTodoIDs(gomock.Any(), gomock.Any(), &tagRet).Return([]string{todoID}, nil)
+			s.todoRepo.EXPECT().GetTodosByIDs(gomock.Any(), gomock.Any(), []string{todoID}).Return([]entity.Todo{sharedTodo}, nil)
+			s.projectRepo.EXPECT().GetMemberUserIDs(gomock.Any(), gomock.Any(), sharedTodo.ProjectID.String()).Return([]string{memberID}, nil)
+
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("tags:all:" + userID).Return(nil)
+		s.cache.EXPECT().Del("todos:" + todoID).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + userID).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + memberID).Return(nil)
+		result, err := s.tagService.UpdateTag(context.Background(), request, userID)
+
+		s.Nil(err)
+		s.Equal("work", result.Name)
+		s.Equal("#ff0000", result.Color)
+	})
+}
+
+func (s *TagTestSuite) TestDeleteTag() {
+	tagID := uuid.NewString()
+	userID := uuid.NewString()
+	todoID := uuid.NewString()
+	tag := &entity.Tag{UserID: uuid.MustParse(userID)}
+	tag.ID = uuid.MustParse(tagID)
+	memberID := uuid.NewString()
+	projectID := uuid.New()
+	sharedTodo := entity.Todo{UserID: uuid.MustParse(userID), ProjectID: &projectID}
+	sharedTodo.ID = uuid.MustParse(todoID)
+
+	s.Run("Failed to get tag", func() {
+		errorTest := errors.New("get tag error")
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTagByID(gomock.Any(), gomock.Any(), tagID).Return(nil, errorTest)
+
+			return f(&gorm.DB{})
+		})
+		err := s.tagService.DeleteTag(context.Background(), tagID, userID)
+
+		s.ErrorIs(err, errorTest)
+	})
+
+	s.Run("User ID mismatch", func() {
+		var e *echo.HTTPError
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTagByID(gomock.Any(), gomock.Any(), tagID).Return(tag, nil)
+
+			return f(&gorm.DB{})
+		})
+		err := s.tagService.DeleteTag(context.Background(), tagID, uuid.NewString())
+
+		s.ErrorAs(err, &e)
+	})
+
+	s.Run("Failed to delete tag", func() {
+		errorTest := errors.New("delete tag error")
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTagByID(gomock.Any(), gomock.Any(), tagID).Return(tag, nil)
+			s.repo.EXPECT().GetTaggedTodoIDs(gomock.Any(), gomock.Any(), tag).Return([]string{todoID}, nil)
+			s.todoRepo.EXPECT().GetTodosByIDs(gomock.Any(), gomock.Any(), []string{todoID}).Return([]entity.Todo{sharedTodo}, nil)
+			s.projectRepo.EXPECT().GetMemberUserIDs(gomock.Any(), gomock.Any(), sharedTodo.ProjectID.String()).Return([]string{memberID}, nil)
+			s.repo.EXPECT().TouchTaggedTodos(gomock.Any(), gomock.Any(), tag).Return(nil)
+			s.repo.EXPECT().DeleteTag(gomock.Any(), gomock.Any(), tag).Return(errorTest)
+
+			return f(&gorm.DB{})
+		})
+		err := s.tagService.DeleteTag(context.Background(), tagID, userID)
+
+		s.ErrorIs(err, errorTest)
+	})
+
+	s.Run("Successfully delete tag", func() {
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTagByID(gomock.Any(), gomock.Any(), tagID).Return(tag, nil)
+			s.repo.EXPECT().GetTaggedTodoIDs(gomock.Any(), gomock.Any(), tag).Return([]string{todoID}, nil)
+			s.todoRepo.EXPECT().GetTodosByIDs(gomock.Any(), gomock.Any(), []string{todoID}).Return([]entity.Todo{sharedTodo}, nil)
+			s.projectRepo.EXPECT().GetMemberUserIDs(gomock.Any(), gomock.Any(), sharedTodo.ProjectID.String()).Return([]string{memberID}, nil)
+			s.repo.EXPECT().TouchTaggedTodos(gomock.Any(), gomock.Any(), tag).Return(nil)
+			s.repo.EXPECT().DeleteTag(gomock.Any(), gomock.Any(), tag).Return(nil)
+
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("tags:all:" + userID).Return(nil)
+		s.cache.EXPECT().Del("todos:" + todoID).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + userID).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + memberID).Return(nil)
+		err := s.tagService.DeleteTag(context.Background(), tagID, userID)
+
+		s.Nil(err)
+	})
+}
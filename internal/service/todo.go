@@ -5,56 +5,200 @@ import (
 	"encoding/json"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/configs"
 	"github.com/sherwin-77/golang-todos/internal/entity"
 	"github.com/sherwin-77/golang-todos/internal/http/dto"
 	"github.com/sherwin-77/golang-todos/internal/repository"
 	"github.com/sherwin-77/golang-todos/pkg/caches"
+	"github.com/sherwin-77/golang-todos/pkg/response"
+	"gorm.io/gorm"
+	"io"
 	"net/http"
+	"strconv"
 	"time"
 )
 
 type TodoService interface {
-	GetTodosByUserID(ctx context.Context, userID string) ([]entity.Todo, error)
+	GetTodosByUserID(ctx context.Context, userID string, query dto.TodoQuery) ([]entity.Todo, *response.Meta, error)
+	GetOverdueTodos(ctx context.Context, userID string, query dto.TodoDueQuery) ([]entity.Todo, *response.Meta, error)
+	GetTodayTodos(ctx context.Context, userID string, query dto.TodoDueQuery) ([]entity.Todo, *response.Meta, error)
+	GetUpcomingTodos(ctx context.Context, userID string, query dto.TodoDueQuery) ([]entity.Todo, *response.Meta, error)
+	SearchTodos(ctx context.Context, userID string, query dto.TodoSearchQuery) ([]entity.Todo, *response.Meta, error)
+	GetAssignedTodos(ctx context.Context, userID string, query dto.AssignedTodoQuery) ([]entity.Todo, *response.Meta, error)
 	GetTodoByID(ctx context.Context, id string, userID string) (*entity.Todo, error)
+	GetSubtasks(ctx context.Context, todoID string, userID string) ([]entity.Todo, error)
+	GetOccurrences(ctx context.Context, todoID string, userID string) ([]entity.Todo, error)
 	CreateTodo(ctx context.Context, request dto.TodoRequest, userID string) (*entity.Todo, error)
 	UpdateTodo(ctx context.Context, request dto.UpdateTodoRequest, userID string) (*entity.Todo, error)
-	DeleteTodo(ctx context.Context, id string, userID string) error
+	MoveTodo(ctx context.Context, request dto.MoveTodoRequest, userID string) (*entity.Todo, error)
+	AssignTodo(ctx context.Context, request dto.AssignTodoRequest, userID string) (*entity.Todo, error)
+	DeleteTodo(ctx context.Context, id string, userID string, version int) error
+	BulkTodos(ctx context.Context, request dto.BulkTodoRequest, userID string) ([]dto.BulkTodoResult, error)
+	GetTrash(ctx context.Context, userID string, query dto.TrashQuery) ([]entity.Todo, *response.Meta, error)
+	RestoreTodo(ctx context.Context, id string, userID string) (*entity.Todo, error)
+	PurgeTodo(ctx context.Context, id string, userID string) error
+	EmptyTrash(ctx context.Context, userID string) error
+	ExportTodos(ctx context.Context, userID string, format string, w io.Writer) error
+	ImportTodos(ctx context.Context, userID string, format string, r io.Reader) (*dto.ImportResult, error)
+	GetCalendarFeed(ctx context.Context, token string) (string, error)
+	CreateCalendarToken(ctx context.Context, userID string) (string, error)
+	DeleteCalendarToken(ctx context.Context, userID string) error
+	GetTodoChanges(ctx context.Context, userID string, query dto.TodoChangesQuery) (*dto.TodoChanges, error)
+	SyncTodos(ctx context.Context, request dto.SyncTodosRequest, userID string) ([]dto.SyncResult, error)
+	CreateSubtask(ctx context.Context, request dto.SubtaskRequest, userID string) (*entity.Todo, error)
+	UpdateSubtask(ctx context.Context, request dto.UpdateSubtaskRequest, userID string) (*entity.Todo, error)
+	DeleteSubtask(ctx context.Context, todoID string, subtaskID string, userID string) error
+	ChangeTags(ctx context.Context, request dto.ChangeTagRequest, userID string) error
 }
 
 type todoService struct {
-	todoRepository repository.TodoRepository
-	userRepository repository.UserRepository
-	cache          caches.Cache
+	todoRepository         repository.TodoRepository
+	userRepository         repository.UserRepository
+	tagRepository          repository.TagRepository
+	projectRepository      repository.ProjectRepository
+	notificationRepository repository.NotificationRepository
+	validator              *configs.AppValidator
+	cache                  caches.Cache
 }
 
-func NewTodoService(todoRepository repository.TodoRepository, userRepository repository.UserRepository, cache caches.Cache) TodoService {
-	return &todoService{todoRepository, userRepository, cache}
+func NewTodoService(todoRepository repository.TodoRepository, userRepository repository.UserRepository, tagRepository repository.TagRepository, projectRepository repository.ProjectRepository, notificationRepository repository.NotificationRepository, validator *configs.AppValidator, cache caches.Cache) TodoService {
+	return &todoService{todoRepository, userRepository, tagRepository, projectRepository, notificationRepository, validator, cache}
 }
 
-func (s *todoService) GetTodosByUserID(ctx context.Context, userID string) ([]entity.Todo, error) {
-	todoKey := "todos:all:" + userID
-	var todos []entity.Todo
+func (s *todoService) GetTodosByUserID(ctx context.Context, userID string, query dto.TodoQuery) ([]entity.Todo, *response.Meta, error) {
+	page, perPage := normalizePage(query.Page, query.PerPage)
+	order, err := buildTodoOrder(query.Sort)
+	if err != nil {
+		return nil, nil, err
+	}
+	condition, args := buildTodoFilter(userID, query)
+
+	version, err := s.todoListVersion(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	todoKey := "todos:all:" + userID + ":" + version + ":" + todoQueryKey(page, perPage, order, query)
+	var result todoListCache
 	cachedData := s.cache.Get(todoKey)
 	if cachedData != "" {
-		if err := json.Unmarshal([]byte(cachedData), &todos); err != nil {
-			return nil, err
+		if err := json.Unmarshal([]byte(cachedData), &result); err != nil {
+			return nil, nil, err
 		}
 	} else {
-		var err error
 		db := s.todoRepository.SingleTransaction()
-		todos, err = s.todoRepository.GetTodosByUserID(ctx, db, userID)
+		result.Total, err = s.todoRepository.CountTodosFiltered(ctx, db, condition, args...)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+
+		result.Todos, err = s.todoRepository.GetTodosFiltered(ctx, db, perPage, (page-1)*perPage, order, condition, args...)
+		if err != nil {
+			return nil, nil, err
 		}
 
-		data, _ := json.Marshal(todos)
+		if err := s.attachProgress(ctx, db, result.Todos); err != nil {
+			return nil, nil, err
+		}
+
+		data, _ := json.Marshal(result)
 
 		if err := s.cache.Set(todoKey, string(data), 5*time.Minute); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
-	return todos, nil
+	return result.Todos, response.NewMeta(page, perPage, int(result.Total)), nil
+}
+
+// todoListVersion returns the cache generation of a user's todo lists. Mutations
+// delete the "todos:all:<userID>" key, so every page cached under the previous
+// generation is skipped instead of being served stale.
+func (s *todoService) todoListVersion(userID string) (string, error) {
+	versionKey := "todos:all:" + userID
+	version := s.cache.Get(versionKey)
+	if version == "" {
+		version = strconv.FormatInt(time.Now().UnixNano(), 36)
+		if err := s.cache.Set(versionKey, version, 24*time.Hour); err != nil {
+			return "", err
+		}
+	}
+
+	return version, nil
+}
+
+func (s *todoService) GetOverdueTodos(ctx context.Context, userID string, query dto.TodoDueQuery) ([]entity.Todo, *response.Meta, error) {
+	return s.getDueTodos(ctx, userID, query, "due_at < ?", time.Now())
+}
+
+func (s *todoService) GetTodayTodos(ctx context.Context, userID string, query dto.TodoDueQuery) ([]entity.Todo, *response.Meta, error) {
+	location, err := s.userLocation(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	start := startOfDay(time.Now(), location)
+
+	return s.getDueTodos(ctx, userID, query, "due_at >= ? AND due_at < ?", start, start.AddDate(0, 0, 1))
+}
+
+func (s *todoService) GetUpcomingTodos(ctx context.Context, userID string, query dto.TodoDueQuery) ([]entity.Todo, *response.Meta, error) {
+	location, err := s.userLocation(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	days := query.Days
+	if days == 0 {
+		days = 7
+	}
+
+	now := time.Now()
+	end := startOfDay(now, location).AddDate(0, 0, days+1)
+
+	return s.getDueTodos(ctx, userID, query, "due_at >= ? AND due_at < ?", now, end)
+}
+
+// dueScope matches the open top-level todos the user can see, including the
+// ones assigned to them. It takes the user ID four times, then false.
+const dueScope = "(" + repository.TodoAccessScope + " OR assignee_id = ?) AND parent_id IS NULL AND is_completed = ?"
+
+// getDueTodos lists the user's open todos matching condition, ordered by due
+// date. These views depend on the current time, so unlike the main list they
+// are not cached.
+func (s *todoService) getDueTodos(ctx context.Context, userID string, query dto.TodoDueQuery, condition string, args ...interface{}) ([]entity.Todo, *response.Meta, error) {
+	condition = dueScope + " AND " + condition
+	args = append([]interface{}{userID, userID, userID, userID, false}, args...)
+	page, perPage := normalizePage(query.Page, query.PerPage)
+	db := s.todoRepository.SingleTransaction()
+
+	total, err := s.todoRepository.CountTodosFiltered(ctx, db, condition, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	todos, err := s.todoRepository.GetTodosFiltered(ctx, db, perPage, (page-1)*perPage, "due_at, id", condition, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return todos, response.NewMeta(page, perPage, int(total)), nil
+}
+
+// userLocation resolves the user's configured time zone, falling back to UTC.
+func (s *todoService) userLocation(ctx context.Context, userID string) (*time.Location, error) {
+	db := s.userRepository.SingleTransaction()
+	user, err := s.userRepository.GetUserByID(ctx, db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := time.LoadLocation(user.Timezone)
+	if err != nil || user.Timezone == "" {
+		return time.UTC, nil
+	}
+
+	return location, nil
 }
 
 func (s *todoService) GetTodoByID(ctx context.Context, id string, userID string) (*entity.Todo, error) {
@@ -73,6 +217,12 @@ func (s *todoService) GetTodoByID(ctx context.Context, id string, userID string)
 			return nil, err
 		}
 
+		todos := []entity.Todo{*todo}
+		if err := s.attachProgress(ctx, db, todos); err != nil {
+			return nil, err
+		}
+		todo = &todos[0]
+
 		data, _ := json.Marshal(todo)
 
 		if err := s.cache.Set(todoKey, string(data), 5*time.Minute); err != nil {
@@ -80,28 +230,86 @@ func (s *todoService) GetTodoByID(ctx context.Context, id string, userID string)
 		}
 	}
 
+	// Only shared todos need the membership lookup.
 	if todo.UserID.String() != userID {
-		return nil, echo.NewHTTPError(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		db := s.projectRepository.SingleTransaction()
+		if err := checkTodoAccess(ctx, db, s.projectRepository, todo, userID, entity.ProjectViewer); err != nil {
+			return nil, err
+		}
 	}
 
 	return todo, nil
 }
 
 func (s *todoService) CreateTodo(ctx context.Context, request dto.TodoRequest, userID string) (*entity.Todo, error) {
+	if err := validateReminder(request.DueAt, request.RemindAt); err != nil {
+		return nil, err
+	}
+
+	recurrence, err := normalizeRecurrence(request.Recurrence, request.DueAt)
+	if err != nil {
+		return nil, err
+	}
+
+	priority, err := parsePriority(request.Priority)
+	if err != nil {
+		return nil, err
+	}
+
 	db := s.todoRepository.SingleTransaction()
 
+	projectID, err := s.resolveProject(ctx, db, request.ProjectID, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	todo := &entity.Todo{
 		Title:       request.Title,
 		Description: request.Description,
 		IsCompleted: request.IsCompleted,
+		DueAt:       request.DueAt,
+		RemindAt:    request.RemindAt,
+		ProjectID:   projectID,
+		Recurrence:  recurrence,
+		Priority:    priority,
 		UserID:      uuid.MustParse(userID),
 	}
 
+	// Offline clients choose the ID themselves.
+	if request.ID != "" {
+		todo.ID, err = uuid.Parse(request.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	todo.Position, err = s.todoRepository.NextPosition(ctx, db, todo)
+	if err != nil {
+		return nil, err
+	}
+
+	// The first todo of a series identifies it.
+	if recurrence != "" {
+		if todo.ID == uuid.Nil {
+			todo.ID, err = uuid.NewV7()
+			if err != nil {
+				return nil, err
+			}
+		}
+		seriesID := todo.ID
+		todo.RecurrenceID = &seriesID
+	}
+
 	if err := s.todoRepository.CreateTodo(ctx, db, todo); err != nil {
 		return nil, err
 	}
 
-	if err := s.cache.Del("todos:all:" + userID); err != nil {
+	audience, err := todoAudience(ctx, db, s.projectRepository, todo)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := delUserKeys(s.cache, "todos:all:", audience); err != nil {
 		return nil, err
 	}
 
@@ -109,22 +317,127 @@ func (s *todoService) CreateTodo(ctx context.Context, request dto.TodoRequest, u
 }
 
 func (s *todoService) UpdateTodo(ctx context.Context, request dto.UpdateTodoRequest, userID string) (*entity.Todo, error) {
-	db := s.todoRepository.SingleTransaction()
-
-	todo, err := s.todoRepository.GetTodoByID(ctx, db, request.ID)
-	if err != nil {
+	if err := requireValue(request.Title, "Title"); err != nil {
 		return nil, err
 	}
 
-	if todo.UserID.String() != userID {
-		return nil, echo.NewHTTPError(http.StatusNotFound, "Todo not found")
-	}
+	var todo *entity.Todo
+	var audience []string
+	var completedSubtaskIDs []string
+
+	if err := s.todoRepository.WithTransaction(func(tx *gorm.DB) error {
+		var err error
+		todo, err = s.todoRepository.GetTodoByID(ctx, tx, request.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkTodoUpdate(ctx, tx, s.projectRepository, todo, userID, request); err != nil {
+			return err
+		}
+
+		if err := checkVersion(request.Version, todo.Version); err != nil {
+			return err
+		}
+
+		audience, err = todoAudience(ctx, tx, s.projectRepository, todo)
+		if err != nil {
+			return err
+		}
+
+		wasCompleted := todo.IsCompleted
+
+		request.Title.Apply(&todo.Title)
+		request.Description.Apply(&todo.Description)
+		request.IsCompleted.Apply(&todo.IsCompleted)
+		request.DueAt.ApplyPtr(&todo.DueAt)
+
+		completing := todo.IsCompleted && !wasCompleted
+
+		remindAt := todo.RemindAt
+		request.RemindAt.ApplyPtr(&remindAt)
+		if !sameTime(todo.RemindAt, remindAt) {
+			todo.RemindAt = remindAt
+			todo.RemindedAt = nil
+		}
+		if err := validateReminder(todo.DueAt, todo.RemindAt); err != nil {
+			return err
+		}
+
+		if request.Priority.Set {
+			todo.Priority, err = parsePriority(request.Priority.Value)
+			if err != nil {
+				return err
+			}
+		}
+
+		if request.ProjectID.Set {
+			var projectID string
+			request.ProjectID.Apply(&projectID)
+			if todo.ProjectID == nil || todo.ProjectID.String() != projectID {
+				todo.ProjectID, err = s.resolveProject(ctx, tx, projectID, userID)
+				if err != nil {
+					return err
+				}
+
+				// Members of the new project see the todo from now on.
+				newAudience, err := todoAudience(ctx, tx, s.projectRepository, todo)
+				if err != nil {
+					return err
+				}
+				audience = append(audience, newAudience...)
+			}
+		}
+
+		// Normalize even when only DueAt changed, since a recurring todo needs a due date.
+		request.Recurrence.Apply(&todo.Recurrence)
+		todo.Recurrence, err = normalizeRecurrence(todo.Recurrence, todo.DueAt)
+		if err != nil {
+			return err
+		}
+		if todo.Recurrence != "" && todo.RecurrenceID == nil {
+			seriesID := todo.ID
+			todo.RecurrenceID = &seriesID
+		}
+
+		if err := s.todoRepository.UpdateTodo(ctx, tx, todo); err != nil {
+			return err
+		}
+
+		if completing {
+			next, err := s.nextOccurrence(ctx, tx, todo)
+			if err != nil {
+				return err
+			}
+
+			if next != nil {
+				if err := s.todoRepository.CreateTodo(ctx, tx, next); err != nil {
+					return err
+				}
+			}
+		}
 
-	todo.Title = request.Title
-	todo.Description = request.Description
-	todo.IsCompleted = request.IsCompleted
+		if completing && request.CompleteSubtasks {
+			subtasks, err := s.todoRepository.GetSubtasks(ctx, tx, todo.ID.String())
+			if err != nil {
+				return err
+			}
+
+			for _, subtask := range subtasks {
+				if !subtask.IsCompleted {
+					completedSubtaskIDs = append(completedSubtaskIDs, subtask.ID.String())
+				}
+			}
+
+			if len(completedSubtaskIDs) > 0 {
+				if err := s.todoRepository.CompleteSubtasks(ctx, tx, todo); err != nil {
+					return err
+				}
+			}
+		}
 
-	if err := s.todoRepository.UpdateTodo(ctx, db, todo); err != nil {
+		return nil
+	}); err != nil {
 		return nil, err
 	}
 
@@ -132,34 +445,287 @@ func (s *todoService) UpdateTodo(ctx context.Context, request dto.UpdateTodoRequ
 		return nil, err
 	}
 
-	if err := s.cache.Del("todos:all:" + userID); err != nil {
+	for _, subtaskID := range completedSubtaskIDs {
+		if err := s.cache.Del("todos:" + subtaskID); err != nil {
+			return nil, err
+		}
+	}
+
+	if todo.ParentID != nil {
+		if err := s.cache.Del("todos:" + todo.ParentID.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := delUserKeys(s.cache, "todos:all:", audience); err != nil {
 		return nil, err
 	}
 
 	return todo, nil
 }
 
-func (s *todoService) DeleteTodo(ctx context.Context, id string, userID string) error {
-	db := s.todoRepository.SingleTransaction()
+// resolveProject checks that a todo may be placed in the given project. An empty
+// projectID means the inbox.
+func (s *todoService) resolveProject(ctx context.Context, tx *gorm.DB, projectID string, userID string) (*uuid.UUID, error) {
+	if projectID == "" {
+		return nil, nil
+	}
+
+	project, err := s.projectRepository.GetProjectByID(ctx, tx, projectID)
+	if err != nil {
+		return nil, err
+	}
 
-	todo, err := s.todoRepository.GetTodoByID(ctx, db, id)
+	role, err := projectRole(ctx, tx, s.projectRepository, project, userID)
 	if err != nil {
+		return nil, err
+	}
+
+	if role == "" {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "Project not found")
+	}
+
+	if !role.Allows(entity.ProjectEditor) {
+		return nil, echo.NewHTTPError(http.StatusForbidden, "You do not have permission to add todos to this project")
+	}
+
+	if project.ArchivedAt != nil {
+		return nil, echo.NewHTTPError(http.StatusUnprocessableEntity, "Project is archived")
+	}
+
+	return &project.ID, nil
+}
+
+func (s *todoService) DeleteTodo(ctx context.Context, id string, userID string, version int) error {
+	var todo *entity.Todo
+	var audience []string
+	var subtasks []entity.Todo
+
+	if err := s.todoRepository.WithTransaction(func(tx *gorm.DB) error {
+		var err error
+		todo, err = s.todoRepository.GetTodoByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := checkTodoAccess(ctx, tx, s.projectRepository, todo, userID, entity.ProjectEditor); err != nil {
+			return err
+		}
+
+		if err := checkVersion(version, todo.Version); err != nil {
+			return err
+		}
+
+		audience, err = todoAudience(ctx, tx, s.projectRepository, todo)
+		if err != nil {
+			return err
+		}
+
+		// Subtasks are trashed along with the todo, so collect them first to drop their cache entries.
+		subtasks, err = s.todoRepository.GetSubtasks(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		return s.todoRepository.DeleteTodo(ctx, tx, todo)
+	}); err != nil {
 		return err
 	}
 
-	if todo.UserID.String() != userID {
+	if err := s.cache.Del("todos:" + todo.ID.String()); err != nil {
+		return err
+	}
+
+	for _, subtask := range subtasks {
+		if err := s.cache.Del("todos:" + subtask.ID.String()); err != nil {
+			return err
+		}
+	}
+
+	if todo.ParentID != nil {
+		if err := s.cache.Del("todos:" + todo.ParentID.String()); err != nil {
+			return err
+		}
+	}
+
+	if err := delUserKeys(s.cache, "todos:all:", audience); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// checkTodoAccess requires userID to hold at least the given role on todo. Todos
+// the user cannot see at all are reported as not found.
+func checkTodoAccess(ctx context.Context, tx *gorm.DB, projectRepository repository.ProjectRepository, todo *entity.Todo, userID string, required entity.ProjectRole) error {
+	role, err := todoRole(ctx, tx, projectRepository, todo, userID)
+	if err != nil {
+		return err
+	}
+
+	return requireTodoRole(role, required)
+}
+
+// checkTodoUpdate requires editor access to todo, except that its assignee may
+// always mark it complete or incomplete.
+func checkTodoUpdate(ctx context.Context, tx *gorm.DB, projectRepository repository.ProjectRepository, todo *entity.Todo, userID string, request dto.UpdateTodoRequest) error {
+	role, err := todoRole(ctx, tx, projectRepository, todo, userID)
+	if err != nil {
+		return err
+	}
+
+	if role.Allows(entity.ProjectViewer) && isAssignee(todo, userID) && onlyCompletion(request) {
+		return nil
+	}
+
+	return requireTodoRole(role, entity.ProjectEditor)
+}
+
+// todoRole returns the role userID holds on todo, or "" when the todo is hidden
+// from them. The creator of a todo owns it; anyone else has their role in the
+// todo's project, and its assignee can at least view it.
+func todoRole(ctx context.Context, tx *gorm.DB, projectRepository repository.ProjectRepository, todo *entity.Todo, userID string) (entity.ProjectRole, error) {
+	if todo.UserID.String() == userID {
+		return entity.ProjectOwner, nil
+	}
+
+	var role entity.ProjectRole
+	if todo.ProjectID != nil {
+		project, err := projectRepository.GetProjectByID(ctx, tx, todo.ProjectID.String())
+		if err != nil {
+			return "", err
+		}
+
+		role, err = projectRole(ctx, tx, projectRepository, project, userID)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if isAssignee(todo, userID) && !role.Allows(entity.ProjectViewer) {
+		role = entity.ProjectViewer
+	}
+
+	return role, nil
+}
+
+func requireTodoRole(role entity.ProjectRole, required entity.ProjectRole) error {
+	if role == "" {
 		return echo.NewHTTPError(http.StatusNotFound, "Todo not found")
 	}
 
-	if err := s.todoRepository.DeleteTodo(ctx, db, todo); err != nil {
+	if !role.Allows(required) {
+		return echo.NewHTTPError(http.StatusForbidden, "You do not have permission to change this todo")
+	}
+
+	return nil
+}
+
+func isAssignee(todo *entity.Todo, userID string) bool {
+	return todo.AssigneeID != nil && todo.AssigneeID.String() == userID
+}
+
+// onlyCompletion reports whether the update does nothing but mark the todo
+// complete or incomplete.
+func onlyCompletion(request dto.UpdateTodoRequest) bool {
+	return request.IsCompleted.Set &&
+		!request.Title.Set &&
+		!request.Description.Set &&
+		!request.DueAt.Set &&
+		!request.RemindAt.Set &&
+		!request.ProjectID.Set &&
+		!request.Recurrence.Set &&
+		!request.Priority.Set &&
+		!request.CompleteSubtasks
+}
+
+// todoAudience returns the users whose todo lists include todo: its creator
+// and, for a todo in a project, everyone the project is shared with.
+func todoAudience(ctx context.Context, tx *gorm.DB, projectRepository repository.ProjectRepository, todo *entity.Todo) ([]string, error) {
+	if todo.ProjectID == nil {
+		return []string{todo.UserID.String()}, nil
+	}
+
+	userIDs, err := projectRepository.GetMemberUserIDs(ctx, tx, todo.ProjectID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return append(userIDs, todo.UserID.String()), nil
+}
+
+func (s *todoService) ChangeTags(ctx context.Context, request dto.ChangeTagRequest, userID string) error {
+	var audience []string
+
+	if err := s.todoRepository.WithTransaction(func(tx *gorm.DB) error {
+		todo, err := s.todoRepository.GetTodoByID(ctx, tx, request.TodoID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkTodoAccess(ctx, tx, s.projectRepository, todo, userID, entity.ProjectEditor); err != nil {
+			return err
+		}
+
+		audience, err = todoAudience(ctx, tx, s.projectRepository, todo)
+		if err != nil {
+			return err
+		}
+
+		if err := checkVersion(request.Version, todo.Version); err != nil {
+			return err
+		}
+
+		var addItems []*entity.Tag
+		var removeItems []*entity.Tag
+
+		for _, item := range request.Items {
+			tag, err := s.tagRepository.GetTagByID(ctx, tx, item.ID)
+			if err != nil {
+				return err
+			}
+
+			if tag.UserID.String() != userID {
+				return echo.NewHTTPError(http.StatusNotFound, "Tag not found")
+			}
+
+			if item.Action == "add" {
+				addItems = append(addItems, tag)
+			} else if item.Action == "remove" {
+				removeItems = append(removeItems, tag)
+			} else {
+				return echo.NewHTTPError(http.StatusBadRequest, "Invalid action")
+			}
+		}
+
+		if len(addItems) > 0 {
+			if err := s.todoRepository.AddTags(ctx, tx, todo, addItems); err != nil {
+				return err
+			}
+		}
+
+		if len(removeItems) > 0 {
+			if err := s.todoRepository.RemoveTags(ctx, tx, todo, removeItems); err != nil {
+				return err
+			}
+		}
+
+		// Tags are part of the todo's representation, so changing them bumps
+		// its version like any other edit.
+		if len(addItems) > 0 || len(removeItems) > 0 {
+			return s.todoRepository.UpdateTodo(ctx, tx, todo)
+		}
+
+		return nil
+	}); err != nil {
 		return err
 	}
 
-	if err := s.cache.Del("todos:" + todo.ID.String()); err != nil {
+	if err := s.cache.Del("todos:" + request.TodoID); err != nil {
 		return err
 	}
 
-	if err := s.cache.Del("todos:all:" + userID); err != nil {
+	if err := delUserKeys(s.cache, "todos:all:", audience); err != nil {
 		return err
 	}
 
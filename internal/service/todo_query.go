@@ -0,0 +1,184 @@
+package service
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"github.com/sherwin-77/golang-todos/pkg/constants"
+)
+
+// todoSortFields whitelists the columns a client may sort todo lists by.
+var todoSortFields = map[string]string{
+	"created_at":   "created_at",
+	"updated_at":   "updated_at",
+	"title":        "title",
+	"is_completed": "is_completed",
+	"due_at":       "due_at",
+	"priority":     "priority",
+	"position":     "position",
+}
+
+type todoListCache struct {
+	Todos []entity.Todo `json:"todos"`
+	Total int64         `json:"total"`
+}
+
+func normalizePage(page int, perPage int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = int(constants.DefaultPerPage)
+	}
+	if perPage > int(constants.MaxPerPage) {
+		perPage = int(constants.MaxPerPage)
+	}
+
+	return page, perPage
+}
+
+// buildTodoOrder turns a comma separated sort parameter such as "-created_at,title"
+// into an ORDER BY clause. A leading "-" sorts descending.
+func buildTodoOrder(sort string) (string, error) {
+	if sort == "" {
+		return "created_at DESC, id", nil
+	}
+
+	var clauses []string
+	for _, field := range strings.Split(sort, ",") {
+		field = strings.TrimSpace(field)
+		direction := "ASC"
+		if strings.HasPrefix(field, "-") {
+			direction = "DESC"
+			field = field[1:]
+		}
+
+		column, ok := todoSortFields[field]
+		if !ok {
+			return "", echo.NewHTTPError(http.StatusBadRequest, "Invalid sort field: "+field)
+		}
+		clauses = append(clauses, column+" "+direction)
+	}
+
+	return strings.Join(append(clauses, "id"), ", "), nil
+}
+
+func buildTodoFilter(userID string, query dto.TodoQuery) (string, []interface{}) {
+	conditions := []string{repository.TodoAccessScope, "parent_id IS NULL"}
+	args := []interface{}{userID, userID, userID}
+
+	if query.IsCompleted != nil {
+		conditions = append(conditions, "is_completed = ?")
+		args = append(args, *query.IsCompleted)
+	}
+	if query.CreatedAfter != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, *query.CreatedAfter)
+	}
+	if query.CreatedBefore != nil {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, *query.CreatedBefore)
+	}
+	if query.Title != "" {
+		conditions = append(conditions, "title ILIKE ?")
+		args = append(args, "%"+escapeLike(query.Title)+"%")
+	}
+	if query.Tag != "" {
+		conditions = append(conditions, "id IN (SELECT tag_todos.todo_id FROM tag_todos JOIN tags ON tags.id = tag_todos.tag_id WHERE tags.user_id = ? AND tags.name = ?)")
+		args = append(args, userID, query.Tag)
+	}
+	if query.Priority != "" {
+		priority, _ := entity.ParsePriority(query.Priority)
+		conditions = append(conditions, "priority = ?")
+		args = append(args, priority)
+	}
+	if query.ProjectID == "inbox" {
+		conditions = append(conditions, "project_id IS NULL")
+	} else if query.ProjectID != "" {
+		conditions = append(conditions, "project_id = ?")
+		args = append(args, query.ProjectID)
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// todoQueryKey builds a deterministic cache key fragment for a normalized list query.
+func todoQueryKey(page int, perPage int, order string, query dto.TodoQuery) string {
+	values := url.Values{}
+	values.Set("page", strconv.Itoa(page))
+	values.Set("per_page", strconv.Itoa(perPage))
+	values.Set("order", order)
+	if query.IsCompleted != nil {
+		values.Set("is_completed", strconv.FormatBool(*query.IsCompleted))
+	}
+	if query.CreatedAfter != nil {
+		values.Set("created_after", query.CreatedAfter.UTC().Format(time.RFC3339Nano))
+	}
+	if query.CreatedBefore != nil {
+		values.Set("created_before", query.CreatedBefore.UTC().Format(time.RFC3339Nano))
+	}
+	if query.Title != "" {
+		values.Set("title", query.Title)
+	}
+	if query.Tag != "" {
+		values.Set("tag", query.Tag)
+	}
+	if query.ProjectID != "" {
+		values.Set("project_id", query.ProjectID)
+	}
+	if query.Priority != "" {
+		values.Set("priority", query.Priority)
+	}
+
+	return values.Encode()
+}
+
+func parsePriority(name string) (entity.Priority, error) {
+	priority, err := entity.ParsePriority(name)
+	if err != nil {
+		return priority, echo.NewHTTPError(http.StatusUnprocessableEntity, "Invalid priority: "+name)
+	}
+
+	return priority, nil
+}
+
+func startOfDay(t time.Time, location *time.Location) time.Time {
+	year, month, day := t.In(location).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, location)
+}
+
+func validateReminder(dueAt *time.Time, remindAt *time.Time) error {
+	if dueAt != nil && remindAt != nil && remindAt.After(*dueAt) {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, "RemindAt must not be after DueAt")
+	}
+
+	return nil
+}
+
+func sameTime(a *time.Time, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
+
+func sameID(a *uuid.UUID, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
@@ -6,14 +6,20 @@ import (
 	"errors"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/configs"
 	"github.com/sherwin-77/golang-todos/internal/entity"
 	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/repository"
 	"github.com/sherwin-77/golang-todos/internal/service"
+	"github.com/sherwin-77/golang-todos/pkg/patch"
 	mock_caches "github.com/sherwin-77/golang-todos/test/mock/pkg/caches"
 	mock_repository "github.com/sherwin-77/golang-todos/test/mock/repository"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"net/http"
 	"testing"
+	"time"
 )
 
 type TodoTestSuite struct {
@@ -21,6 +27,9 @@ type TodoTestSuite struct {
 	ctrl        *gomock.Controller
 	repo        *mock_repository.MockTodoRepository
 	userRepo    *mock_repository.MockUserRepository
+	tagRepo     *mock_repository.MockTagRepository
+	projectRepo *mock_repository.MockProjectRepository
+	notifyRepo  *mock_repository.MockNotificationRepository
 	cache       *mock_caches.MockCache
 	todoService service.TodoService
 }
@@ -29,8 +38,11 @@ func (s *TodoTestSuite) SetupTest() {
 	s.ctrl = gomock.NewController(s.T())
 	s.repo = mock_repository.NewMockTodoRepository(s.ctrl)
 	s.userRepo = mock_repository.NewMockUserRepository(s.ctrl)
+	s.tagRepo = mock_repository.NewMockTagRepository(s.ctrl)
+	s.projectRepo = mock_repository.NewMockProjectRepository(s.ctrl)
+	s.notifyRepo = mock_repository.NewMockNotificationRepository(s.ctrl)
 	s.cache = mock_caches.NewMockCache(s.ctrl)
-	s.todoService = service.NewTodoService(s.repo, s.userRepo, s.cache)
+	s.todoService = service.NewTodoService(s.repo, s.userRepo, s.tagRepo, s.projectRepo, s.notifyRepo, configs.NewAppValidator(), s.cache)
 }
 
 func TestTodoService(t *testing.T) {
@@ -39,58 +51,127 @@ func TestTodoService(t *testing.T) {
 
 func (s *TodoTestSuite) TestGetTodosByUserID() {
 	userID := uuid.New().String()
-	keyFindAll := "todos:all:" + userID
+	keyVersion := "todos:all:" + userID
+	keyFindAll := keyVersion + ":v1:order=created_at+DESC%2C+id&page=1&per_page=10"
+	scope := "(user_id = ? OR project_id IN (SELECT id FROM projects WHERE user_id = ? UNION SELECT project_id FROM project_members WHERE user_id = ? AND accepted_at IS NOT NULL)) AND parent_id IS NULL"
 	todos := make([]entity.Todo, 0)
-	marshalledData, _ := json.Marshal(todos)
+	marshalledData, _ := json.Marshal(map[string]interface{}{"todos": todos, "total": 0})
+
+	s.Run("Invalid sort field", func() {
+		var e *echo.HTTPError
+		result, meta, err := s.todoService.GetTodosByUserID(context.Background(), userID, dto.TodoQuery{Sort: "password"})
+
+		s.ErrorAs(err, &e)
+		s.Nil(result)
+		s.Nil(meta)
+	})
+
+	s.Run("Failed to set version cache", func() {
+		errorTest := errors.New("set cache error")
+		s.cache.EXPECT().Get(keyVersion).Return("")
+		s.cache.EXPECT().Set(keyVersion, gomock.Any(), gomock.Any()).Return(errorTest)
+		result, meta, err := s.todoService.GetTodosByUserID(context.Background(), userID, dto.TodoQuery{})
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+		s.Nil(meta)
+	})
 
 	s.Run("Failed unmarshal", func() {
+		s.cache.EXPECT().Get(keyVersion).Return("v1")
 		s.cache.EXPECT().Get(keyFindAll).Return("invalid")
-		result, err := s.todoService.GetTodosByUserID(context.Background(), userID)
+		result, meta, err := s.todoService.GetTodosByUserID(context.Background(), userID, dto.TodoQuery{})
 
 		s.Error(err)
 		s.Nil(result)
+		s.Nil(meta)
+	})
+
+	s.Run("Failed to count todos", func() {
+		errorTest := errors.New("count todos error")
+		s.cache.EXPECT().Get(keyVersion).Return("v1")
+		s.cache.EXPECT().Get(keyFindAll).Return("")
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().CountTodosFiltered(gomock.Any(), gomock.Any(), scope, userID, userID, userID).Return(int64(0), errorTest)
+		result, meta, err := s.todoService.GetTodosByUserID(context.Background(), userID, dto.TodoQuery{})
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+		s.Nil(meta)
 	})
 
 	s.Run("Failed to get todos", func() {
 		errorTest := errors.New("get todos error")
+		s.cache.EXPECT().Get(keyVersion).Return("v1")
 		s.cache.EXPECT().Get(keyFindAll).Return("")
 		s.repo.EXPECT().SingleTransaction().Return(nil)
-		s.repo.EXPECT().GetTodosByUserID(gomock.Any(), gomock.Any(), userID).Return(nil, errorTest)
-		result, err := s.todoService.GetTodosByUserID(context.Background(), userID)
+		s.repo.EXPECT().CountTodosFiltered(gomock.Any(), gomock.Any(), scope, userID, userID, userID).Return(int64(0), nil)
+		s.repo.EXPECT().GetTodosFiltered(gomock.Any(), gomock.Any(), 10, 0, "created_at DESC, id", scope, userID, userID, userID).Return(nil, errorTest)
+		result, meta, err := s.todoService.GetTodosByUserID(context.Background(), userID, dto.TodoQuery{})
 
 		s.ErrorIs(err, errorTest)
 		s.Nil(result)
+		s.Nil(meta)
 	})
 
 	s.Run("Failed to set cache", func() {
 		errorTest := errors.New("set cache error")
+		s.cache.EXPECT().Get(keyVersion).Return("v1")
 		s.cache.EXPECT().Get(keyFindAll).Return("")
 		s.repo.EXPECT().SingleTransaction().Return(nil)
-		s.repo.EXPECT().GetTodosByUserID(gomock.Any(), gomock.Any(), userID).Return(todos, nil)
+		s.repo.EXPECT().CountTodosFiltered(gomock.Any(), gomock.Any(), scope, userID, userID, userID).Return(int64(0), nil)
+		s.repo.EXPECT().GetTodosFiltered(gomock.Any(), gomock.Any(), 10, 0, "created_at DESC, id", scope, userID, userID, userID).Return(todos, nil)
 		s.cache.EXPECT().Set(keyFindAll, string(marshalledData), gomock.Any()).Return(errorTest)
-		result, err := s.todoService.GetTodosByUserID(context.Background(), userID)
+		result, meta, err := s.todoService.GetTodosByUserID(context.Background(), userID, dto.TodoQuery{})
 
 		s.ErrorIs(err, errorTest)
 		s.Nil(result)
+		s.Nil(meta)
 	})
 
 	s.Run("Successfully get todos", func() {
+		s.cache.EXPECT().Get(keyVersion).Return("v1")
 		s.cache.EXPECT().Get(keyFindAll).Return("")
 		s.repo.EXPECT().SingleTransaction().Return(nil)
-		s.repo.EXPECT().GetTodosByUserID(gomock.Any(), gomock.Any(), userID).Return(todos, nil)
+		s.repo.EXPECT().CountTodosFiltered(gomock.Any(), gomock.Any(), scope, userID, userID, userID).Return(int64(0), nil)
+		s.repo.EXPECT().GetTodosFiltered(gomock.Any(), gomock.Any(), 10, 0, "created_at DESC, id", scope, userID, userID, userID).Return(todos, nil)
 		s.cache.EXPECT().Set(keyFindAll, string(marshalledData), gomock.Any()).Return(nil)
-		result, err := s.todoService.GetTodosByUserID(context.Background(), userID)
+		result, meta, err := s.todoService.GetTodosByUserID(context.Background(), userID, dto.TodoQuery{})
+
+		s.Nil(err)
+		s.Equal(todos, result)
+		s.Equal(1, meta.Page)
+		s.Equal(10, meta.PerPage)
+		s.Equal(1, meta.LastPage)
+	})
+
+	s.Run("Successfully get filtered todos", func() {
+		isCompleted := true
+		query := dto.TodoQuery{Page: 3, PerPage: 500, IsCompleted: &isCompleted, Title: "50%", Sort: "-title"}
+		s.cache.EXPECT().Get(keyVersion).Return("v1")
+		s.cache.EXPECT().Get(gomock.Any()).Return("")
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().CountTodosFiltered(gomock.Any(), gomock.Any(), scope+" AND is_completed = ? AND title ILIKE ?", userID, userID, userID, true, `%50\%%`).Return(int64(250), nil)
+		s.repo.EXPECT().GetTodosFiltered(gomock.Any(), gomock.Any(), 100, 200, "title DESC, id", scope+" AND is_completed = ? AND title ILIKE ?", userID, userID, userID, true, `%50\%%`).Return(todos, nil)
+		s.cache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		result, meta, err := s.todoService.GetTodosByUserID(context.Background(), userID, query)
 
 		s.Nil(err)
 		s.Equal(todos, result)
+		s.Equal(3, meta.Page)
+		s.Equal(100, meta.PerPage)
+		s.Equal(3, meta.LastPage)
+		s.Equal(250, meta.Total)
 	})
 
 	s.Run("Successfully get todos from cache", func() {
+		s.cache.EXPECT().Get(keyVersion).Return("v1")
 		s.cache.EXPECT().Get(keyFindAll).Return(string(marshalledData))
-		result, err := s.todoService.GetTodosByUserID(context.Background(), userID)
+		result, meta, err := s.todoService.GetTodosByUserID(context.Background(), userID, dto.TodoQuery{})
 
 		s.Nil(err)
 		s.Equal(todos, result)
+		s.Equal(0, meta.Total)
 	})
 }
 
@@ -127,6 +208,7 @@ func (s *TodoTestSuite) TestGetTodoByID() {
 		s.cache.EXPECT().Get(keyFindTodo).Return("")
 		s.repo.EXPECT().SingleTransaction().Return(nil)
 		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+		s.repo.EXPECT().GetSubtaskProgress(gomock.Any(), gomock.Any(), []string{todoID}).Return(nil, nil)
 		s.cache.EXPECT().Set(keyFindTodo, string(marshalledData), gomock.Any()).Return(errorTest)
 		result, err := s.todoService.GetTodoByID(context.Background(), todoID, userID)
 
@@ -139,7 +221,9 @@ func (s *TodoTestSuite) TestGetTodoByID() {
 		s.cache.EXPECT().Get(keyFindTodo).Return("")
 		s.repo.EXPECT().SingleTransaction().Return(nil)
 		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+		s.repo.EXPECT().GetSubtaskProgress(gomock.Any(), gomock.Any(), []string{todoID}).Return(nil, nil)
 		s.cache.EXPECT().Set(keyFindTodo, string(marshalledData), gomock.Any()).Return(nil)
+		s.projectRepo.EXPECT().SingleTransaction().Return(nil)
 		result, err := s.todoService.GetTodoByID(context.Background(), todoID, uuid.NewString())
 
 		s.ErrorAs(err, &e)
@@ -150,6 +234,7 @@ func (s *TodoTestSuite) TestGetTodoByID() {
 		s.cache.EXPECT().Get(keyFindTodo).Return("")
 		s.repo.EXPECT().SingleTransaction().Return(nil)
 		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+		s.repo.EXPECT().GetSubtaskProgress(gomock.Any(), gomock.Any(), []string{todoID}).Return(nil, nil)
 		s.cache.EXPECT().Set(keyFindTodo, string(marshalledData), gomock.Any()).Return(nil)
 		result, err := s.todoService.GetTodoByID(context.Background(), todoID, userID)
 
@@ -166,12 +251,166 @@ func (s *TodoTestSuite) TestGetTodoByID() {
 	})
 }
 
+func (s *TodoTestSuite) TestGetOverdueTodos() {
+	userID := uuid.NewString()
+	todos := make([]entity.Todo, 0)
+	condition := "(" + repository.TodoAccessScope + " OR assignee_id = ?) AND parent_id IS NULL AND is_completed = ? AND due_at < ?"
+
+	s.Run("Failed to count todos", func() {
+		errorTest := errors.New("count todos error")
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().CountTodosFiltered(gomock.Any(), gomock.Any(), condition, userID, userID, userID, userID, false, gomock.Any()).Return(int64(0), errorTest)
+		result, meta, err := s.todoService.GetOverdueTodos(context.Background(), userID, dto.TodoDueQuery{})
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+		s.Nil(meta)
+	})
+
+	s.Run("Failed to get todos", func() {
+		errorTest := errors.New("get todos error")
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().CountTodosFiltered(gomock.Any(), gomock.Any(), condition, userID, userID, userID, userID, false, gomock.Any()).Return(int64(1), nil)
+		s.repo.EXPECT().GetTodosFiltered(gomock.Any(), gomock.Any(), 10, 0, "due_at, id", condition, userID, userID, userID, userID, false, gomock.Any()).Return(nil, errorTest)
+		result, meta, err := s.todoService.GetOverdueTodos(context.Background(), userID, dto.TodoDueQuery{})
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+		s.Nil(meta)
+	})
+
+	s.Run("Successfully get overdue todos", func() {
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().CountTodosFiltered(gomock.Any(), gomock.Any(), condition, userID, userID, userID, userID, false, gomock.Any()).Return(int64(0), nil)
+		s.repo.EXPECT().GetTodosFiltered(gomock.Any(), gomock.Any(), 10, 0, "due_at, id", condition, userID, userID, userID, userID, false, gomock.Any()).Return(todos, nil)
+		result, meta, err := s.todoService.GetOverdueTodos(context.Background(), userID, dto.TodoDueQuery{})
+
+		s.Nil(err)
+		s.Equal(todos, result)
+		s.Equal(0, meta.Total)
+	})
+}
+
+func (s *TodoTestSuite) TestGetTodayTodos() {
+	userID := uuid.NewString()
+	todos := make([]entity.Todo, 0)
+	user := &entity.User{Timezone: "Asia/Jakarta"}
+
+	s.Run("Failed to get user", func() {
+		errorTest := errors.New("get user error")
+		s.userRepo.EXPECT().SingleTransaction().Return(nil)
+		s.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), userID).Return(nil, errorTest)
+		result, meta, err := s.todoService.GetTodayTodos(context.Background(), userID, dto.TodoDueQuery{})
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+		s.Nil(meta)
+	})
+
+	s.Run("Successfully get today todos", func() {
+		location, _ := time.LoadLocation(user.Timezone)
+		var start, end time.Time
+		s.userRepo.EXPECT().SingleTransaction().Return(nil)
+		s.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), userID).Return(user, nil)
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().CountTodosFiltered(gomock.Any(), gomock.Any(), "("+repository.TodoAccessScope+" OR assignee_id = ?) AND parent_id IS NULL AND is_completed = ? AND due_at >= ? AND due_at < ?", userID, userID, userID, userID, false, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ interface{}, _ interface{}, args ...interface{}) (int64, error) {
+				start = args[5].(time.Time)
+				end = args[6].(time.Time)
+				return 0, nil
+			})
+		s.repo.EXPECT().GetTodosFiltered(gomock.Any(), gomock.Any(), 10, 0, "due_at, id", gomock.Any(), gomock.Any()).Return(todos, nil)
+		result, _, err := s.todoService.GetTodayTodos(context.Background(), userID, dto.TodoDueQuery{})
+
+		s.Nil(err)
+		s.Equal(todos, result)
+		s.Equal(location, start.Location())
+		s.Zero(start.Hour())
+		s.Equal(24*time.Hour, end.Sub(start))
+	})
+}
+
+func (s *TodoTestSuite) TestGetUpcomingTodos() {
+	userID := uuid.NewString()
+	todos := make([]entity.Todo, 0)
+	user := &entity.User{Timezone: "UTC"}
+	condition := "(" + repository.TodoAccessScope + " OR assignee_id = ?) AND parent_id IS NULL AND is_completed = ? AND due_at >= ? AND due_at < ?"
+
+	s.Run("Failed to get user", func() {
+		errorTest := errors.New("get user error")
+		s.userRepo.EXPECT().SingleTransaction().Return(nil)
+		s.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), userID).Return(nil, errorTest)
+		result, meta, err := s.todoService.GetUpcomingTodos(context.Background(), userID, dto.TodoDueQuery{})
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+		s.Nil(meta)
+	})
+
+	s.Run("Successfully get upcoming todos", func() {
+		var end time.Time
+		s.userRepo.EXPECT().SingleTransaction().Return(nil)
+		s.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), userID).Return(user, nil)
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().CountTodosFiltered(gomock.Any(), gomock.Any(), condition, userID, userID, userID, userID, false, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ interface{}, _ interface{}, args ...interface{}) (int64, error) {
+				end = args[6].(time.Time)
+				return 0, nil
+			})
+		s.repo.EXPECT().GetTodosFiltered(gomock.Any(), gomock.Any(), 10, 0, "due_at, id", condition, userID, userID, userID, userID, false, gomock.Any(), gomock.Any()).Return(todos, nil)
+		result, _, err := s.todoService.GetUpcomingTodos(context.Background(), userID, dto.TodoDueQuery{Days: 3})
+
+		s.Nil(err)
+		s.Equal(todos, result)
+		s.Equal(time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 4), end)
+	})
+}
+
 func (s *TodoTestSuite) TestCreateTodo() {
 	userID := uuid.New().String()
 	keyFindAll := "todos:all:" + userID
+	s.Run("Remind after due date", func() {
+		var e *echo.HTTPError
+		dueAt := time.Now()
+		remindAt := dueAt.Add(time.Hour)
+		result, err := s.todoService.CreateTodo(context.Background(), dto.TodoRequest{DueAt: &dueAt, RemindAt: &remindAt}, userID)
+
+		s.ErrorAs(err, &e)
+		s.Nil(result)
+	})
+
+	s.Run("Project user ID mismatch", func() {
+		var e *echo.HTTPError
+		project := &entity.Project{UserID: uuid.New()}
+		project.ID = uuid.New()
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.projectRepo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), project.ID.String()).Return(project, nil)
+		s.projectRepo.EXPECT().GetMember(gomock.Any(), gomock.Any(), project.ID.String(), userID).Return(nil, gorm.ErrRecordNotFound)
+		result, err := s.todoService.CreateTodo(context.Background(), dto.TodoRequest{ProjectID: project.ID.String()}, userID)
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusNotFound, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Project is archived", func() {
+		var e *echo.HTTPError
+		archivedAt := time.Now()
+		project := &entity.Project{UserID: uuid.MustParse(userID), ArchivedAt: &archivedAt}
+		project.ID = uuid.New()
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.projectRepo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), project.ID.String()).Return(project, nil)
+		result, err := s.todoService.CreateTodo(context.Background(), dto.TodoRequest{ProjectID: project.ID.String()}, userID)
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusUnprocessableEntity, e.Code)
+		s.Nil(result)
+	})
+
 	s.Run("Failed to create todo", func() {
 		errorTest := errors.New("create todo error")
 		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().NextPosition(gomock.Any(), gomock.Any(), gomock.Any()).Return(float64(1), nil)
 		s.repo.EXPECT().CreateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(errorTest)
 		result, err := s.todoService.CreateTodo(context.Background(), dto.TodoRequest{}, userID)
 
@@ -182,6 +421,7 @@ func (s *TodoTestSuite) TestCreateTodo() {
 	s.Run("Failed to delete cache", func() {
 		errorTest := errors.New("delete cache error")
 		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().NextPosition(gomock.Any(), gomock.Any(), gomock.Any()).Return(float64(1), nil)
 		s.repo.EXPECT().CreateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		s.cache.EXPECT().Del(keyFindAll).Return(errorTest)
 		result, err := s.todoService.CreateTodo(context.Background(), dto.TodoRequest{}, userID)
@@ -192,6 +432,7 @@ func (s *TodoTestSuite) TestCreateTodo() {
 
 	s.Run("Successfully create todo", func() {
 		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().NextPosition(gomock.Any(), gomock.Any(), gomock.Any()).Return(float64(1), nil)
 		s.repo.EXPECT().CreateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 		s.cache.EXPECT().Del(keyFindAll).Return(nil)
 		result, err := s.todoService.CreateTodo(context.Background(), dto.TodoRequest{}, userID)
@@ -199,6 +440,21 @@ func (s *TodoTestSuite) TestCreateTodo() {
 		s.Nil(err)
 		s.NotNil(result)
 	})
+
+	s.Run("Successfully create todo in project", func() {
+		project := &entity.Project{UserID: uuid.MustParse(userID)}
+		project.ID = uuid.New()
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.projectRepo.EXPECT().GetProjectByID(gomock.Any(), gomock.Any(), project.ID.String()).Return(project, nil)
+		s.repo.EXPECT().NextPosition(gomock.Any(), gomock.Any(), gomock.Any()).Return(float64(1), nil)
+		s.repo.EXPECT().CreateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		s.projectRepo.EXPECT().GetMemberUserIDs(gomock.Any(), gomock.Any(), project.ID.String()).Return([]string{userID}, nil)
+		s.cache.EXPECT().Del(keyFindAll).Return(nil)
+		result, err := s.todoService.CreateTodo(context.Background(), dto.TodoRequest{ProjectID: project.ID.String()}, userID)
+
+		s.Nil(err)
+		s.Equal(project.ID, *result.ProjectID)
+	})
 }
 
 func (s *TodoTestSuite) TestUpdateTodo() {
@@ -212,8 +468,11 @@ func (s *TodoTestSuite) TestUpdateTodo() {
 
 	s.Run("Failed to get todo", func() {
 		errorTest := errors.New("get todo error")
-		s.repo.EXPECT().SingleTransaction().Return(nil)
-		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(nil, errorTest)
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(nil, errorTest)
+
+			return f(&gorm.DB{})
+		})
 		result, err := s.todoService.UpdateTodo(context.Background(), dto.UpdateTodoRequest{
 			ID: todoID,
 		}, userID)
@@ -224,8 +483,11 @@ func (s *TodoTestSuite) TestUpdateTodo() {
 
 	s.Run("User ID mismatch", func() {
 		var e *echo.HTTPError
-		s.repo.EXPECT().SingleTransaction().Return(nil)
-		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(emptyTodo, nil)
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(emptyTodo, nil)
+
+			return f(&gorm.DB{})
+		})
 		result, err := s.todoService.UpdateTodo(context.Background(), dto.UpdateTodoRequest{
 			ID: todoID,
 		}, uuid.NewString())
@@ -236,9 +498,12 @@ func (s *TodoTestSuite) TestUpdateTodo() {
 
 	s.Run("Failed to update todo", func() {
 		errorTest := errors.New("update todo error")
-		s.repo.EXPECT().SingleTransaction().Return(nil)
-		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(emptyTodo, nil)
-		s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(errorTest)
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(emptyTodo, nil)
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(errorTest)
+
+			return f(&gorm.DB{})
+		})
 		result, err := s.todoService.UpdateTodo(context.Background(), dto.UpdateTodoRequest{
 			ID: todoID,
 		}, userID)
@@ -249,10 +514,13 @@ func (s *TodoTestSuite) TestUpdateTodo() {
 
 	s.Run("Failed to delete todo cache", func() {
 		errorTest := errors.New("delete todo cache error")
-		s.repo.EXPECT().SingleTransaction().Return(nil)
-		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(emptyTodo, nil)
-		s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
-		s.cache.EXPECT().Del("todos:" + todoID).Return(errorTest)
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(emptyTodo, nil)
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del(keyFindTodo).Return(errorTest)
 		result, err := s.todoService.UpdateTodo(context.Background(), dto.UpdateTodoRequest{
 			ID: todoID,
 		}, userID)
@@ -263,10 +531,14 @@ func (s *TodoTestSuite) TestUpdateTodo() {
 
 	s.Run("Failed to delete todos cache", func() {
 		errorTest := errors.New("delete todos cache error")
-		s.repo.EXPECT().SingleTransaction().Return(nil)
-		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(emptyTodo, nil)
-		s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
-		s.cache.EXPECT().Del(keyFindTodo).Return(errorTest)
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(emptyTodo, nil)
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del(keyFindTodo).Return(nil)
+		s.cache.EXPECT().Del(keyFindAll).Return(errorTest)
 		result, err := s.todoService.UpdateTodo(context.Background(), dto.UpdateTodoRequest{
 			ID: todoID,
 		}, userID)
@@ -275,21 +547,132 @@ func (s *TodoTestSuite) TestUpdateTodo() {
 		s.Nil(result)
 	})
 
+	s.Run("Stale version", func() {
+		todoRet := *emptyTodo
+		todoRet.Version = 2
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(&todoRet, nil)
+
+			return f(&gorm.DB{})
+		})
+		result, err := s.todoService.UpdateTodo(context.Background(), dto.UpdateTodoRequest{
+			ID:      todoID,
+			Title:   patch.Value("Todo"),
+			Version: 1,
+		}, userID)
+
+		s.ErrorIs(err, repository.ErrVersionConflict)
+		s.Nil(result)
+	})
+
+	s.Run("Clear title", func() {
+		var e *echo.HTTPError
+		result, err := s.todoService.UpdateTodo(context.Background(), dto.UpdateTodoRequest{
+			ID:    todoID,
+			Title: patch.Null[string](),
+		}, userID)
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusUnprocessableEntity, e.Code)
+		s.Nil(result)
+	})
+
 	s.Run("Successfully update todo", func() {
 		todoRet := *emptyTodo
-		s.repo.EXPECT().SingleTransaction().Return(nil)
-		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(&todoRet, nil)
-		s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(&todoRet, nil)
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+			return f(&gorm.DB{})
+		})
 		s.cache.EXPECT().Del(keyFindTodo).Return(nil)
 		s.cache.EXPECT().Del(keyFindAll).Return(nil)
 		result, err := s.todoService.UpdateTodo(context.Background(), dto.UpdateTodoRequest{
 			ID:    todoID,
-			Title: "Todo",
+			Title: patch.Value("Todo"),
 		}, userID)
 
 		s.Nil(err)
 		s.NotEqual(emptyTodo, result)
 	})
+
+	s.Run("Omitted fields are left untouched", func() {
+		dueAt := time.Now().Add(time.Hour)
+		todoRet := *emptyTodo
+		todoRet.Title = "Keep me"
+		todoRet.Description = "Clear me"
+		todoRet.DueAt = &dueAt
+		todoRet.Priority = entity.PriorityHigh
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(&todoRet, nil)
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del(keyFindTodo).Return(nil)
+		s.cache.EXPECT().Del(keyFindAll).Return(nil)
+		result, err := s.todoService.UpdateTodo(context.Background(), dto.UpdateTodoRequest{
+			ID:          todoID,
+			Description: patch.Null[string](),
+			DueAt:       patch.Null[time.Time](),
+		}, userID)
+
+		s.Nil(err)
+		s.Equal("Keep me", result.Title)
+		s.Equal(entity.PriorityHigh, result.Priority)
+		s.Empty(result.Description)
+		s.Nil(result.DueAt)
+	})
+
+	s.Run("Successfully complete todo with subtasks", func() {
+		todoRet := *emptyTodo
+		done := entity.Todo{IsCompleted: true}
+		done.ID = uuid.New()
+		open := entity.Todo{}
+		open.ID = uuid.New()
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(&todoRet, nil)
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			s.repo.EXPECT().GetSubtasks(gomock.Any(), gomock.Any(), todoID).Return([]entity.Todo{done, open}, nil)
+			s.repo.EXPECT().CompleteSubtasks(gomock.Any(), gomock.Any(), &todoRet).Return(nil)
+
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del(keyFindTodo).Return(nil)
+		s.cache.EXPECT().Del("todos:" + open.ID.String()).Return(nil)
+		s.cache.EXPECT().Del(keyFindAll).Return(nil)
+		result, err := s.todoService.UpdateTodo(context.Background(), dto.UpdateTodoRequest{
+			ID:               todoID,
+			IsCompleted:      patch.Value(true),
+			CompleteSubtasks: true,
+		}, userID)
+
+		s.Nil(err)
+		s.True(result.IsCompleted)
+	})
+
+	s.Run("Failed to complete subtasks", func() {
+		errorTest := errors.New("complete subtasks error")
+		todoRet := *emptyTodo
+		open := entity.Todo{}
+		open.ID = uuid.New()
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(&todoRet, nil)
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			s.repo.EXPECT().GetSubtasks(gomock.Any(), gomock.Any(), todoID).Return([]entity.Todo{open}, nil)
+			s.repo.EXPECT().CompleteSubtasks(gomock.Any(), gomock.Any(), &todoRet).Return(errorTest)
+
+			return f(&gorm.DB{})
+		})
+		result, err := s.todoService.UpdateTodo(context.Background(), dto.UpdateTodoRequest{
+			ID:               todoID,
+			IsCompleted:      patch.Value(true),
+			CompleteSubtasks: true,
+		}, userID)
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+	})
 }
 
 func (s *TodoTestSuite) TestDeleteTodo() {
@@ -303,63 +686,225 @@ func (s *TodoTestSuite) TestDeleteTodo() {
 
 	s.Run("Failed to get todo", func() {
 		errorTest := errors.New("get todo error")
-		s.repo.EXPECT().SingleTransaction().Return(nil)
-		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(nil, errorTest)
-		err := s.todoService.DeleteTodo(context.Background(), todoID, userID)
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(nil, errorTest)
+
+			return f(&gorm.DB{})
+		})
+		err := s.todoService.DeleteTodo(context.Background(), todoID, userID, 0)
 
 		s.ErrorIs(err, errorTest)
 	})
 
 	s.Run("User ID mismatch", func() {
 		var e *echo.HTTPError
-		s.repo.EXPECT().SingleTransaction().Return(nil)
-		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(emptyTodo, nil)
-		err := s.todoService.DeleteTodo(context.Background(), todoID, uuid.NewString())
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(emptyTodo, nil)
+
+			return f(&gorm.DB{})
+		})
+		err := s.todoService.DeleteTodo(context.Background(), todoID, uuid.NewString(), 0)
 
 		s.ErrorAs(err, &e)
 	})
 
+	s.Run("Stale version", func() {
+		todoRet := *emptyTodo
+		todoRet.Version = 2
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(&todoRet, nil)
+
+			return f(&gorm.DB{})
+		})
+		err := s.todoService.DeleteTodo(context.Background(), todoID, userID, 1)
+
+		s.ErrorIs(err, repository.ErrVersionConflict)
+	})
+
 	s.Run("Failed to delete todo", func() {
 		errorTest := errors.New("delete todo error")
-		s.repo.EXPECT().SingleTransaction().Return(nil)
-		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(emptyTodo, nil)
-		s.repo.EXPECT().DeleteTodo(gomock.Any(), gomock.Any(), emptyTodo).Return(errorTest)
-		err := s.todoService.DeleteTodo(context.Background(), todoID, userID)
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(emptyTodo, nil)
+			s.repo.EXPECT().GetSubtasks(gomock.Any(), gomock.Any(), todoID).Return(nil, nil)
+			s.repo.EXPECT().DeleteTodo(gomock.Any(), gomock.Any(), emptyTodo).Return(errorTest)
+
+			return f(&gorm.DB{})
+		})
+		err := s.todoService.DeleteTodo(context.Background(), todoID, userID, 0)
 
 		s.ErrorIs(err, errorTest)
 	})
 
 	s.Run("Failed to delete todo cache", func() {
 		errorTest := errors.New("delete todo cache error")
-		s.repo.EXPECT().SingleTransaction().Return(nil)
-		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(emptyTodo, nil)
-		s.repo.EXPECT().DeleteTodo(gomock.Any(), gomock.Any(), emptyTodo).Return(nil)
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(emptyTodo, nil)
+			s.repo.EXPECT().GetSubtasks(gomock.Any(), gomock.Any(), todoID).Return(nil, nil)
+			s.repo.EXPECT().DeleteTodo(gomock.Any(), gomock.Any(), emptyTodo).Return(nil)
+
+			return f(&gorm.DB{})
+		})
 		s.cache.EXPECT().Del(keyFindTodo).Return(errorTest)
-		err := s.todoService.DeleteTodo(context.Background(), todoID, userID)
+		err := s.todoService.DeleteTodo(context.Background(), todoID, userID, 0)
 
 		s.ErrorIs(err, errorTest)
 	})
 
 	s.Run("Failed to delete todos cache", func() {
 		errorTest := errors.New("delete todos cache error")
-		s.repo.EXPECT().SingleTransaction().Return(nil)
-		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(emptyTodo, nil)
-		s.repo.EXPECT().DeleteTodo(gomock.Any(), gomock.Any(), emptyTodo).Return(nil)
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(emptyTodo, nil)
+			s.repo.EXPECT().GetSubtasks(gomock.Any(), gomock.Any(), todoID).Return(nil, nil)
+			s.repo.EXPECT().DeleteTodo(gomock.Any(), gomock.Any(), emptyTodo).Return(nil)
+
+			return f(&gorm.DB{})
+		})
 		s.cache.EXPECT().Del(keyFindTodo).Return(nil)
 		s.cache.EXPECT().Del(keyFindAll).Return(errorTest)
-		err := s.todoService.DeleteTodo(context.Background(), todoID, userID)
+		err := s.todoService.DeleteTodo(context.Background(), todoID, userID, 0)
 
 		s.ErrorIs(err, errorTest)
 	})
 
 	s.Run("Successfully delete todo", func() {
-		s.repo.EXPECT().SingleTransaction().Return(nil)
-		s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(emptyTodo, nil)
-		s.repo.EXPECT().DeleteTodo(gomock.Any(), gomock.Any(), emptyTodo).Return(nil)
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(emptyTodo, nil)
+			s.repo.EXPECT().GetSubtasks(gomock.Any(), gomock.Any(), todoID).Return(nil, nil)
+			s.repo.EXPECT().DeleteTodo(gomock.Any(), gomock.Any(), emptyTodo).Return(nil)
+
+			return f(&gorm.DB{})
+		})
 		s.cache.EXPECT().Del(keyFindTodo).Return(nil)
 		s.cache.EXPECT().Del(keyFindAll).Return(nil)
-		err := s.todoService.DeleteTodo(context.Background(), todoID, userID)
+		err := s.todoService.DeleteTodo(context.Background(), todoID, userID, 0)
+
+		s.Nil(err)
+	})
+}
+
+func (s *TodoTestSuite) TestChangeTags() {
+	userID := uuid.NewString()
+	todoID := uuid.NewString()
+	todo := &entity.Todo{UserID: uuid.MustParse(userID)}
+	todo.ID = uuid.MustParse(todoID)
+
+	tagAdd := &entity.Tag{Name: "work", UserID: uuid.MustParse(userID)}
+	tagAdd.ID = uuid.New()
+
+	tagRemove := &entity.Tag{Name: "home", UserID: uuid.MustParse(userID)}
+	tagRemove.ID = uuid.New()
+
+	request := dto.ChangeTagRequest{
+		TodoID: todoID,
+		Items: []dto.ChangeTagRequestItem{
+			{
+				ID:     tagAdd.ID.String(),
+				Action: "add",
+			},
+			{
+				ID:     tagRemove.ID.String(),
+				Action: "remove",
+			},
+		},
+	}
+
+	s.Run("Failed to get todo", func() {
+		errorTest := errors.New("get todo error")
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(nil, errorTest)
+
+			return f(&gorm.DB{})
+		})
+
+		err := s.todoService.ChangeTags(context.Background(), request, userID)
+		s.ErrorIs(err, errorTest)
+	})
+
+	s.Run("User ID mismatch", func() {
+		var e *echo.HTTPError
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+
+			return f(&gorm.DB{})
+		})
+
+		err := s.todoService.ChangeTags(context.Background(), request, uuid.NewString())
+		s.ErrorAs(err, &e)
+	})
+
+	s.Run("Version mismatch", func() {
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+
+			return f(&gorm.DB{})
+		})
+		staleRequest := request
+		staleRequest.Version = todo.Version + 1
+
+		err := s.todoService.ChangeTags(context.Background(), staleRequest, userID)
+		s.ErrorIs(err, repository.ErrVersionConflict)
+	})
+
+	s.Run("Failed to bump version", func() {
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+			s.tagRepo.EXPECT().GetTagByID(gomock.Any(), gomock.Any(), tagAdd.ID.String()).Return(tagAdd, nil)
+			s.tagRepo.EXPECT().GetTagByID(gomock.Any(), gomock.Any(), tagRemove.ID.String()).Return(tagRemove, nil)
+			s.repo.EXPECT().AddTags(gomock.Any(), gomock.Any(), todo, []*entity.Tag{tagAdd}).Return(nil)
+			s.repo.EXPECT().RemoveTags(gomock.Any(), gomock.Any(), todo, []*entity.Tag{tagRemove}).Return(nil)
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), todo).Return(repository.ErrVersionConflict)
+
+			return f(&gorm.DB{})
+		})
+
+		err := s.todoService.ChangeTags(context.Background(), request, userID)
+		s.ErrorIs(err, repository.ErrVersionConflict)
+	})
+
+	s.Run("Tag belongs to another user", func() {
+		var e *echo.HTTPError
+		foreignTag := &entity.Tag{UserID: uuid.New()}
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+			s.tagRepo.EXPECT().GetTagByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(foreignTag, nil)
+
+			return f(&gorm.DB{})
+		})
+
+		err := s.todoService.ChangeTags(context.Background(), request, userID)
+		s.ErrorAs(err, &e)
+	})
+
+	s.Run("Failed add tag", func() {
+		errorTest := errors.New("add tag error")
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+			s.tagRepo.EXPECT().GetTagByID(gomock.Any(), gomock.Any(), tagAdd.ID.String()).Return(tagAdd, nil)
+			s.tagRepo.EXPECT().GetTagByID(gomock.Any(), gomock.Any(), tagRemove.ID.String()).Return(tagRemove, nil)
+			s.repo.EXPECT().AddTags(gomock.Any(), gomock.Any(), todo, gomock.Any()).Return(errorTest)
+
+			return f(&gorm.DB{})
+		})
+
+		err := s.todoService.ChangeTags(context.Background(), request, userID)
+		s.ErrorIs(err, errorTest)
+	})
 
+	s.Run("Successfully change tags", func() {
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(todo, nil)
+			s.tagRepo.EXPECT().GetTagByID(gomock.Any(), gomock.Any(), tagAdd.ID.String()).Return(tagAdd, nil)
+			s.tagRepo.EXPECT().GetTagByID(gomock.Any(), gomock.Any(), tagRemove.ID.String()).Return(tagRemove, nil)
+			s.repo.EXPECT().AddTags(gomock.Any(), gomock.Any(), todo, []*entity.Tag{tagAdd}).Return(nil)
+			s.repo.EXPECT().RemoveTags(gomock.Any(), gomock.Any(), todo, []*entity.Tag{tagRemove}).Return(nil)
+			s.repo.EXPECT().UpdateTodo(gomock.Any(), gomock.Any(), todo).Return(nil)
+
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("todos:" + todoID).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + userID).Return(nil)
+
+		err := s.todoService.ChangeTags(context.Background(), request, userID)
 		s.Nil(err)
 	})
 }
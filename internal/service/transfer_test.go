@@ -0,0 +1,279 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func (s *TodoTestSuite) TestExportTodos() {
+	userID := uuid.NewString()
+	createdAt := time.Date(2024, 12, 1, 9, 0, 0, 0, time.UTC)
+	dueAt := time.Date(2024, 12, 20, 17, 0, 0, 0, time.UTC)
+	todo := entity.Todo{
+		Title:       "Buy milk",
+		Description: "Two bottles\nSemi-skimmed",
+		DueAt:       &dueAt,
+		Priority:    entity.PriorityHigh,
+		UserID:      uuid.MustParse(userID),
+		Tags:        []*entity.Tag{{Name: "home"}},
+	}
+	todo.ID = uuid.MustParse("0193b4e6-7f00-7000-8000-000000000001")
+	todo.CreatedAt = createdAt
+	todo.UpdatedAt = createdAt
+	todo.Version = 2
+
+	expectTodos := func(todos []entity.Todo, err error) {
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTodosFiltered(gomock.Any(), gomock.Any(), 200, 0, "created_at, id", repository.TodoAccessScope, userID, userID, userID).Return(todos, err)
+	}
+
+	s.Run("Failed to get todos", func() {
+		errorTest := errors.New("get todos error")
+		expectTodos(nil, errorTest)
+		var out bytes.Buffer
+		err := s.todoService.ExportTodos(context.Background(), userID, dto.FormatJSON, &out)
+
+		s.ErrorIs(err, errorTest)
+	})
+
+	s.Run("Export JSON", func() {
+		expectTodos([]entity.Todo{todo, todo}, nil)
+		var out bytes.Buffer
+		err := s.todoService.ExportTodos(context.Background(), userID, dto.FormatJSON, &out)
+
+		s.Nil(err)
+		var result []map[string]interface{}
+		s.Nil(json.Unmarshal(out.Bytes(), &result))
+		s.Len(result, 2)
+		s.Equal("Buy milk", result[0]["title"])
+		s.Equal("high", result[0]["priority"])
+	})
+
+	s.Run("Export empty JSON", func() {
+		expectTodos(nil, nil)
+		var out bytes.Buffer
+		err := s.todoService.ExportTodos(context.Background(), userID, dto.FormatJSON, &out)
+
+		s.Nil(err)
+		s.Equal("[]\n", out.String())
+	})
+
+	s.Run("Export CSV", func() {
+		expectTodos([]entity.Todo{todo}, nil)
+		var out bytes.Buffer
+		err := s.todoService.ExportTodos(context.Background(), userID, dto.FormatCSV, &out)
+
+		s.Nil(err)
+		lines := strings.SplitN(out.String(), "\n", 2)
+		s.Equal("id,title,description,is_completed,due_at,remind_at,reminded_at,project_id,parent_id,priority,position,recurrence,recurrence_id,occurrence,user_id,version,created_at,updated_at,deleted_at,tags", lines[0])
+		s.Contains(lines[1], `Buy milk,"Two bottles`)
+		s.Contains(lines[1], ",high,0,,,0,"+userID+",2,2024-12-01T09:00:00Z,2024-12-01T09:00:00Z,,home\n")
+	})
+
+	s.Run("Export Markdown", func() {
+		expectTodos([]entity.Todo{todo}, nil)
+		var out bytes.Buffer
+		err := s.todoService.ExportTodos(context.Background(), userID, dto.FormatMarkdown, &out)
+
+		s.Nil(err)
+		s.Contains(out.String(), "# Todos\n\n- [ ] Buy milk\n  - id: "+todo.ID.String()+"\n  - description: Two bottles\\nSemi-skimmed\n  - due_at: 2024-12-20T17:00:00Z\n")
+		s.Contains(out.String(), "  - tags: home\n")
+		s.NotContains(out.String(), "remind_at")
+	})
+
+	s.Run("Export todo.txt", func() {
+		completed := todo
+		completed.IsCompleted = true
+		completed.UpdatedAt = createdAt.AddDate(0, 0, 2)
+		expectTodos([]entity.Todo{todo, completed}, nil)
+		var out bytes.Buffer
+		err := s.todoService.ExportTodos(context.Background(), userID, dto.FormatTodoTxt, &out)
+
+		s.Nil(err)
+		s.Equal(
+			"(B) 2024-12-01 Buy milk @home due:2024-12-20T17:00:00Z id:"+todo.ID.String()+"\n"+
+				"x 2024-12-03 2024-12-01 Buy milk @home pri:B due:2024-12-20T17:00:00Z id:"+todo.ID.String()+"\n",
+			out.String(),
+		)
+	})
+}
+
+func (s *TodoTestSuite) TestImportTodos() {
+	userID := uuid.NewString()
+	home := entity.Tag{Name: "Home", UserID: uuid.MustParse(userID)}
+	home.ID = uuid.New()
+
+	expectTags := func() {
+		s.tagRepo.EXPECT().SingleTransaction().Return(nil)
+		s.tagRepo.EXPECT().GetTagsByUserID(gomock.Any(), gomock.Any(), userID).Return([]entity.Tag{home}, nil)
+	}
+
+	s.Run("Malformed file", func() {
+		var e *echo.HTTPError
+		result, err := s.todoService.ImportTodos(context.Background(), userID, dto.FormatJSON, strings.NewReader(`[{"title": "Buy milk"`))
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusBadRequest, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("CSV without a title column", func() {
+		var e *echo.HTTPError
+		result, err := s.todoService.ImportTodos(context.Background(), userID, dto.FormatCSV, strings.NewReader("name\nBuy milk\n"))
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusBadRequest, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Import CSV with row errors and subtasks", func() {
+		parentID := uuid.NewString()
+		file := "id,title,due_at,parent_id,priority,tags\n" +
+			parentID + ",Groceries,,,high,home\n" +
+			",Bad date,tomorrow,,,\n" +
+			",,,,,\n" +
+			",Milk,," + parentID + ",,\"home, errands\"\n" +
+			",Orphan,," + uuid.NewString() + ",,\n"
+		var created []*entity.Todo
+
+		expectTags()
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().NextPosition(gomock.Any(), gomock.Any(), gomock.Any()).Return(float64(1), nil).Times(2)
+			s.tagRepo.EXPECT().CreateTag(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *gorm.DB, tag *entity.Tag) error {
+				s.Equal("errands", tag.Name)
+				s.Equal("#808080", tag.Color)
+				tag.ID = uuid.New()
+				return nil
+			})
+			s.repo.EXPECT().CreateTodo(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *gorm.DB, todo *entity.Todo) error {
+				todo.ID = uuid.New()
+				created = append(created, todo)
+				return nil
+			}).Times(2)
+
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("todos:all:"+userID, "tags:all:"+userID).Return(nil)
+		result, err := s.todoService.ImportTodos(context.Background(), userID, dto.FormatCSV, strings.NewReader(file))
+
+		s.Nil(err)
+		s.Equal(2, result.Imported)
+		s.Equal(3, result.Failed)
+		s.Equal([]dto.ImportRowError{
+			{Row: 2, Errors: []string{"due_at is not a valid RFC 3339 time"}},
+			{Row: 3, Errors: []string{"Title is required"}},
+			{Row: 5, Errors: []string{"Parent todo is not part of the import"}},
+		}, result.Errors)
+		s.Len(created, 2)
+		s.Equal(entity.PriorityHigh, created[0].Priority)
+		s.Equal([]*entity.Tag{&home}, created[0].Tags)
+		s.NotEqual(parentID, created[0].ID.String())
+		s.Equal(&created[0].ID, created[1].ParentID)
+		s.Len(created[1].Tags, 2)
+	})
+
+	s.Run("Import todo.txt", func() {
+		file := "(A) 2024-12-01 Call mom at 10:30 +family @phone due:2024-12-20T17:00:00Z\n\n" +
+			"x 2024-12-03 2024-12-01 Pay rent pri:C\n"
+		var created []*entity.Todo
+
+		expectTags()
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().NextPosition(gomock.Any(), gomock.Any(), gomock.Any()).Return(float64(1), nil).Times(2)
+			s.tagRepo.EXPECT().CreateTag(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			s.repo.EXPECT().CreateTodo(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *gorm.DB, todo *entity.Todo) error {
+				created = append(created, todo)
+				return nil
+			}).Times(2)
+
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("todos:all:"+userID, "tags:all:"+userID).Return(nil)
+		result, err := s.todoService.ImportTodos(context.Background(), userID, dto.FormatTodoTxt, strings.NewReader(file))
+
+		s.Nil(err)
+		s.Equal(2, result.Imported)
+		s.Equal("Call mom at 10:30 +family", created[0].Title)
+		s.Equal(entity.PriorityUrgent, created[0].Priority)
+		s.Equal("phone", created[0].Tags[0].Name)
+		s.Equal(time.Date(2024, 12, 20, 17, 0, 0, 0, time.UTC), created[0].DueAt.UTC())
+		s.Equal("Pay rent", created[1].Title)
+		s.True(created[1].IsCompleted)
+		s.Equal(entity.PriorityMedium, created[1].Priority)
+	})
+
+	s.Run("Markdown export imports back", func() {
+		dueAt := time.Date(2024, 12, 20, 17, 0, 0, 0, time.UTC)
+		remindAt := dueAt.Add(-time.Hour)
+		todo := entity.Todo{
+			Title:       `Back\up`,
+			Description: "Line one\nLine two",
+			IsCompleted: true,
+			DueAt:       &dueAt,
+			RemindAt:    &remindAt,
+			Recurrence:  "FREQ=WEEKLY",
+			Priority:    entity.PriorityLow,
+			Tags:        []*entity.Tag{{Name: "Home"}},
+		}
+		todo.ID = uuid.New()
+
+		var file bytes.Buffer
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetTodosFiltered(gomock.Any(), gomock.Any(), 200, 0, gomock.Any(), gomock.Any(), userID).Return([]entity.Todo{todo}, nil)
+		s.Nil(s.todoService.ExportTodos(context.Background(), userID, dto.FormatMarkdown, &file))
+
+		var created *entity.Todo
+		expectTags()
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().NextPosition(gomock.Any(), gomock.Any(), gomock.Any()).Return(float64(1), nil)
+			s.repo.EXPECT().CreateTodo(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *gorm.DB, todo *entity.Todo) error {
+				created = todo
+				return nil
+			})
+
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("todos:all:"+userID, "tags:all:"+userID).Return(nil)
+		result, err := s.todoService.ImportTodos(context.Background(), userID, dto.FormatMarkdown, &file)
+
+		s.Nil(err)
+		s.Equal(1, result.Imported)
+		s.Equal(todo.Title, created.Title)
+		s.Equal(todo.Description, created.Description)
+		s.True(created.IsCompleted)
+		s.True(dueAt.Equal(*created.DueAt))
+		s.True(remindAt.Equal(*created.RemindAt))
+		s.Equal(todo.Recurrence, created.Recurrence)
+		s.Equal(created.ID, *created.RecurrenceID)
+		s.Equal(entity.PriorityLow, created.Priority)
+		s.Equal([]*entity.Tag{&home}, created.Tags)
+	})
+
+	s.Run("Failed to create todo", func() {
+		errorTest := errors.New("create todo error")
+		expectTags()
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().NextPosition(gomock.Any(), gomock.Any(), gomock.Any()).Return(float64(1), nil)
+			s.repo.EXPECT().CreateTodo(gomock.Any(), gomock.Any(), gomock.Any()).Return(errorTest)
+
+			return f(&gorm.DB{})
+		})
+		result, err := s.todoService.ImportTodos(context.Background(), userID, dto.FormatJSON, strings.NewReader(`[{"title": "Buy milk"}]`))
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+	})
+}
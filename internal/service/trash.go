@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/pkg/response"
+	"gorm.io/gorm"
+)
+
+// GetTrash lists the user's trashed todos, most recently deleted first.
+func (s *todoService) GetTrash(ctx context.Context, userID string, query dto.TrashQuery) ([]entity.Todo, *response.Meta, error) {
+	page, perPage := normalizePage(query.Page, query.PerPage)
+	db := s.todoRepository.SingleTransaction()
+
+	total, err := s.todoRepository.CountDeletedTodos(ctx, db, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	todos, err := s.todoRepository.GetDeletedTodos(ctx, db, userID, perPage, (page-1)*perPage)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return todos, response.NewMeta(page, perPage, int(total)), nil
+}
+
+// getTrashedTodo loads a trashed todo the user may edit.
+func (s *todoService) getTrashedTodo(ctx context.Context, tx *gorm.DB, id string, userID string) (*entity.Todo, error) {
+	todo, err := s.todoRepository.GetDeletedTodoByID(ctx, tx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, echo.NewHTTPError(http.StatusNotFound, "Todo not found in trash")
+		}
+		return nil, err
+	}
+
+	if err := checkTodoAccess(ctx, tx, s.projectRepository, todo, userID, entity.ProjectEditor); err != nil {
+		return nil, err
+	}
+
+	return todo, nil
+}
+
+func (s *todoService) RestoreTodo(ctx context.Context, id string, userID string) (*entity.Todo, error) {
+	var todo *entity.Todo
+	var audience []string
+
+	if err := s.todoRepository.WithTransaction(func(tx *gorm.DB) error {
+		trashed, err := s.getTrashedTodo(ctx, tx, id, userID)
+		if err != nil {
+			return err
+		}
+
+		if trashed.ParentID != nil {
+			if _, err := s.todoRepository.GetTodoByID(ctx, tx, trashed.ParentID.String()); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return echo.NewHTTPError(http.StatusUnprocessableEntity, "Parent todo is in the trash")
+				}
+				return err
+			}
+		}
+
+		if err := s.todoRepository.RestoreTodo(ctx, tx, trashed); err != nil {
+			return err
+		}
+
+		todo, err = s.todoRepository.GetTodoByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		audience, err = todoAudience(ctx, tx, s.projectRepository, todo)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if todo.ParentID != nil {
+		if err := s.cache.Del("todos:" + todo.ParentID.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := delUserKeys(s.cache, "todos:all:", audience); err != nil {
+		return nil, err
+	}
+
+	return todo, nil
+}
+
+func (s *todoService) PurgeTodo(ctx context.Context, id string, userID string) error {
+	db := s.todoRepository.SingleTransaction()
+
+	todo, err := s.getTrashedTodo(ctx, db, id, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.todoRepository.PurgeTodo(ctx, db, todo)
+}
+
+func (s *todoService) EmptyTrash(ctx context.Context, userID string) error {
+	db := s.todoRepository.SingleTransaction()
+
+	_, err := s.todoRepository.PurgeDeletedTodos(ctx, db, "user_id = ?", userID)
+	return err
+}
@@ -0,0 +1,154 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func (s *TodoTestSuite) TestGetTrash() {
+	userID := uuid.NewString()
+
+	s.Run("Failed to count trash", func() {
+		errorTest := errors.New("count trash error")
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().CountDeletedTodos(gomock.Any(), gomock.Any(), userID).Return(int64(0), errorTest)
+		result, meta, err := s.todoService.GetTrash(context.Background(), userID, dto.TrashQuery{})
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+		s.Nil(meta)
+	})
+
+	s.Run("Successfully get trash", func() {
+		todos := []entity.Todo{{Title: "Deleted"}}
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().CountDeletedTodos(gomock.Any(), gomock.Any(), userID).Return(int64(11), nil)
+		s.repo.EXPECT().GetDeletedTodos(gomock.Any(), gomock.Any(), userID, 10, 10).Return(todos, nil)
+		result, meta, err := s.todoService.GetTrash(context.Background(), userID, dto.TrashQuery{Page: 2})
+
+		s.Nil(err)
+		s.Equal(todos, result)
+		s.Equal(2, meta.Page)
+		s.Equal(11, meta.Total)
+	})
+}
+
+func (s *TodoTestSuite) TestRestoreTodo() {
+	userID := uuid.NewString()
+	todoID := uuid.NewString()
+	parentID := uuid.New()
+	trashed := &entity.Todo{UserID: uuid.MustParse(userID), DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}}
+	trashed.ID = uuid.MustParse(todoID)
+
+	s.Run("Todo not in trash", func() {
+		var e *echo.HTTPError
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetDeletedTodoByID(gomock.Any(), gomock.Any(), todoID).Return(nil, gorm.ErrRecordNotFound)
+
+			return f(&gorm.DB{})
+		})
+		result, err := s.todoService.RestoreTodo(context.Background(), todoID, userID)
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusNotFound, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("User ID mismatch", func() {
+		var e *echo.HTTPError
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetDeletedTodoByID(gomock.Any(), gomock.Any(), todoID).Return(trashed, nil)
+
+			return f(&gorm.DB{})
+		})
+		result, err := s.todoService.RestoreTodo(context.Background(), todoID, uuid.NewString())
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusNotFound, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Parent is in the trash", func() {
+		var e *echo.HTTPError
+		subtask := *trashed
+		subtask.ParentID = &parentID
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetDeletedTodoByID(gomock.Any(), gomock.Any(), todoID).Return(&subtask, nil)
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), parentID.String()).Return(nil, gorm.ErrRecordNotFound)
+
+			return f(&gorm.DB{})
+		})
+		result, err := s.todoService.RestoreTodo(context.Background(), todoID, userID)
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusUnprocessableEntity, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Successfully restore subtask", func() {
+		subtask := *trashed
+		subtask.ParentID = &parentID
+		restored := subtask
+		restored.DeletedAt = gorm.DeletedAt{}
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetDeletedTodoByID(gomock.Any(), gomock.Any(), todoID).Return(&subtask, nil)
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), parentID.String()).Return(&entity.Todo{}, nil)
+			s.repo.EXPECT().RestoreTodo(gomock.Any(), gomock.Any(), &subtask).Return(nil)
+			s.repo.EXPECT().GetTodoByID(gomock.Any(), gomock.Any(), todoID).Return(&restored, nil)
+
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("todos:" + parentID.String()).Return(nil)
+		s.cache.EXPECT().Del("todos:all:" + userID).Return(nil)
+		result, err := s.todoService.RestoreTodo(context.Background(), todoID, userID)
+
+		s.Nil(err)
+		s.Equal(&restored, result)
+	})
+}
+
+func (s *TodoTestSuite) TestPurgeTodo() {
+	userID := uuid.NewString()
+	todoID := uuid.NewString()
+	trashed := &entity.Todo{UserID: uuid.MustParse(userID)}
+	trashed.ID = uuid.MustParse(todoID)
+
+	s.Run("User ID mismatch", func() {
+		var e *echo.HTTPError
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetDeletedTodoByID(gomock.Any(), gomock.Any(), todoID).Return(trashed, nil)
+		err := s.todoService.PurgeTodo(context.Background(), todoID, uuid.NewString())
+
+		s.ErrorAs(err, &e)
+	})
+
+	s.Run("Successfully purge todo", func() {
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetDeletedTodoByID(gomock.Any(), gomock.Any(), todoID).Return(trashed, nil)
+		s.repo.EXPECT().PurgeTodo(gomock.Any(), gomock.Any(), trashed).Return(nil)
+		err := s.todoService.PurgeTodo(context.Background(), todoID, userID)
+
+		s.Nil(err)
+	})
+}
+
+func (s *TodoTestSuite) TestEmptyTrash() {
+	userID := uuid.NewString()
+
+	s.Run("Successfully empty trash", func() {
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().PurgeDeletedTodos(gomock.Any(), gomock.Any(), "user_id = ?", userID).Return(int64(3), nil)
+		err := s.todoService.EmptyTrash(context.Background(), userID)
+
+		s.Nil(err)
+	})
+}
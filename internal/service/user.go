@@ -3,15 +3,19 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/configs"
 	"github.com/sherwin-77/golang-todos/internal/entity"
 	"github.com/sherwin-77/golang-todos/internal/http/dto"
 	"github.com/sherwin-77/golang-todos/internal/repository"
 	"github.com/sherwin-77/golang-todos/pkg/caches"
+	"github.com/sherwin-77/golang-todos/pkg/notifier"
 	"github.com/sherwin-77/golang-todos/pkg/tokens"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
@@ -22,21 +26,44 @@ type UserService interface {
 	GetUserByID(ctx context.Context, id string) (*entity.User, error)
 	CreateUser(ctx context.Context, request dto.UserRequest) (*entity.User, error)
 	UpdateUser(ctx context.Context, request dto.UpdateUserRequest) (*entity.User, error)
-	DeleteUser(ctx context.Context, id string) error
-	Login(ctx context.Context, request dto.LoginRequest) (string, error)
+	DeleteUser(ctx context.Context, id string, version int) error
+	Login(ctx context.Context, request dto.LoginRequest) (*dto.LoginResponse, error)
+	LoginMFA(ctx context.Context, request dto.MFALoginRequest) (*dto.TokenResponse, error)
+	Refresh(ctx context.Context, request dto.RefreshRequest) (*dto.TokenResponse, error)
+	Logout(ctx context.Context, claims *tokens.JWTCustomClaims) error
+	LogoutAll(ctx context.Context, userID string) error
 	Register(ctx context.Context, request dto.UserRequest) (*entity.User, bool, error)
 	ChangeRole(ctx context.Context, request dto.ChangeRoleRequest) error
 }
 
 type userService struct {
-	tokenService   tokens.TokenService
-	userRepository repository.UserRepository
-	roleRepository repository.RoleRepository
-	cache          caches.Cache
+	tokenService           tokens.TokenService
+	denylist               tokens.Denylist
+	userRepository         repository.UserRepository
+	roleRepository         repository.RoleRepository
+	refreshTokenRepository repository.RefreshTokenRepository
+	userTokenRepository    repository.UserTokenRepository
+	recoveryCodeRepository repository.RecoveryCodeRepository
+	settingRepository      repository.SettingRepository
+	cache                  caches.Cache
+	mailer                 notifier.Notifier
+	config                 configs.AuthConfig
 }
 
-func NewUserService(tokenService tokens.TokenService, userRepository repository.UserRepository, roleRepository repository.RoleRepository, cache caches.Cache) UserService {
-	return &userService{tokenService, userRepository, roleRepository, cache}
+func NewUserService(
+	tokenService tokens.TokenService,
+	denylist tokens.Denylist,
+	userRepository repository.UserRepository,
+	roleRepository repository.RoleRepository,
+	refreshTokenRepository repository.RefreshTokenRepository,
+	userTokenRepository repository.UserTokenRepository,
+	recoveryCodeRepository repository.RecoveryCodeRepository,
+	settingRepository repository.SettingRepository,
+	cache caches.Cache,
+	mailer notifier.Notifier,
+	config configs.AuthConfig,
+) UserService {
+	return &userService{tokenService, denylist, userRepository, roleRepository, refreshTokenRepository, userTokenRepository, recoveryCodeRepository, settingRepository, cache, mailer, config}
 }
 
 func (s *userService) GetUsers(ctx context.Context) ([]entity.User, error) {
@@ -96,6 +123,7 @@ func (s *userService) CreateUser(ctx context.Context, request dto.UserRequest) (
 	user := &entity.User{
 		Username: request.Username,
 		Email:    request.Email,
+		Timezone: defaultTimezone(request.Timezone),
 	}
 
 	if err := s.userRepository.CreateUser(ctx, db, user); err != nil {
@@ -109,20 +137,35 @@ func (s *userService) CreateUser(ctx context.Context, request dto.UserRequest) (
 }
 
 func (s *userService) UpdateUser(ctx context.Context, request dto.UpdateUserRequest) (*entity.User, error) {
+	if err := requireValue(request.Email, "Email"); err != nil {
+		return nil, err
+	}
+	if err := requireValue(request.Username, "Username"); err != nil {
+		return nil, err
+	}
+	if err := requireValue(request.Password, "Password"); err != nil {
+		return nil, err
+	}
+	if err := requireValue(request.Timezone, "Timezone"); err != nil {
+		return nil, err
+	}
+
 	db := s.userRepository.SingleTransaction()
 	user, err := s.userRepository.GetUserByID(ctx, db, request.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	if request.Email != "" {
-		user.Email = request.Email
-	}
-	if request.Username != "" {
-		user.Username = request.Username
+	if err := checkVersion(request.Version, user.Version); err != nil {
+		return nil, err
 	}
-	if request.Password != "" {
-		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
+
+	previousEmail := user.Email
+	request.Email.Apply(&user.Email)
+	request.Username.Apply(&user.Username)
+	request.Timezone.Apply(&user.Timezone)
+	if request.Password.Set {
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(request.Password.Value), bcrypt.DefaultCost)
 		if err != nil {
 			return nil, err
 		}
@@ -130,10 +173,30 @@ func (s *userService) UpdateUser(ctx context.Context, request dto.UpdateUserRequ
 		user.Password = string(hashedPassword)
 	}
 
+	emailChanged := user.Email != previousEmail
+	if emailChanged {
+		user.EmailVerifiedAt = nil
+	}
+
 	if err := s.userRepository.UpdateUser(ctx, db, user); err != nil {
 		return nil, err
 	}
 
+	if emailChanged {
+		message, err := verificationMessage(ctx, db, s.userTokenRepository, s.config, user)
+		if err != nil {
+			return nil, err
+		}
+		deliver(s.mailer, message)
+	}
+
+	// Sessions started with the old password must not outlive it.
+	if request.Password.Set {
+		if err := revokeSessions(ctx, db, s.userRepository, s.refreshTokenRepository, user.ID.String()); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := s.cache.Del("users:" + user.ID.String()); err != nil {
 		return nil, err
 	}
@@ -145,13 +208,17 @@ func (s *userService) UpdateUser(ctx context.Context, request dto.UpdateUserRequ
 	return user, nil
 }
 
-func (s *userService) DeleteUser(ctx context.Context, id string) error {
+func (s *userService) DeleteUser(ctx context.Context, id string, version int) error {
 	db := s.userRepository.SingleTransaction()
 	user, err := s.userRepository.GetUserByID(ctx, db, id)
 	if err != nil {
 		return err
 	}
 
+	if err := checkVersion(version, user.Version); err != nil {
+		return err
+	}
+
 	if err := s.userRepository.DeleteUser(ctx, db, user); err != nil {
 		return err
 	}
@@ -208,7 +275,11 @@ func (s *userService) ChangeRole(ctx context.Context, request dto.ChangeRoleRequ
 	})
 }
 
-func (s *userService) Login(ctx context.Context, request dto.LoginRequest) (string, error) {
+// Login checks the credentials and starts a new token family with a
+// short-lived access token and a refresh token. Users who have two-factor
+// authentication, or whom the policy requires to have it, get an MFA challenge
+// instead.
+func (s *userService) Login(ctx context.Context, request dto.LoginRequest) (*dto.LoginResponse, error) {
 	db := s.userRepository.SingleTransaction()
 	user, err := s.userRepository.GetUserByEmail(ctx, db, request.Email)
 	var userPassword string
@@ -219,32 +290,276 @@ func (s *userService) Login(ctx context.Context, request dto.LoginRequest) (stri
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(userPassword), []byte(request.Password)); err != nil || user == nil {
-		return "", echo.NewHTTPError(http.StatusUnauthorized, "Invalid email or password")
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Invalid email or password")
 	}
-	expiredTime := time.Now().Add(24 * time.Hour)
+
+	if err := checkVerified(s.config, user); err != nil {
+		return nil, err
+	}
+
+	var enrollmentRequired bool
+	if user.TOTPEnabledAt == nil {
+		enrollmentRequired, err = mfaRequired(ctx, db, s.userRepository, s.settingRepository, user.ID.String())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if user.TOTPEnabledAt != nil || enrollmentRequired {
+		challenge, err := s.mfaChallenge(user, enrollmentRequired)
+		if err != nil {
+			return nil, err
+		}
+
+		return &dto.LoginResponse{MFA: challenge}, nil
+	}
+
+	var token *dto.TokenResponse
+	if err := s.refreshTokenRepository.WithTransaction(func(tx *gorm.DB) error {
+		token, err = s.startSession(ctx, tx, user)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.LoginResponse{TokenResponse: token}, nil
+}
+
+// LoginMFA finishes a login that needed a second factor by exchanging its
+// mfa_pending token and a TOTP or recovery code for a new token family. The
+// mfa_pending token works once.
+func (s *userService) LoginMFA(ctx context.Context, request dto.MFALoginRequest) (*dto.TokenResponse, error) {
+	claims, err := s.tokenService.ValidateToken(request.Token)
+	if err != nil || claims.Purpose != tokens.PurposeMFAPending {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Invalid or expired MFA token")
+	}
+
+	revoked, err := s.denylist.IsRevoked(claims)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Invalid or expired MFA token")
+	}
+
+	var token *dto.TokenResponse
+	if err := s.userRepository.WithTransaction(func(tx *gorm.DB) error {
+		user, err := s.userRepository.GetUserByID(ctx, tx, claims.ID)
+		if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && user.TokenVersion != claims.Version) {
+			return echo.NewHTTPError(http.StatusUnauthorized, "Invalid or expired MFA token")
+		}
+		if err != nil {
+			return err
+		}
+
+		if user.TOTPEnabledAt == nil {
+			return echo.NewHTTPError(http.StatusForbidden, "Set up two-factor authentication first")
+		}
+
+		if err := verifySecondFactor(ctx, tx, s.userRepository, s.recoveryCodeRepository, s.cache, user, request.Code); err != nil {
+			return err
+		}
+
+		token, err = s.startSession(ctx, tx, user)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := s.denylist.Revoke(claims); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// Refresh exchanges a refresh token for a new pair in the same family. Each
+// refresh token works once: presenting a used one again means it was copied,
+// so the whole family is revoked and the user has to log in again.
+func (s *userService) Refresh(ctx context.Context, request dto.RefreshRequest) (*dto.TokenResponse, error) {
+	var token *dto.TokenResponse
+	var reused bool
+
+	if err := s.refreshTokenRepository.WithTransaction(func(tx *gorm.DB) error {
+		refreshToken, err := s.refreshTokenRepository.GetRefreshTokenByHash(ctx, tx, tokens.HashToken(request.RefreshToken))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return echo.NewHTTPError(http.StatusUnauthorized, "Invalid refresh token")
+		}
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if refreshToken.RevokedAt != nil || !now.Before(refreshToken.ExpiresAt) {
+			return echo.NewHTTPError(http.StatusUnauthorized, "Invalid refresh token")
+		}
+
+		// The revocation has to be committed, so the error is returned only
+		// after the transaction.
+		if refreshToken.UsedAt != nil {
+			reused = true
+			return s.refreshTokenRepository.RevokeFamily(ctx, tx, refreshToken.FamilyID.String(), now)
+		}
+
+		refreshToken.UsedAt = &now
+		if err := s.refreshTokenRepository.UpdateRefreshToken(ctx, tx, refreshToken); err != nil {
+			return err
+		}
+
+		user, err := s.userRepository.GetUserByID(ctx, tx, refreshToken.UserID.String())
+		if err != nil {
+			return err
+		}
+
+		if err := checkVerified(s.config, user); err != nil {
+			return err
+		}
+
+		// Sessions started before the policy applied to the user end here.
+		if user.TOTPEnabledAt == nil {
+			required, err := mfaRequired(ctx, tx, s.userRepository, s.settingRepository, user.ID.String())
+			if err != nil {
+				return err
+			}
+			if required {
+				return echo.NewHTTPError(http.StatusForbidden, "Two-factor authentication is required, log in again to set it up")
+			}
+		}
+
+		token, err = s.issueTokens(ctx, tx, user, refreshToken.FamilyID, now)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if reused {
+		return nil, echo.NewHTTPError(http.StatusUnauthorized, "Invalid refresh token")
+	}
+
+	return token, nil
+}
+
+// Logout ends the session of the given access token: the token is denied
+// until it expires and its refresh token family is revoked.
+func (s *userService) Logout(ctx context.Context, claims *tokens.JWTCustomClaims) error {
+	if claims.SessionID != "" {
+		db := s.refreshTokenRepository.SingleTransaction()
+		if err := s.refreshTokenRepository.RevokeFamily(ctx, db, claims.SessionID, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	return s.denylist.Revoke(claims)
+}
+
+// LogoutAll ends every session of the user.
+func (s *userService) LogoutAll(ctx context.Context, userID string) error {
+	return revokeSessions(ctx, s.refreshTokenRepository.SingleTransaction(), s.userRepository, s.refreshTokenRepository, userID)
+}
+
+// revokeSessions revokes every refresh token of the user and moves the user
+// to a new token version, which denies all access tokens issued so far.
+func revokeSessions(ctx context.Context, tx *gorm.DB, userRepository repository.UserRepository, refreshTokenRepository repository.RefreshTokenRepository, userID string) error {
+	if err := refreshTokenRepository.RevokeUserRefreshTokens(ctx, tx, userID, time.Now()); err != nil {
+		return err
+	}
+
+	return userRepository.ChangeTokenVersion(ctx, tx, userID)
+}
+
+// startSession starts a new token family for the user.
+func (s *userService) startSession(ctx context.Context, tx *gorm.DB, user *entity.User) (*dto.TokenResponse, error) {
+	now := time.Now()
+	if err := s.refreshTokenRepository.DeleteExpiredRefreshTokens(ctx, tx, user.ID.String(), now); err != nil {
+		return nil, err
+	}
+
+	return s.issueTokens(ctx, tx, user, uuid.New(), now)
+}
+
+// mfaChallenge signs the short-lived mfa_pending token that Login returns
+// instead of tokens when a second factor is needed.
+func (s *userService) mfaChallenge(user *entity.User, enrollmentRequired bool) (*dto.MFAChallenge, error) {
+	now := time.Now()
+	expiresAt := now.Add(s.config.MFATokenTTL)
 	token, err := s.tokenService.GenerateAccessToken(tokens.JWTCustomClaims{
 		ID:       user.ID.String(),
 		Username: user.Username,
+		Version:  user.TokenVersion,
+		Purpose:  tokens.PurposeMFAPending,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expiredTime),
+			ID:        uuid.NewString(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
 		},
 	})
+	if err != nil {
+		return nil, err
+	}
 
+	return &dto.MFAChallenge{
+		Token:              token,
+		ExpiresAt:          expiresAt,
+		EnrollmentRequired: enrollmentRequired,
+	}, nil
+}
+
+// issueTokens signs an access token for the user and stores the hash of a new
+// refresh token in the given family.
+func (s *userService) issueTokens(ctx context.Context, tx *gorm.DB, user *entity.User, familyID uuid.UUID, now time.Time) (*dto.TokenResponse, error) {
+	accessExpiresAt := now.Add(s.config.AccessTokenTTL)
+	accessToken, err := s.tokenService.GenerateAccessToken(tokens.JWTCustomClaims{
+		ID:        user.ID.String(),
+		Username:  user.Username,
+		SessionID: familyID.String(),
+		Version:   user.TokenVersion,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(accessExpiresAt),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := tokens.NewOpaqueToken()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return token, nil
+	stored := &entity.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: tokens.HashToken(refreshToken),
+		ExpiresAt: now.Add(s.config.RefreshTokenTTL),
+	}
+	if err := s.refreshTokenRepository.CreateRefreshToken(ctx, tx, stored); err != nil {
+		return nil, err
+	}
+
+	return &dto.TokenResponse{
+		TokenType:             "Bearer",
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessExpiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: stored.ExpiresAt,
+	}, nil
 }
 
+// Register creates the user and mails a link to verify their email. The
+// returned bool reports whether no user is admin yet, in which case the user
+// becomes admin once they verify theirs unless someone else does first.
 func (s *userService) Register(ctx context.Context, request dto.UserRequest) (*entity.User, bool, error) {
 	user := &entity.User{
 		Username: request.Username,
 		Email:    request.Email,
+		Timezone: defaultTimezone(request.Timezone),
 	}
 	var isFirstUser bool
+	var message notifier.Message
 	if err := s.userRepository.WithTransaction(func(tx *gorm.DB) error {
-		users, err := s.userRepository.GetUsersFiltered(ctx, tx, 1, 0, "id", "email != ?", request.Email)
+		hasAdmin, err := s.userRepository.HasAdmin(ctx, tx)
 		if err != nil {
 			return err
 		}
@@ -256,34 +571,23 @@ func (s *userService) Register(ctx context.Context, request dto.UserRequest) (*e
 		if err := s.userRepository.CreateUser(ctx, tx, user); err != nil {
 			return err
 		}
-		if len(users) == 0 {
-			isFirstUser = true
-			roles, err := s.roleRepository.GetRolesFiltered(ctx, tx, 1, 0, "id", "auth_level >= 3")
-			if err != nil {
-				return err
-			}
-			var role *entity.Role
-			if len(roles) == 0 {
-				role = &entity.Role{
-					Name:      "Admin",
-					AuthLevel: 3,
-				}
-				if err := s.roleRepository.CreateRole(ctx, tx, role); err != nil {
-					return err
-				}
-			} else {
-				role = &roles[0]
-			}
-
-			if err := s.userRepository.AddRoles(ctx, tx, user, []*entity.Role{role}); err != nil {
-				return err
-			}
-		}
+		isFirstUser = !hasAdmin
 
-		return nil
+		message, err = verificationMessage(ctx, tx, s.userTokenRepository, s.config, user)
+		return err
 	}); err != nil {
 		return nil, isFirstUser, err
 	}
 
+	deliver(s.mailer, message)
+
 	return user, isFirstUser, nil
 }
+
+func defaultTimezone(timezone string) string {
+	if timezone == "" {
+		return "UTC"
+	}
+
+	return timezone
+}
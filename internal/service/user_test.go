@@ -6,17 +6,26 @@ import (
 	"errors"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/configs"
 	"github.com/sherwin-77/golang-todos/internal/entity"
 	"github.com/sherwin-77/golang-todos/internal/http/dto"
 	"github.com/sherwin-77/golang-todos/internal/service"
+	"github.com/sherwin-77/golang-todos/pkg/notifier"
+	"github.com/sherwin-77/golang-todos/pkg/patch"
+	"github.com/sherwin-77/golang-todos/pkg/tokens"
+	"github.com/sherwin-77/golang-todos/pkg/totp"
 	mock_caches "github.com/sherwin-77/golang-todos/test/mock/pkg/caches"
+	mock_notifier "github.com/sherwin-77/golang-todos/test/mock/pkg/notifier"
 	mock_tokens "github.com/sherwin-77/golang-todos/test/mock/pkg/tokens"
 	mock_repository "github.com/sherwin-77/golang-todos/test/mock/repository"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 )
 
 type UserTestSuite struct {
@@ -24,7 +33,13 @@ type UserTestSuite struct {
 	ctrl         *gomock.Controller
 	repo         *mock_repository.MockUserRepository
 	roleRepo     *mock_repository.MockRoleRepository
+	refreshRepo  *mock_repository.MockRefreshTokenRepository
+	tokenRepo    *mock_repository.MockUserTokenRepository
+	recoveryRepo *mock_repository.MockRecoveryCodeRepository
+	settingRepo  *mock_repository.MockSettingRepository
+	mailer       *mock_notifier.MockNotifier
 	tokenService *mock_tokens.MockTokenService
+	denylist     *mock_tokens.MockDenylist
 	cache        *mock_caches.MockCache
 	userService  service.UserService
 }
@@ -33,9 +48,22 @@ func (s *UserTestSuite) SetupTest() {
 	s.ctrl = gomock.NewController(s.T())
 	s.repo = mock_repository.NewMockUserRepository(s.ctrl)
 	s.roleRepo = mock_repository.NewMockRoleRepository(s.ctrl)
+	s.refreshRepo = mock_repository.NewMockRefreshTokenRepository(s.ctrl)
+	s.tokenRepo = mock_repository.NewMockUserTokenRepository(s.ctrl)
+	s.recoveryRepo = mock_repository.NewMockRecoveryCodeRepository(s.ctrl)
+	s.settingRepo = mock_repository.NewMockSettingRepository(s.ctrl)
+	s.mailer = mock_notifier.NewMockNotifier(s.ctrl)
 	s.tokenService = mock_tokens.NewMockTokenService(s.ctrl)
+	s.denylist = mock_tokens.NewMockDenylist(s.ctrl)
 	s.cache = mock_caches.NewMockCache(s.ctrl)
-	s.userService = service.NewUserService(s.tokenService, s.repo, s.roleRepo, s.cache)
+	s.userService = service.NewUserService(s.tokenService, s.denylist, s.repo, s.roleRepo, s.refreshRepo, s.tokenRepo, s.recoveryRepo, s.settingRepo, s.cache, s.mailer, configs.AuthConfig{
+		AccessTokenTTL:       15 * time.Minute,
+		MFATokenTTL:          5 * time.Minute,
+		RefreshTokenTTL:      24 * time.Hour,
+		EmailVerification:    configs.EmailVerificationSoft,
+		EmailVerificationTTL: 48 * time.Hour,
+		EmailVerificationURL: "https://todos.example.com/api/v1/verify-email",
+	})
 }
 
 func TestUserService(t *testing.T) {
@@ -217,6 +245,28 @@ func (s *UserTestSuite) TestUpdateUser() {
 		s.Nil(result)
 	})
 
+	s.Run("Clear email", func() {
+		var e *echo.HTTPError
+		result, err := s.userService.UpdateUser(context.Background(), dto.UpdateUserRequest{
+			ID:    userId,
+			Email: patch.Null[string](),
+		})
+
+		s.ErrorAs(err, &e)
+		s.Nil(result)
+	})
+
+	s.Run("Clear timezone", func() {
+		var e *echo.HTTPError
+		result, err := s.userService.UpdateUser(context.Background(), dto.UpdateUserRequest{
+			ID:       userId,
+			Timezone: patch.Null[string](),
+		})
+
+		s.ErrorAs(err, &e)
+		s.Nil(result)
+	})
+
 	s.Run("Failed to update user", func() {
 		userRet := *emptyUser
 		errorTest := errors.New("update user error")
@@ -226,7 +276,7 @@ func (s *UserTestSuite) TestUpdateUser() {
 		s.repo.EXPECT().UpdateUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(errorTest)
 		result, err := s.userService.UpdateUser(context.Background(), dto.UpdateUserRequest{
 			ID:       userId,
-			Username: "admin",
+			Username: patch.Value("admin"),
 		})
 
 		s.ErrorIs(err, errorTest)
@@ -243,7 +293,7 @@ func (s *UserTestSuite) TestUpdateUser() {
 		s.cache.EXPECT().Del("users:" + userId).Return(errorTest)
 		result, err := s.userService.UpdateUser(context.Background(), dto.UpdateUserRequest{
 			ID:       userId,
-			Username: "admin",
+			Username: patch.Value("admin"),
 		})
 
 		s.ErrorIs(err, errorTest)
@@ -261,7 +311,7 @@ func (s *UserTestSuite) TestUpdateUser() {
 		s.cache.EXPECT().Del("users:all").Return(errorTest)
 		result, err := s.userService.UpdateUser(context.Background(), dto.UpdateUserRequest{
 			ID:       userId,
-			Username: "admin",
+			Username: patch.Value("admin"),
 		})
 
 		s.ErrorIs(err, errorTest)
@@ -269,21 +319,59 @@ func (s *UserTestSuite) TestUpdateUser() {
 	})
 
 	s.Run("Update user successfully", func() {
+		sent := make(chan notifier.Message, 1)
+		verifiedAt := time.Now()
 		userRet := *emptyUser
+		userRet.EmailVerifiedAt = &verifiedAt
 		s.repo.EXPECT().SingleTransaction().Return(nil)
 		s.repo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), userId).Return(&userRet, nil)
 		s.repo.EXPECT().UpdateUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		s.tokenRepo.EXPECT().DeleteUserTokens(gomock.Any(), gomock.Any(), userId, entity.UserTokenEmailVerification).Return(nil)
+		s.tokenRepo.EXPECT().CreateUserToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		s.mailer.EXPECT().Notify(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, message notifier.Message) error {
+			sent <- message
+			return nil
+		})
+		s.refreshRepo.EXPECT().RevokeUserRefreshTokens(gomock.Any(), gomock.Any(), userId, gomock.Any()).Return(nil)
+		s.repo.EXPECT().ChangeTokenVersion(gomock.Any(), gomock.Any(), userId).Return(nil)
 		s.cache.EXPECT().Del("users:" + userId).Return(nil)
 		s.cache.EXPECT().Del("users:all").Return(nil)
 		result, err := s.userService.UpdateUser(context.Background(), dto.UpdateUserRequest{
 			ID:       userId,
-			Username: "admin",
-			Email:    "admin@example.com",
-			Password: "admin#1234",
+			Username: patch.Value("admin"),
+			Email:    patch.Value("admin@example.com"),
+			Password: patch.Value("admin#1234"),
 		})
 
 		s.Nil(err)
 		s.NotEqual(emptyUser, result)
+		s.Nil(result.EmailVerifiedAt)
+		s.Equal("admin@example.com", (<-sent).Recipient)
+	})
+
+	s.Run("Omitted fields are left untouched", func() {
+		userRet := *emptyUser
+		verifiedAt := time.Now()
+		userRet.Username = "admin"
+		userRet.Email = "admin@example.com"
+		userRet.EmailVerifiedAt = &verifiedAt
+		userRet.Password = "hashed"
+		userRet.Timezone = "Asia/Jakarta"
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), userId).Return(&userRet, nil)
+		s.repo.EXPECT().UpdateUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		s.cache.EXPECT().Del("users:" + userId).Return(nil)
+		s.cache.EXPECT().Del("users:all").Return(nil)
+		result, err := s.userService.UpdateUser(context.Background(), dto.UpdateUserRequest{
+			ID:    userId,
+			Email: patch.Value("admin@example.com"),
+		})
+
+		s.Nil(err)
+		s.Equal("admin", result.Username)
+		s.Equal("hashed", result.Password)
+		s.NotNil(result.EmailVerifiedAt)
+		s.Equal("Asia/Jakarta", result.Timezone)
 	})
 }
 
@@ -296,7 +384,7 @@ func (s *UserTestSuite) TestDeleteUser() {
 		errorTest := errors.New("get user error")
 		s.repo.EXPECT().SingleTransaction().Return(nil)
 		s.repo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), userID).Return(nil, errorTest)
-		err := s.userService.DeleteUser(context.Background(), userID)
+		err := s.userService.DeleteUser(context.Background(), userID, 0)
 
 		s.ErrorIs(err, errorTest)
 	})
@@ -306,7 +394,7 @@ func (s *UserTestSuite) TestDeleteUser() {
 		s.repo.EXPECT().SingleTransaction().Return(nil)
 		s.repo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), userID).Return(emptyUser, nil)
 		s.repo.EXPECT().DeleteUser(gomock.Any(), gomock.Any(), emptyUser).Return(errorTest)
-		err := s.userService.DeleteUser(context.Background(), userID)
+		err := s.userService.DeleteUser(context.Background(), userID, 0)
 
 		s.ErrorIs(err, errorTest)
 	})
@@ -317,7 +405,7 @@ func (s *UserTestSuite) TestDeleteUser() {
 		s.repo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), userID).Return(emptyUser, nil)
 		s.repo.EXPECT().DeleteUser(gomock.Any(), gomock.Any(), emptyUser).Return(nil)
 		s.cache.EXPECT().Del("users:" + userID).Return(errorTest)
-		err := s.userService.DeleteUser(context.Background(), userID)
+		err := s.userService.DeleteUser(context.Background(), userID, 0)
 
 		s.ErrorIs(err, errorTest)
 	})
@@ -329,7 +417,7 @@ func (s *UserTestSuite) TestDeleteUser() {
 		s.repo.EXPECT().DeleteUser(gomock.Any(), gomock.Any(), emptyUser).Return(nil)
 		s.cache.EXPECT().Del("users:" + userID).Return(nil)
 		s.cache.EXPECT().Del("users:all").Return(errorTest)
-		err := s.userService.DeleteUser(context.Background(), userID)
+		err := s.userService.DeleteUser(context.Background(), userID, 0)
 
 		s.ErrorIs(err, errorTest)
 	})
@@ -340,7 +428,7 @@ func (s *UserTestSuite) TestDeleteUser() {
 		s.repo.EXPECT().DeleteUser(gomock.Any(), gomock.Any(), emptyUser).Return(nil)
 		s.cache.EXPECT().Del("users:" + userID).Return(nil)
 		s.cache.EXPECT().Del("users:all").Return(nil)
-		err := s.userService.DeleteUser(context.Background(), userID)
+		err := s.userService.DeleteUser(context.Background(), userID, 0)
 
 		s.Nil(err)
 	})
@@ -464,71 +552,441 @@ func (s *UserTestSuite) TestLogin() {
 		})
 
 		s.ErrorAs(err, &e)
-		s.Empty(result)
+		s.Nil(result)
 	})
 
-	s.Run("Login successfully", func() {
+	s.Run("Unverified email in strict mode", func() {
+		var e *echo.HTTPError
+		userService := service.NewUserService(s.tokenService, s.denylist, s.repo, s.roleRepo, s.refreshRepo, s.tokenRepo, s.recoveryRepo, s.settingRepo, s.cache, s.mailer, configs.AuthConfig{
+			EmailVerification: configs.EmailVerificationStrict,
+		})
 		pass, _ := bcrypt.GenerateFromPassword([]byte("admin"), bcrypt.DefaultCost)
 		s.repo.EXPECT().SingleTransaction().Return(nil)
 		s.repo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any(), gomock.Any()).Return(&entity.User{
 			Email:    "admin",
 			Password: string(pass),
 		}, nil)
-		s.tokenService.EXPECT().GenerateAccessToken(gomock.Any()).Return("token", nil)
+		result, err := userService.Login(context.Background(), dto.LoginRequest{
+			Email:    "admin",
+			Password: "admin",
+		})
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusForbidden, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Login successfully", func() {
+		var stored *entity.RefreshToken
+		pass, _ := bcrypt.GenerateFromPassword([]byte("admin"), bcrypt.DefaultCost)
+		user := &entity.User{
+			Email:        "admin",
+			Password:     string(pass),
+			TokenVersion: "v2",
+		}
+		user.ID = uuid.New()
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any(), gomock.Any()).Return(user, nil)
+		s.settingRepo.EXPECT().GetSetting(gomock.Any(), gomock.Any(), entity.SettingMFARequiredLevel).Return(nil, gorm.ErrRecordNotFound)
+		s.refreshRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.refreshRepo.EXPECT().DeleteExpiredRefreshTokens(gomock.Any(), gomock.Any(), user.ID.String(), gomock.Any()).Return(nil)
+			s.tokenService.EXPECT().GenerateAccessToken(gomock.Any()).DoAndReturn(func(claims tokens.JWTCustomClaims) (string, error) {
+				s.Equal(user.ID.String(), claims.ID)
+				s.Equal("v2", claims.Version)
+				s.NotEmpty(claims.SessionID)
+				s.NotEmpty(claims.RegisteredClaims.ID)
+				return "token", nil
+			})
+			s.refreshRepo.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *gorm.DB, token *entity.RefreshToken) error {
+				stored = token
+				return nil
+			})
+			return f(&gorm.DB{})
+		})
 		result, err := s.userService.Login(context.Background(), dto.LoginRequest{
 			Email:    "admin",
 			Password: "admin",
 		})
 
 		s.Nil(err)
-		s.NotEmpty(result)
+		s.Equal("token", result.AccessToken)
+		s.WithinDuration(time.Now().Add(15*time.Minute), result.AccessTokenExpiresAt, time.Minute)
+		s.NotEmpty(result.RefreshToken)
+		s.Equal(tokens.HashToken(result.RefreshToken), stored.TokenHash)
+		s.Equal(user.ID, stored.UserID)
+		s.Equal(result.RefreshTokenExpiresAt, stored.ExpiresAt)
+		s.Nil(result.MFA)
+	})
+
+	s.Run("Two-factor authentication enabled", func() {
+		pass, _ := bcrypt.GenerateFromPassword([]byte("admin"), bcrypt.DefaultCost)
+		enabledAt := time.Now()
+		user := &entity.User{
+			Email:         "admin",
+			Password:      string(pass),
+			TOTPEnabledAt: &enabledAt,
+			TokenVersion:  "v2",
+		}
+		user.ID = uuid.New()
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any(), gomock.Any()).Return(user, nil)
+		s.tokenService.EXPECT().GenerateAccessToken(gomock.Any()).DoAndReturn(func(claims tokens.JWTCustomClaims) (string, error) {
+			s.Equal(user.ID.String(), claims.ID)
+			s.Equal(tokens.PurposeMFAPending, claims.Purpose)
+			s.Equal("v2", claims.Version)
+			s.Empty(claims.SessionID)
+			return "mfa-token", nil
+		})
+		result, err := s.userService.Login(context.Background(), dto.LoginRequest{
+			Email:    "admin",
+			Password: "admin",
+		})
+
+		s.Nil(err)
+		s.Nil(result.TokenResponse)
+		s.Equal("mfa-token", result.MFA.Token)
+		s.False(result.MFA.EnrollmentRequired)
+		s.WithinDuration(time.Now().Add(5*time.Minute), result.MFA.ExpiresAt, time.Minute)
+	})
+
+	s.Run("Policy requires enrollment", func() {
+		pass, _ := bcrypt.GenerateFromPassword([]byte("admin"), bcrypt.DefaultCost)
+		user := &entity.User{
+			Email:    "admin",
+			Password: string(pass),
+		}
+		user.ID = uuid.New()
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any(), gomock.Any()).Return(user, nil)
+		s.settingRepo.EXPECT().GetSetting(gomock.Any(), gomock.Any(), entity.SettingMFARequiredLevel).Return(&entity.Setting{Value: "2"}, nil)
+		s.repo.EXPECT().GetAuthLevel(gomock.Any(), gomock.Any(), user.ID.String()).Return(2, nil)
+		s.tokenService.EXPECT().GenerateAccessToken(gomock.Any()).Return("mfa-token", nil)
+		result, err := s.userService.Login(context.Background(), dto.LoginRequest{
+			Email:    "admin",
+			Password: "admin",
+		})
+
+		s.Nil(err)
+		s.Nil(result.TokenResponse)
+		s.True(result.MFA.EnrollmentRequired)
 	})
 }
 
-func (s *UserTestSuite) TestRegister() {
-	userReq := dto.UserRequest{
-		Username: "admin",
-		Email:    "admin@example.com",
-		Password: "secret",
-	}
+func (s *UserTestSuite) TestLoginMFA() {
+	secret, _ := totp.GenerateSecret()
+	enabledAt := time.Now()
+	user := &entity.User{Username: "admin", TOTPSecret: &secret, TOTPEnabledAt: &enabledAt}
+	user.ID = uuid.New()
+	claims := &tokens.JWTCustomClaims{ID: user.ID.String(), Purpose: tokens.PurposeMFAPending}
+	attemptsKey := "mfa:attempts:" + user.ID.String()
 
-	s.Run("Failed to get users", func() {
-		errorTest := errors.New("get users error")
+	s.Run("Access tokens are not MFA tokens", func() {
+		var e *echo.HTTPError
+		s.tokenService.EXPECT().ValidateToken("access").Return(&tokens.JWTCustomClaims{ID: user.ID.String()}, nil)
+		result, err := s.userService.LoginMFA(context.Background(), dto.MFALoginRequest{Token: "access", Code: "123456"})
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusUnauthorized, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Denylist cannot be read", func() {
+		errorTest := errors.New("denylist error")
+		s.tokenService.EXPECT().ValidateToken("mfa").Return(claims, nil)
+		s.denylist.EXPECT().IsRevoked(claims).Return(false, errorTest)
+		result, err := s.userService.LoginMFA(context.Background(), dto.MFALoginRequest{Token: "mfa", Code: "123456"})
+
+		s.ErrorIs(err, errorTest)
+		s.Nil(result)
+	})
+
+	s.Run("Token of an older version", func() {
+		var e *echo.HTTPError
+		revoked := *user
+		revoked.TokenVersion = "v2"
+		s.tokenService.EXPECT().ValidateToken("mfa").Return(claims, nil)
+		s.denylist.EXPECT().IsRevoked(claims).Return(false, nil)
 		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
-			s.repo.EXPECT().GetUsersFiltered(gomock.Any(), gomock.Any(), 1, 0, "id", "email != ?", userReq.Email).Return(nil, errorTest)
+			s.repo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), user.ID.String()).Return(&revoked, nil)
+			return f(&gorm.DB{})
+		})
+		result, err := s.userService.LoginMFA(context.Background(), dto.MFALoginRequest{Token: "mfa", Code: "123456"})
 
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusUnauthorized, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Wrong code is counted", func() {
+		var e *echo.HTTPError
+		s.tokenService.EXPECT().ValidateToken("mfa").Return(claims, nil)
+		s.denylist.EXPECT().IsRevoked(claims).Return(false, nil)
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), user.ID.String()).Return(user, nil)
+			s.cache.EXPECT().Incr(attemptsKey, 15*time.Minute).Return(int64(3), nil)
+			s.recoveryRepo.EXPECT().GetRecoveryCode(gomock.Any(), gomock.Any(), user.ID.String(), gomock.Any()).Return(nil, gorm.ErrRecordNotFound)
 			return f(&gorm.DB{})
 		})
+		result, err := s.userService.LoginMFA(context.Background(), dto.MFALoginRequest{Token: "mfa", Code: "wrong"})
 
-		user, isFirstUser, err := s.userService.Register(context.Background(), userReq)
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusUnauthorized, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Too many wrong codes", func() {
+		var e *echo.HTTPError
+		s.tokenService.EXPECT().ValidateToken("mfa").Return(claims, nil)
+		s.denylist.EXPECT().IsRevoked(claims).Return(false, nil)
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), user.ID.String()).Return(user, nil)
+			s.cache.EXPECT().Incr(attemptsKey, 15*time.Minute).Return(int64(6), nil)
+			return f(&gorm.DB{})
+		})
+		result, err := s.userService.LoginMFA(context.Background(), dto.MFALoginRequest{Token: "mfa", Code: "123456"})
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusTooManyRequests, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Attempts cannot be counted", func() {
+		errorTest := errors.New("incr error")
+		s.tokenService.EXPECT().ValidateToken("mfa").Return(claims, nil)
+		s.denylist.EXPECT().IsRevoked(claims).Return(false, nil)
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), user.ID.String()).Return(user, nil)
+			s.cache.EXPECT().Incr(attemptsKey, 15*time.Minute).Return(int64(0), errorTest)
+			return f(&gorm.DB{})
+		})
+		result, err := s.userService.LoginMFA(context.Background(), dto.MFALoginRequest{Token: "mfa", Code: "123456"})
 
 		s.ErrorIs(err, errorTest)
-		s.Nil(user)
-		s.False(isFirstUser)
+		s.Nil(result)
 	})
 
-	s.Run("Failed to create user", func() {
-		errorTest := errors.New("create user error")
+	s.Run("Replayed TOTP code", func() {
+		var e *echo.HTTPError
+		code, _ := totp.Code(secret, time.Now())
+		s.tokenService.EXPECT().ValidateToken("mfa").Return(claims, nil)
+		s.denylist.EXPECT().IsRevoked(claims).Return(false, nil)
 		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
-			s.repo.EXPECT().GetUsersFiltered(gomock.Any(), gomock.Any(), 1, 0, "id", "email != ?", userReq.Email).Return([]entity.User{}, nil)
-			s.repo.EXPECT().CreateUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(errorTest)
+			s.repo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), user.ID.String()).Return(user, nil)
+			s.cache.EXPECT().Incr(attemptsKey, 15*time.Minute).Return(int64(1), nil)
+			s.repo.EXPECT().UseTOTPCounter(gomock.Any(), gomock.Any(), user.ID.String(), gomock.Any()).Return(false, nil)
+			return f(&gorm.DB{})
+		})
+		result, err := s.userService.LoginMFA(context.Background(), dto.MFALoginRequest{Token: "mfa", Code: code})
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusUnauthorized, e.Code)
+		s.Nil(result)
+	})
 
+	s.Run("Successfully log in with TOTP code", func() {
+		now := time.Now()
+		code, _ := totp.Code(secret, now)
+		s.tokenService.EXPECT().ValidateToken("mfa").Return(claims, nil)
+		s.denylist.EXPECT().IsRevoked(claims).Return(false, nil)
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), user.ID.String()).Return(user, nil)
+			s.cache.EXPECT().Incr(attemptsKey, 15*time.Minute).Return(int64(2), nil)
+			s.repo.EXPECT().UseTOTPCounter(gomock.Any(), gomock.Any(), user.ID.String(), totp.Counter(now)).Return(true, nil)
+			s.cache.EXPECT().Del(attemptsKey).Return(nil)
+			s.refreshRepo.EXPECT().DeleteExpiredRefreshTokens(gomock.Any(), gomock.Any(), user.ID.String(), gomock.Any()).Return(nil)
+			s.tokenService.EXPECT().GenerateAccessToken(gomock.Any()).DoAndReturn(func(claims tokens.JWTCustomClaims) (string, error) {
+				s.Empty(claims.Purpose)
+				s.NotEmpty(claims.SessionID)
+				return "token", nil
+			})
+			s.refreshRepo.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 			return f(&gorm.DB{})
 		})
+		s.denylist.EXPECT().Revoke(claims).Return(nil)
+		result, err := s.userService.LoginMFA(context.Background(), dto.MFALoginRequest{Token: "mfa", Code: code})
 
-		user, isFirstUser, err := s.userService.Register(context.Background(), userReq)
+		s.Nil(err)
+		s.Equal("token", result.AccessToken)
+	})
+
+	s.Run("Successfully log in with recovery code", func() {
+		recoveryCode := &entity.RecoveryCode{UserID: user.ID}
+		s.tokenService.EXPECT().ValidateToken("mfa").Return(claims, nil)
+		s.denylist.EXPECT().IsRevoked(claims).Return(false, nil)
+		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.repo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), user.ID.String()).Return(user, nil)
+			s.cache.EXPECT().Incr(attemptsKey, 15*time.Minute).Return(int64(1), nil)
+			s.recoveryRepo.EXPECT().GetRecoveryCode(gomock.Any(), gomock.Any(), user.ID.String(), tokens.HashToken("abcde2345f")).Return(recoveryCode, nil)
+			s.recoveryRepo.EXPECT().DeleteRecoveryCode(gomock.Any(), gomock.Any(), recoveryCode).Return(nil)
+			s.cache.EXPECT().Del(attemptsKey).Return(nil)
+			s.refreshRepo.EXPECT().DeleteExpiredRefreshTokens(gomock.Any(), gomock.Any(), user.ID.String(), gomock.Any()).Return(nil)
+			s.tokenService.EXPECT().GenerateAccessToken(gomock.Any()).Return("token", nil)
+			s.refreshRepo.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			return f(&gorm.DB{})
+		})
+		s.denylist.EXPECT().Revoke(claims).Return(nil)
+		result, err := s.userService.LoginMFA(context.Background(), dto.MFALoginRequest{Token: "mfa", Code: "ABCDE-2345F"})
+
+		s.Nil(err)
+		s.Equal("token", result.AccessToken)
+	})
+}
+
+func (s *UserTestSuite) TestRefresh() {
+	user := &entity.User{Username: "admin"}
+	user.ID = uuid.New()
+	familyID := uuid.New()
+	newRefreshToken := func() *entity.RefreshToken {
+		token := &entity.RefreshToken{
+			UserID:    user.ID,
+			FamilyID:  familyID,
+			TokenHash: tokens.HashToken("refresh"),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+		token.ID = uuid.New()
+		return token
+	}
+
+	s.Run("Unknown refresh token", func() {
+		var e *echo.HTTPError
+		s.refreshRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.refreshRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), gomock.Any(), tokens.HashToken("unknown")).Return(nil, gorm.ErrRecordNotFound)
+			return f(&gorm.DB{})
+		})
+		result, err := s.userService.Refresh(context.Background(), dto.RefreshRequest{RefreshToken: "unknown"})
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusUnauthorized, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Expired refresh token", func() {
+		var e *echo.HTTPError
+		token := newRefreshToken()
+		token.ExpiresAt = time.Now().Add(-time.Minute)
+		s.refreshRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.refreshRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), gomock.Any(), token.TokenHash).Return(token, nil)
+			return f(&gorm.DB{})
+		})
+		result, err := s.userService.Refresh(context.Background(), dto.RefreshRequest{RefreshToken: "refresh"})
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusUnauthorized, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Reused refresh token revokes the family", func() {
+		var e *echo.HTTPError
+		usedAt := time.Now().Add(-time.Minute)
+		token := newRefreshToken()
+		token.UsedAt = &usedAt
+		s.refreshRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.refreshRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), gomock.Any(), token.TokenHash).Return(token, nil)
+			s.refreshRepo.EXPECT().RevokeFamily(gomock.Any(), gomock.Any(), familyID.String(), gomock.Any()).Return(nil)
+			s.Nil(f(&gorm.DB{}))
+			return nil
+		})
+		result, err := s.userService.Refresh(context.Background(), dto.RefreshRequest{RefreshToken: "refresh"})
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusUnauthorized, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Policy requires two-factor authentication", func() {
+		var e *echo.HTTPError
+		token := newRefreshToken()
+		s.refreshRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.refreshRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), gomock.Any(), token.TokenHash).Return(token, nil)
+			s.refreshRepo.EXPECT().UpdateRefreshToken(gomock.Any(), gomock.Any(), token).Return(nil)
+			s.repo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), user.ID.String()).Return(user, nil)
+			s.settingRepo.EXPECT().GetSetting(gomock.Any(), gomock.Any(), entity.SettingMFARequiredLevel).Return(&entity.Setting{Value: "2"}, nil)
+			s.repo.EXPECT().GetAuthLevel(gomock.Any(), gomock.Any(), user.ID.String()).Return(3, nil)
+			return f(&gorm.DB{})
+		})
+		result, err := s.userService.Refresh(context.Background(), dto.RefreshRequest{RefreshToken: "refresh"})
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusForbidden, e.Code)
+		s.Nil(result)
+	})
+
+	s.Run("Successfully rotate refresh token", func() {
+		var stored *entity.RefreshToken
+		token := newRefreshToken()
+		s.refreshRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.refreshRepo.EXPECT().GetRefreshTokenByHash(gomock.Any(), gomock.Any(), token.TokenHash).Return(token, nil)
+			s.refreshRepo.EXPECT().UpdateRefreshToken(gomock.Any(), gomock.Any(), token).Return(nil)
+			s.repo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), user.ID.String()).Return(user, nil)
+			s.settingRepo.EXPECT().GetSetting(gomock.Any(), gomock.Any(), entity.SettingMFARequiredLevel).Return(nil, gorm.ErrRecordNotFound)
+			s.tokenService.EXPECT().GenerateAccessToken(gomock.Any()).DoAndReturn(func(claims tokens.JWTCustomClaims) (string, error) {
+				s.Equal(familyID.String(), claims.SessionID)
+				return "token", nil
+			})
+			s.refreshRepo.EXPECT().CreateRefreshToken(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *gorm.DB, token *entity.RefreshToken) error {
+				stored = token
+				return nil
+			})
+			return f(&gorm.DB{})
+		})
+		result, err := s.userService.Refresh(context.Background(), dto.RefreshRequest{RefreshToken: "refresh"})
+
+		s.Nil(err)
+		s.NotNil(token.UsedAt)
+		s.Equal("token", result.AccessToken)
+		s.NotEqual("refresh", result.RefreshToken)
+		s.Equal(familyID, stored.FamilyID)
+		s.Equal(tokens.HashToken(result.RefreshToken), stored.TokenHash)
+	})
+}
+
+func (s *UserTestSuite) TestLogout() {
+	claims := &tokens.JWTCustomClaims{ID: uuid.NewString(), SessionID: uuid.NewString()}
+	claims.RegisteredClaims.ID = uuid.NewString()
+
+	s.Run("Failed to revoke session", func() {
+		errorTest := errors.New("revoke family error")
+		s.refreshRepo.EXPECT().SingleTransaction().Return(nil)
+		s.refreshRepo.EXPECT().RevokeFamily(gomock.Any(), gomock.Any(), claims.SessionID, gomock.Any()).Return(errorTest)
+		err := s.userService.Logout(context.Background(), claims)
 
 		s.ErrorIs(err, errorTest)
-		s.Nil(user)
-		s.False(isFirstUser)
 	})
 
-	s.Run("Failed to get roles", func() {
-		errorTest := errors.New("get roles error")
+	s.Run("Logout successfully", func() {
+		s.refreshRepo.EXPECT().SingleTransaction().Return(nil)
+		s.refreshRepo.EXPECT().RevokeFamily(gomock.Any(), gomock.Any(), claims.SessionID, gomock.Any()).Return(nil)
+		s.denylist.EXPECT().Revoke(claims).Return(nil)
+		err := s.userService.Logout(context.Background(), claims)
+
+		s.Nil(err)
+	})
+}
+
+func (s *UserTestSuite) TestLogoutAll() {
+	userID := uuid.NewString()
+
+	s.Run("Logout everywhere successfully", func() {
+		s.refreshRepo.EXPECT().SingleTransaction().Return(nil)
+		s.refreshRepo.EXPECT().RevokeUserRefreshTokens(gomock.Any(), gomock.Any(), userID, gomock.Any()).Return(nil)
+		s.repo.EXPECT().ChangeTokenVersion(gomock.Any(), gomock.Any(), userID).Return(nil)
+		err := s.userService.LogoutAll(context.Background(), userID)
+
+		s.Nil(err)
+	})
+}
+
+func (s *UserTestSuite) TestRegister() {
+	userReq := dto.UserRequest{
+		Username: "admin",
+		Email:    "admin@example.com",
+		Password: "secret",
+	}
+
+	s.Run("Failed to get users", func() {
+		errorTest := errors.New("get users error")
 		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
-			s.repo.EXPECT().GetUsersFiltered(gomock.Any(), gomock.Any(), 1, 0, "id", "email != ?", userReq.Email).Return([]entity.User{}, nil)
-			s.repo.EXPECT().CreateUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
-			s.roleRepo.EXPECT().GetRolesFiltered(gomock.Any(), gomock.Any(), 1, 0, "id", "auth_level >= 3").Return(nil, errorTest)
+			s.repo.EXPECT().HasAdmin(gomock.Any(), gomock.Any()).Return(false, errorTest)
 
 			return f(&gorm.DB{})
 		})
@@ -537,16 +995,14 @@ func (s *UserTestSuite) TestRegister() {
 
 		s.ErrorIs(err, errorTest)
 		s.Nil(user)
-		s.True(isFirstUser)
+		s.False(isFirstUser)
 	})
 
-	s.Run("Failed to create role", func() {
-		errorTest := errors.New("create role error")
+	s.Run("Failed to create user", func() {
+		errorTest := errors.New("create user error")
 		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
-			s.repo.EXPECT().GetUsersFiltered(gomock.Any(), gomock.Any(), 1, 0, "id", "email != ?", userReq.Email).Return([]entity.User{}, nil)
-			s.repo.EXPECT().CreateUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
-			s.roleRepo.EXPECT().GetRolesFiltered(gomock.Any(), gomock.Any(), 1, 0, "id", "auth_level >= 3").Return([]entity.Role{}, nil)
-			s.roleRepo.EXPECT().CreateRole(gomock.Any(), gomock.Any(), gomock.Any()).Return(errorTest)
+			s.repo.EXPECT().HasAdmin(gomock.Any(), gomock.Any()).Return(false, nil)
+			s.repo.EXPECT().CreateUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(errorTest)
 
 			return f(&gorm.DB{})
 		})
@@ -555,18 +1011,16 @@ func (s *UserTestSuite) TestRegister() {
 
 		s.ErrorIs(err, errorTest)
 		s.Nil(user)
-		s.True(isFirstUser)
+		s.False(isFirstUser)
 	})
 
-	s.Run("Failed to add role", func() {
-		errorTest := errors.New("add role error")
+	s.Run("Failed to issue verification token", func() {
+		errorTest := errors.New("create user token error")
 		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
-			s.repo.EXPECT().GetUsersFiltered(gomock.Any(), gomock.Any(), 1, 0, "id", "email != ?", userReq.Email).Return([]entity.User{}, nil)
+			s.repo.EXPECT().HasAdmin(gomock.Any(), gomock.Any()).Return(false, nil)
 			s.repo.EXPECT().CreateUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
-			s.roleRepo.EXPECT().GetRolesFiltered(gomock.Any(), gomock.Any(), 1, 0, "id", "auth_level >= 3").Return([]entity.Role{
-				{Name: "admin"},
-			}, nil)
-			s.repo.EXPECT().AddRoles(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errorTest)
+			s.tokenRepo.EXPECT().DeleteUserTokens(gomock.Any(), gomock.Any(), gomock.Any(), entity.UserTokenEmailVerification).Return(nil)
+			s.tokenRepo.EXPECT().CreateUserToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(errorTest)
 
 			return f(&gorm.DB{})
 		})
@@ -579,19 +1033,36 @@ func (s *UserTestSuite) TestRegister() {
 	})
 
 	s.Run("Register successfully", func() {
+		var stored *entity.UserToken
+		sent := make(chan notifier.Message, 1)
 		s.repo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
-			s.repo.EXPECT().GetUsersFiltered(gomock.Any(), gomock.Any(), 1, 0, "id", "email != ?", userReq.Email).Return([]entity.User{
-				{Email: userReq.Email},
-			}, nil)
+			s.repo.EXPECT().HasAdmin(gomock.Any(), gomock.Any()).Return(true, nil)
 			s.repo.EXPECT().CreateUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			s.tokenRepo.EXPECT().DeleteUserTokens(gomock.Any(), gomock.Any(), gomock.Any(), entity.UserTokenEmailVerification).Return(nil)
+			s.tokenRepo.EXPECT().CreateUserToken(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *gorm.DB, token *entity.UserToken) error {
+				stored = token
+				return nil
+			})
 
 			return f(&gorm.DB{})
 		})
+		s.mailer.EXPECT().Notify(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, message notifier.Message) error {
+			sent <- message
+			return nil
+		})
 
 		user, isFirstUser, err := s.userService.Register(context.Background(), userReq)
 
 		s.NoError(err)
 		s.NotNil(user)
 		s.False(isFirstUser)
+		s.Nil(user.EmailVerifiedAt)
+
+		message := <-sent
+		s.Equal(userReq.Email, message.Recipient)
+		_, token, found := strings.Cut(message.Body, "https://todos.example.com/api/v1/verify-email?token=")
+		s.True(found)
+		s.Equal(tokens.HashToken(strings.Fields(token)[0]), stored.TokenHash)
+		s.Equal(entity.UserTokenEmailVerification, stored.Purpose)
 	})
 }
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/configs"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"github.com/sherwin-77/golang-todos/pkg/caches"
+	"github.com/sherwin-77/golang-todos/pkg/notifier"
+	"github.com/sherwin-77/golang-todos/pkg/tokens"
+	"gorm.io/gorm"
+)
+
+type VerificationService interface {
+	VerifyEmail(ctx context.Context, request dto.VerifyEmailRequest) (bool, error)
+	ResendVerification(ctx context.Context, request dto.ResendVerificationRequest) error
+}
+
+type verificationService struct {
+	userRepository      repository.UserRepository
+	roleRepository      repository.RoleRepository
+	userTokenRepository repository.UserTokenRepository
+	cache               caches.Cache
+	mailer              notifier.Notifier
+	config              configs.AuthConfig
+}
+
+func NewVerificationService(
+	userRepository repository.UserRepository,
+	roleRepository repository.RoleRepository,
+	userTokenRepository repository.UserTokenRepository,
+	cache caches.Cache,
+	mailer notifier.Notifier,
+	config configs.AuthConfig,
+) VerificationService {
+	return &verificationService{userRepository, roleRepository, userTokenRepository, cache, mailer, config}
+}
+
+// VerifyEmail marks the email of the token's user as verified. While no user
+// is admin, the user verifying becomes admin, which is reported by the
+// returned bool. Concurrent verifications take turns at that check.
+func (s *verificationService) VerifyEmail(ctx context.Context, request dto.VerifyEmailRequest) (bool, error) {
+	var userID string
+	var isFirstUser bool
+
+	if err := s.userTokenRepository.WithTransaction(func(tx *gorm.DB) error {
+		token, err := s.userTokenRepository.GetUserTokenByHash(ctx, tx, entity.UserTokenEmailVerification, tokens.HashToken(request.Token))
+		if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && !time.Now().Before(token.ExpiresAt)) {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid or expired verification token")
+		}
+		if err != nil {
+			return err
+		}
+
+		userID = token.UserID.String()
+		user, err := s.userRepository.GetUserByID(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+
+		if user.EmailVerifiedAt == nil {
+			if err := s.userRepository.LockAdminGrant(ctx, tx); err != nil {
+				return err
+			}
+
+			hasAdmin, err := s.userRepository.HasAdmin(ctx, tx)
+			if err != nil {
+				return err
+			}
+
+			now := time.Now()
+			user.EmailVerifiedAt = &now
+			if err := s.userRepository.UpdateUser(ctx, tx, user); err != nil {
+				return err
+			}
+
+			if !hasAdmin {
+				isFirstUser = true
+				if err := grantAdmin(ctx, tx, s.userRepository, s.roleRepository, user); err != nil {
+					return err
+				}
+			}
+		}
+
+		return s.userTokenRepository.DeleteUserTokens(ctx, tx, userID, entity.UserTokenEmailVerification)
+	}); err != nil {
+		return false, err
+	}
+
+	return isFirstUser, s.cache.Del("users:"+userID, "users:all")
+}
+
+// ResendVerification mails a new verification link to an unverified user, at
+// most once per EmailVerificationResend. Like ForgotPassword, the result does
+// not tell whether the email is registered, verified or throttled.
+func (s *verificationService) ResendVerification(ctx context.Context, request dto.ResendVerificationRequest) error {
+	var message *notifier.Message
+
+	if err := s.userTokenRepository.WithTransaction(func(tx *gorm.DB) error {
+		user, err := s.userRepository.GetUserByEmail(ctx, tx, request.Email)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if user.EmailVerifiedAt != nil {
+			return nil
+		}
+
+		latest, err := s.userTokenRepository.GetLatestUserToken(ctx, tx, user.ID.String(), entity.UserTokenEmailVerification)
+		if err == nil && time.Since(latest.CreatedAt) < s.config.EmailVerificationResend {
+			return nil
+		}
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		m, err := verificationMessage(ctx, tx, s.userTokenRepository, s.config, user)
+		if err != nil {
+			return err
+		}
+
+		message = &m
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if message != nil {
+		deliver(s.mailer, *message)
+	}
+
+	return nil
+}
+
+// issueUserToken replaces the user's tokens for purpose with a new one, of
+// which only the hash is stored.
+func issueUserToken(ctx context.Context, tx *gorm.DB, userTokenRepository repository.UserTokenRepository, userID uuid.UUID, purpose string, ttl time.Duration) (string, error) {
+	token, err := tokens.NewOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	if err := userTokenRepository.DeleteUserTokens(ctx, tx, userID.String(), purpose); err != nil {
+		return "", err
+	}
+
+	if err := userTokenRepository.CreateUserToken(ctx, tx, &entity.UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: tokens.HashToken(token),
+		ExpiresAt: time.Now().Add(ttl),
+	}); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// verificationMessage issues a verification token for the user and returns
+// the mail with its link. Deliver it once the transaction has committed.
+func verificationMessage(ctx context.Context, tx *gorm.DB, userTokenRepository repository.UserTokenRepository, config configs.AuthConfig, user *entity.User) (notifier.Message, error) {
+	token, err := issueUserToken(ctx, tx, userTokenRepository, user.ID, entity.UserTokenEmailVerification, config.EmailVerificationTTL)
+	if err != nil {
+		return notifier.Message{}, err
+	}
+
+	link := config.EmailVerificationURL + "?token=" + url.QueryEscape(token)
+	return notifier.Message{
+		Recipient: user.Email,
+		Subject:   "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nOpen the link below to verify your email address. It expires in %s.\n\n%s\n\nIf you did not create an account, you can ignore this email.",
+			user.Username, config.EmailVerificationTTL, link),
+		Metadata: map[string]string{"type": entity.UserTokenEmailVerification},
+	}, nil
+}
+
+// checkVerified refuses users who have not verified their email address when
+// verification is strict.
+func checkVerified(config configs.AuthConfig, user *entity.User) error {
+	if config.EmailVerification == configs.EmailVerificationStrict && user.EmailVerifiedAt == nil {
+		return echo.NewHTTPError(http.StatusForbidden, "Email address is not verified")
+	}
+
+	return nil
+}
+
+// grantAdmin gives the user the admin role, creating the role if no role of
+// that level exists yet.
+func grantAdmin(ctx context.Context, tx *gorm.DB, userRepository repository.UserRepository, roleRepository repository.RoleRepository, user *entity.User) error {
+	roles, err := roleRepository.GetRolesFiltered(ctx, tx, 1, 0, "id", "auth_level >= 3")
+	if err != nil {
+		return err
+	}
+
+	var role *entity.Role
+	if len(roles) == 0 {
+		role = &entity.Role{
+			Name:      "Admin",
+			AuthLevel: 3,
+		}
+		if err := roleRepository.CreateRole(ctx, tx, role); err != nil {
+			return err
+		}
+	} else {
+		role = &roles[0]
+	}
+
+	return userRepository.AddRoles(ctx, tx, user, []*entity.Role{role})
+}
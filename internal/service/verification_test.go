@@ -0,0 +1,218 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/sherwin-77/golang-todos/configs"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/http/dto"
+	"github.com/sherwin-77/golang-todos/internal/service"
+	"github.com/sherwin-77/golang-todos/pkg/notifier"
+	"github.com/sherwin-77/golang-todos/pkg/tokens"
+	mock_caches "github.com/sherwin-77/golang-todos/test/mock/pkg/caches"
+	mock_notifier "github.com/sherwin-77/golang-todos/test/mock/pkg/notifier"
+	mock_repository "github.com/sherwin-77/golang-todos/test/mock/repository"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type VerificationTestSuite struct {
+	suite.Suite
+	ctrl                *gomock.Controller
+	userRepo            *mock_repository.MockUserRepository
+	roleRepo            *mock_repository.MockRoleRepository
+	tokenRepo           *mock_repository.MockUserTokenRepository
+	cache               *mock_caches.MockCache
+	mailer              *mock_notifier.MockNotifier
+	verificationService service.VerificationService
+}
+
+func (s *VerificationTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.userRepo = mock_repository.NewMockUserRepository(s.ctrl)
+	s.roleRepo = mock_repository.NewMockRoleRepository(s.ctrl)
+	s.tokenRepo = mock_repository.NewMockUserTokenRepository(s.ctrl)
+	s.cache = mock_caches.NewMockCache(s.ctrl)
+	s.mailer = mock_notifier.NewMockNotifier(s.ctrl)
+	s.verificationService = service.NewVerificationService(s.userRepo, s.roleRepo, s.tokenRepo, s.cache, s.mailer, configs.AuthConfig{
+		EmailVerificationTTL:    48 * time.Hour,
+		EmailVerificationURL:    "https://todos.example.com/api/v1/verify-email",
+		EmailVerificationResend: time.Minute,
+	})
+}
+
+func TestVerificationService(t *testing.T) {
+	suite.Run(t, new(VerificationTestSuite))
+}
+
+func (s *VerificationTestSuite) TestVerifyEmail() {
+	user := &entity.User{Email: "alice@example.com"}
+	user.ID = uuid.New()
+	userID := user.ID.String()
+	newToken := func() *entity.UserToken {
+		return &entity.UserToken{
+			UserID:    user.ID,
+			Purpose:   entity.UserTokenEmailVerification,
+			TokenHash: tokens.HashToken("verify"),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
+
+	s.Run("Expired token", func() {
+		var e *echo.HTTPError
+		token := newToken()
+		token.ExpiresAt = time.Now().Add(-time.Minute)
+		s.tokenRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.tokenRepo.EXPECT().GetUserTokenByHash(gomock.Any(), gomock.Any(), entity.UserTokenEmailVerification, token.TokenHash).Return(token, nil)
+			return f(&gorm.DB{})
+		})
+		isFirstUser, err := s.verificationService.VerifyEmail(context.Background(), dto.VerifyEmailRequest{Token: "verify"})
+
+		s.ErrorAs(err, &e)
+		s.Equal(http.StatusBadRequest, e.Code)
+		s.False(isFirstUser)
+	})
+
+	s.Run("First verified user becomes admin", func() {
+		unverified := *user
+		token := newToken()
+		s.tokenRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.tokenRepo.EXPECT().GetUserTokenByHash(gomock.Any(), gomock.Any(), entity.UserTokenEmailVerification, token.TokenHash).Return(token, nil)
+			s.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), userID).Return(&unverified, nil)
+			s.userRepo.EXPECT().LockAdminGrant(gomock.Any(), gomock.Any()).Return(nil)
+			s.userRepo.EXPECT().HasAdmin(gomock.Any(), gomock.Any()).Return(false, nil)
+			s.userRepo.EXPECT().UpdateUser(gomock.Any(), gomock.Any(), &unverified).Return(nil)
+			s.roleRepo.EXPECT().GetRolesFiltered(gomock.Any(), gomock.Any(), 1, 0, "id", "auth_level >= 3").Return([]entity.Role{}, nil)
+			s.roleRepo.EXPECT().CreateRole(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			s.userRepo.EXPECT().AddRoles(gomock.Any(), gomock.Any(), &unverified, gomock.Any()).Return(nil)
+			s.tokenRepo.EXPECT().DeleteUserTokens(gomock.Any(), gomock.Any(), userID, entity.UserTokenEmailVerification).Return(nil)
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("users:"+userID, "users:all").Return(nil)
+		isFirstUser, err := s.verificationService.VerifyEmail(context.Background(), dto.VerifyEmailRequest{Token: "verify"})
+
+		s.Nil(err)
+		s.True(isFirstUser)
+		s.NotNil(unverified.EmailVerifiedAt)
+	})
+
+	s.Run("No admin while the admin's changed email is unverified", func() {
+		// Changing their email left the only admin unverified, so no user
+		// has a verified email, but the admin role is still held.
+		unverified := *user
+		token := newToken()
+		s.tokenRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.tokenRepo.EXPECT().GetUserTokenByHash(gomock.Any(), gomock.Any(), entity.UserTokenEmailVerification, token.TokenHash).Return(token, nil)
+			s.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), userID).Return(&unverified, nil)
+			s.userRepo.EXPECT().LockAdminGrant(gomock.Any(), gomock.Any()).Return(nil)
+			s.userRepo.EXPECT().HasAdmin(gomock.Any(), gomock.Any()).Return(true, nil)
+			s.userRepo.EXPECT().UpdateUser(gomock.Any(), gomock.Any(), &unverified).Return(nil)
+			s.tokenRepo.EXPECT().DeleteUserTokens(gomock.Any(), gomock.Any(), userID, entity.UserTokenEmailVerification).Return(nil)
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("users:"+userID, "users:all").Return(nil)
+		isFirstUser, err := s.verificationService.VerifyEmail(context.Background(), dto.VerifyEmailRequest{Token: "verify"})
+
+		s.Nil(err)
+		s.False(isFirstUser)
+	})
+
+	s.Run("Failed to lock the admin grant", func() {
+		errorTest := errors.New("lock error")
+		unverified := *user
+		token := newToken()
+		s.tokenRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.tokenRepo.EXPECT().GetUserTokenByHash(gomock.Any(), gomock.Any(), entity.UserTokenEmailVerification, token.TokenHash).Return(token, nil)
+			s.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), userID).Return(&unverified, nil)
+			s.userRepo.EXPECT().LockAdminGrant(gomock.Any(), gomock.Any()).Return(errorTest)
+			return f(&gorm.DB{})
+		})
+		isFirstUser, err := s.verificationService.VerifyEmail(context.Background(), dto.VerifyEmailRequest{Token: "verify"})
+
+		s.ErrorIs(err, errorTest)
+		s.False(isFirstUser)
+	})
+
+	s.Run("Successfully verify email", func() {
+		unverified := *user
+		token := newToken()
+		s.tokenRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.tokenRepo.EXPECT().GetUserTokenByHash(gomock.Any(), gomock.Any(), entity.UserTokenEmailVerification, token.TokenHash).Return(token, nil)
+			s.userRepo.EXPECT().GetUserByID(gomock.Any(), gomock.Any(), userID).Return(&unverified, nil)
+			s.userRepo.EXPECT().LockAdminGrant(gomock.Any(), gomock.Any()).Return(nil)
+			s.userRepo.EXPECT().HasAdmin(gomock.Any(), gomock.Any()).Return(true, nil)
+			s.userRepo.EXPECT().UpdateUser(gomock.Any(), gomock.Any(), &unverified).Return(nil)
+			s.tokenRepo.EXPECT().DeleteUserTokens(gomock.Any(), gomock.Any(), userID, entity.UserTokenEmailVerification).Return(nil)
+			return f(&gorm.DB{})
+		})
+		s.cache.EXPECT().Del("users:"+userID, "users:all").Return(nil)
+		isFirstUser, err := s.verificationService.VerifyEmail(context.Background(), dto.VerifyEmailRequest{Token: "verify"})
+
+		s.Nil(err)
+		s.False(isFirstUser)
+		s.NotNil(unverified.EmailVerifiedAt)
+	})
+}
+
+func (s *VerificationTestSuite) TestResendVerification() {
+	user := &entity.User{Email: "alice@example.com"}
+	user.ID = uuid.New()
+	userID := user.ID.String()
+	request := dto.ResendVerificationRequest{Email: user.Email}
+
+	s.Run("Already verified", func() {
+		verified := *user
+		verifiedAt := time.Now()
+		verified.EmailVerifiedAt = &verifiedAt
+		s.tokenRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.userRepo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any(), user.Email).Return(&verified, nil)
+			return f(&gorm.DB{})
+		})
+		err := s.verificationService.ResendVerification(context.Background(), request)
+
+		s.Nil(err)
+	})
+
+	s.Run("Throttled", func() {
+		latest := &entity.UserToken{}
+		latest.CreatedAt = time.Now().Add(-10 * time.Second)
+		s.tokenRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.userRepo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any(), user.Email).Return(user, nil)
+			s.tokenRepo.EXPECT().GetLatestUserToken(gomock.Any(), gomock.Any(), userID, entity.UserTokenEmailVerification).Return(latest, nil)
+			return f(&gorm.DB{})
+		})
+		err := s.verificationService.ResendVerification(context.Background(), request)
+
+		s.Nil(err)
+	})
+
+	s.Run("Successfully resend verification", func() {
+		sent := make(chan notifier.Message, 1)
+		latest := &entity.UserToken{}
+		latest.CreatedAt = time.Now().Add(-2 * time.Minute)
+		s.tokenRepo.EXPECT().WithTransaction(gomock.Any()).DoAndReturn(func(f func(tx *gorm.DB) error) error {
+			s.userRepo.EXPECT().GetUserByEmail(gomock.Any(), gomock.Any(), user.Email).Return(user, nil)
+			s.tokenRepo.EXPECT().GetLatestUserToken(gomock.Any(), gomock.Any(), userID, entity.UserTokenEmailVerification).Return(latest, nil)
+			s.tokenRepo.EXPECT().DeleteUserTokens(gomock.Any(), gomock.Any(), userID, entity.UserTokenEmailVerification).Return(nil)
+			s.tokenRepo.EXPECT().CreateUserToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			return f(&gorm.DB{})
+		})
+		s.mailer.EXPECT().Notify(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, message notifier.Message) error {
+			sent <- message
+			return nil
+		})
+		err := s.verificationService.ResendVerification(context.Background(), request)
+
+		s.Nil(err)
+		message := <-sent
+		s.Equal(user.Email, message.Recipient)
+		s.Equal("Verify your email address", message.Subject)
+	})
+}
@@ -0,0 +1,13 @@
+package service
+
+import "github.com/sherwin-77/golang-todos/internal/repository"
+
+// checkVersion rejects a conditional request made against a stale version. An
+// expected version of zero means the request was unconditional.
+func checkVersion(expected int, current int) error {
+	if expected != 0 && expected != current {
+		return repository.ErrVersionConflict
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/sherwin-77/golang-todos/configs"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"github.com/sherwin-77/golang-todos/pkg/storage"
+)
+
+// orphanBatchSize bounds how many orphaned attachments are loaded at once.
+const orphanBatchSize = 100
+
+// PurgeJob permanently deletes todos that have been in the trash longer than the
+// retention period. Deleting is idempotent, so replicas do not need to coordinate.
+//
+// Trashed todos keep their attachments so they can be restored. Once a todo is
+// purged, by this job or by the user, its attachments are left without a todo
+// and the job removes their blobs.
+type PurgeJob struct {
+	config               configs.TrashConfig
+	todoRepository       repository.TodoRepository
+	attachmentRepository repository.AttachmentRepository
+	blobStore            storage.BlobStore
+}
+
+func NewPurgeJob(config configs.TrashConfig, todoRepository repository.TodoRepository, attachmentRepository repository.AttachmentRepository, blobStore storage.BlobStore) *PurgeJob {
+	return &PurgeJob{config, todoRepository, attachmentRepository, blobStore}
+}
+
+func (j *PurgeJob) Name() string {
+	return "trash-purge"
+}
+
+func (j *PurgeJob) Interval() time.Duration {
+	return j.config.PurgeInterval
+}
+
+func (j *PurgeJob) Run(ctx context.Context) error {
+	db := j.todoRepository.SingleTransaction()
+	purged, err := j.todoRepository.PurgeDeletedTodos(ctx, db, "deleted_at < ?", time.Now().Add(-j.config.Retention))
+	if err != nil {
+		return err
+	}
+
+	if purged > 0 {
+		log.Printf("[worker] purged %d todos from the trash", purged)
+	}
+
+	return j.deleteOrphanedAttachments(ctx)
+}
+
+// deleteOrphanedAttachments removes the blob before the record, so a failure
+// leaves the record behind to be retried on the next run.
+func (j *PurgeJob) deleteOrphanedAttachments(ctx context.Context) error {
+	db := j.attachmentRepository.SingleTransaction()
+
+	deleted := 0
+	for {
+		attachments, err := j.attachmentRepository.GetOrphanedAttachments(ctx, db, orphanBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for i := range attachments {
+			if err := j.blobStore.Delete(ctx, attachments[i].StorageKey); err != nil {
+				return err
+			}
+
+			if err := j.attachmentRepository.DeleteAttachment(ctx, db, &attachments[i]); err != nil {
+				return err
+			}
+			deleted++
+		}
+
+		if len(attachments) < orphanBatchSize {
+			break
+		}
+	}
+
+	if deleted > 0 {
+		log.Printf("[worker] deleted %d orphaned attachments", deleted)
+	}
+
+	return nil
+}
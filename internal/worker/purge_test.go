@@ -0,0 +1,95 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sherwin-77/golang-todos/configs"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/worker"
+	mock_storage "github.com/sherwin-77/golang-todos/test/mock/pkg/storage"
+	mock_repository "github.com/sherwin-77/golang-todos/test/mock/repository"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type PurgeTestSuite struct {
+	suite.Suite
+	ctrl           *gomock.Controller
+	repo           *mock_repository.MockTodoRepository
+	attachmentRepo *mock_repository.MockAttachmentRepository
+	blobStore      *mock_storage.MockBlobStore
+	job            *worker.PurgeJob
+}
+
+func (s *PurgeTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.repo = mock_repository.NewMockTodoRepository(s.ctrl)
+	s.attachmentRepo = mock_repository.NewMockAttachmentRepository(s.ctrl)
+	s.blobStore = mock_storage.NewMockBlobStore(s.ctrl)
+	s.job = worker.NewPurgeJob(configs.TrashConfig{
+		Retention:     24 * time.Hour,
+		PurgeInterval: time.Hour,
+	}, s.repo, s.attachmentRepo, s.blobStore)
+}
+
+func TestPurgeJob(t *testing.T) {
+	suite.Run(t, new(PurgeTestSuite))
+}
+
+func (s *PurgeTestSuite) TestRun() {
+	s.Run("Failed to purge trash", func() {
+		errorTest := errors.New("purge error")
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().PurgeDeletedTodos(gomock.Any(), gomock.Any(), "deleted_at < ?", gomock.Any()).Return(int64(0), errorTest)
+
+		s.ErrorIs(s.job.Run(context.Background()), errorTest)
+	})
+
+	s.Run("Successfully purge expired trash", func() {
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().PurgeDeletedTodos(gomock.Any(), gomock.Any(), "deleted_at < ?", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *gorm.DB, _ interface{}, args ...interface{}) (int64, error) {
+				cutoff := args[0].(time.Time)
+				s.WithinDuration(time.Now().Add(-24*time.Hour), cutoff, time.Minute)
+				return 2, nil
+			})
+		s.attachmentRepo.EXPECT().SingleTransaction().Return(nil)
+		s.attachmentRepo.EXPECT().GetOrphanedAttachments(gomock.Any(), gomock.Any(), 100).Return(nil, nil)
+
+		s.Nil(s.job.Run(context.Background()))
+	})
+
+	s.Run("Failed to delete orphaned blob", func() {
+		errorTest := errors.New("blob error")
+		orphan := entity.Attachment{StorageKey: "attachments/" + uuid.NewString()}
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().PurgeDeletedTodos(gomock.Any(), gomock.Any(), "deleted_at < ?", gomock.Any()).Return(int64(0), nil)
+		s.attachmentRepo.EXPECT().SingleTransaction().Return(nil)
+		s.attachmentRepo.EXPECT().GetOrphanedAttachments(gomock.Any(), gomock.Any(), 100).Return([]entity.Attachment{orphan}, nil)
+		s.blobStore.EXPECT().Delete(gomock.Any(), orphan.StorageKey).Return(errorTest)
+
+		s.ErrorIs(s.job.Run(context.Background()), errorTest)
+	})
+
+	s.Run("Successfully delete orphaned attachments", func() {
+		orphans := []entity.Attachment{
+			{StorageKey: "attachments/" + uuid.NewString()},
+			{StorageKey: "attachments/" + uuid.NewString()},
+		}
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().PurgeDeletedTodos(gomock.Any(), gomock.Any(), "deleted_at < ?", gomock.Any()).Return(int64(1), nil)
+		s.attachmentRepo.EXPECT().SingleTransaction().Return(nil)
+		s.attachmentRepo.EXPECT().GetOrphanedAttachments(gomock.Any(), gomock.Any(), 100).Return(orphans, nil)
+		for i := range orphans {
+			s.blobStore.EXPECT().Delete(gomock.Any(), orphans[i].StorageKey).Return(nil)
+			s.attachmentRepo.EXPECT().DeleteAttachment(gomock.Any(), gomock.Any(), &orphans[i]).Return(nil)
+		}
+
+		s.Nil(s.job.Run(context.Background()))
+	})
+}
@@ -0,0 +1,135 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/sherwin-77/golang-todos/configs"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/repository"
+	"github.com/sherwin-77/golang-todos/pkg/caches"
+	"github.com/sherwin-77/golang-todos/pkg/notifier"
+)
+
+const reminderLockKey = "reminders:claims"
+
+type ReminderJob struct {
+	config         configs.ReminderConfig
+	todoRepository repository.TodoRepository
+	locker         caches.Locker
+	notifier       notifier.Notifier
+}
+
+func NewReminderJob(config configs.ReminderConfig, todoRepository repository.TodoRepository, locker caches.Locker, notifier notifier.Notifier) *ReminderJob {
+	return &ReminderJob{config, todoRepository, locker, notifier}
+}
+
+func (j *ReminderJob) Name() string {
+	return "reminder"
+}
+
+func (j *ReminderJob) Interval() time.Duration {
+	return j.config.Interval
+}
+
+// Run sends every due reminder that this replica manages to claim. Claims are
+// never released early: after a failed delivery the reminder cools down until
+// the lock expires, and after a successful one the claim keeps replicas that
+// loaded the same batch from sending it again.
+func (j *ReminderJob) Run(ctx context.Context) error {
+	db := j.todoRepository.SingleTransaction()
+	todos, err := j.todoRepository.GetDueReminders(ctx, db, time.Now(), j.config.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	for i := range todos {
+		todo := &todos[i]
+
+		claimed, err := j.locker.Claim(ctx, reminderLockKey, todo.ID.String(), j.config.LockTTL)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			continue
+		}
+
+		// The batch may be stale by now; another replica could have sent the
+		// reminder between our query and our claim.
+		pending, err := j.todoRepository.IsReminderPending(ctx, db, todo.ID.String())
+		if err != nil {
+			return err
+		}
+		if !pending {
+			continue
+		}
+
+		if err := j.deliver(ctx, todo); err != nil {
+			log.Printf("[worker] reminder %s: %v", todo.ID, err)
+			continue
+		}
+
+		marked, err := j.todoRepository.MarkReminderSent(ctx, db, todo, time.Now())
+		if err != nil {
+			return err
+		}
+		if !marked {
+			log.Printf("[worker] reminder %s: already marked as sent", todo.ID)
+		}
+	}
+
+	return nil
+}
+
+// deliver retries the notifier with exponential backoff.
+func (j *ReminderJob) deliver(ctx context.Context, todo *entity.Todo) error {
+	message := reminderMessage(todo)
+	attempts := max(j.config.MaxAttempts, 1)
+	backoff := j.config.Backoff
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = j.notifier.Notify(ctx, message); err == nil {
+			return nil
+		}
+
+		if attempt == attempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	return fmt.Errorf("giving up after %d attempts: %w", attempts, err)
+}
+
+func reminderMessage(todo *entity.Todo) notifier.Message {
+	message := notifier.Message{
+		Subject: "Reminder: " + todo.Title,
+		Body:    todo.Title,
+		Metadata: map[string]string{
+			"todo_id": todo.ID.String(),
+			"user_id": todo.UserID.String(),
+		},
+	}
+
+	if todo.User != nil {
+		message.Recipient = todo.User.Email
+	}
+	if todo.Description != "" {
+		message.Body += "\n\n" + todo.Description
+	}
+	if todo.DueAt != nil {
+		message.Body += "\n\nDue at " + todo.DueAt.Format(time.RFC1123)
+		message.Metadata["due_at"] = todo.DueAt.Format(time.RFC3339)
+	}
+
+	return message
+}
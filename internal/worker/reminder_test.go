@@ -0,0 +1,104 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sherwin-77/golang-todos/configs"
+	"github.com/sherwin-77/golang-todos/internal/entity"
+	"github.com/sherwin-77/golang-todos/internal/worker"
+	"github.com/sherwin-77/golang-todos/pkg/notifier"
+	mock_caches "github.com/sherwin-77/golang-todos/test/mock/pkg/caches"
+	mock_notifier "github.com/sherwin-77/golang-todos/test/mock/pkg/notifier"
+	mock_repository "github.com/sherwin-77/golang-todos/test/mock/repository"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type ReminderTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	repo     *mock_repository.MockTodoRepository
+	locker   *mock_caches.MockLocker
+	notifier *mock_notifier.MockNotifier
+	job      *worker.ReminderJob
+}
+
+func (s *ReminderTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.repo = mock_repository.NewMockTodoRepository(s.ctrl)
+	s.locker = mock_caches.NewMockLocker(s.ctrl)
+	s.notifier = mock_notifier.NewMockNotifier(s.ctrl)
+	s.job = worker.NewReminderJob(configs.ReminderConfig{
+		LockTTL:     time.Minute,
+		BatchSize:   10,
+		MaxAttempts: 3,
+		Backoff:     time.Millisecond,
+	}, s.repo, s.locker, s.notifier)
+}
+
+func TestReminderJob(t *testing.T) {
+	suite.Run(t, new(ReminderTestSuite))
+}
+
+func (s *ReminderTestSuite) TestRun() {
+	todo := entity.Todo{Title: "Pay rent", User: &entity.User{Email: "user@example.com"}}
+	todo.ID = uuid.Must(uuid.NewV7())
+	todoID := todo.ID.String()
+
+	s.Run("Failed to get reminders", func() {
+		errorTest := errors.New("get reminders error")
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetDueReminders(gomock.Any(), gomock.Any(), gomock.Any(), 10).Return(nil, errorTest)
+
+		s.ErrorIs(s.job.Run(context.Background()), errorTest)
+	})
+
+	s.Run("Claimed by another replica", func() {
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetDueReminders(gomock.Any(), gomock.Any(), gomock.Any(), 10).Return([]entity.Todo{todo}, nil)
+		s.locker.EXPECT().Claim(gomock.Any(), "reminders:claims", todoID, time.Minute).Return(false, nil)
+
+		s.Nil(s.job.Run(context.Background()))
+	})
+
+	s.Run("Already sent after the batch was loaded", func() {
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetDueReminders(gomock.Any(), gomock.Any(), gomock.Any(), 10).Return([]entity.Todo{todo}, nil)
+		s.locker.EXPECT().Claim(gomock.Any(), "reminders:claims", todoID, time.Minute).Return(true, nil)
+		s.repo.EXPECT().IsReminderPending(gomock.Any(), gomock.Any(), todoID).Return(false, nil)
+
+		s.Nil(s.job.Run(context.Background()))
+	})
+
+	s.Run("Delivery keeps failing", func() {
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetDueReminders(gomock.Any(), gomock.Any(), gomock.Any(), 10).Return([]entity.Todo{todo}, nil)
+		s.locker.EXPECT().Claim(gomock.Any(), "reminders:claims", todoID, time.Minute).Return(true, nil)
+		s.repo.EXPECT().IsReminderPending(gomock.Any(), gomock.Any(), todoID).Return(true, nil)
+		s.notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(errors.New("smtp down")).Times(3)
+
+		s.Nil(s.job.Run(context.Background()))
+	})
+
+	s.Run("Successfully send reminder after retry", func() {
+		s.repo.EXPECT().SingleTransaction().Return(nil)
+		s.repo.EXPECT().GetDueReminders(gomock.Any(), gomock.Any(), gomock.Any(), 10).Return([]entity.Todo{todo}, nil)
+		s.locker.EXPECT().Claim(gomock.Any(), "reminders:claims", todoID, time.Minute).Return(true, nil)
+		s.repo.EXPECT().IsReminderPending(gomock.Any(), gomock.Any(), todoID).Return(true, nil)
+		gomock.InOrder(
+			s.notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(errors.New("timeout")),
+			s.notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, message notifier.Message) error {
+				s.Equal("user@example.com", message.Recipient)
+				s.Equal("Reminder: Pay rent", message.Subject)
+				return nil
+			}),
+		)
+		s.repo.EXPECT().MarkReminderSent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+
+		s.Nil(s.job.Run(context.Background()))
+	})
+}
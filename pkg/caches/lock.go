@@ -0,0 +1,42 @@
+package caches
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// claimScript drops expired claims from the sorted set, then adds the member
+// only if no other live claim exists. Each member's score is its expiry time.
+var claimScript = redis.NewScript(`
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', ARGV[1])
+return redis.call('ZADD', KEYS[1], 'NX', ARGV[2], ARGV[3])
+`)
+
+type Locker interface {
+	Claim(ctx context.Context, key string, member string, ttl time.Duration) (bool, error)
+	Release(ctx context.Context, key string, member string) error
+}
+
+type locker struct {
+	client *redis.Client
+}
+
+func NewLocker(client *redis.Client) Locker {
+	return &locker{client}
+}
+
+func (l *locker) Claim(ctx context.Context, key string, member string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	claimed, err := claimScript.Run(ctx, l.client, []string{key}, now.UnixMilli(), now.Add(ttl).UnixMilli(), member).Int()
+	if err != nil {
+		return false, err
+	}
+
+	return claimed == 1, nil
+}
+
+func (l *locker) Release(ctx context.Context, key string, member string) error {
+	return l.client.ZRem(ctx, key, member).Err()
+}
@@ -2,6 +2,7 @@ package caches
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,6 +10,16 @@ import (
 	"github.com/sherwin-77/golang-todos/configs"
 )
 
+// incrScript increments the counter and starts its expiry on the first
+// increment only, so the window is not extended by later ones.
+var incrScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+if count == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return count
+`)
+
 func InitRedis(config configs.RedisConfig) *redis.Client {
 	client := redis.NewClient(&redis.Options{
 		Addr:     fmt.Sprintf("%s:%s", config.Host, config.Port),
@@ -27,7 +38,9 @@ func InitRedis(config configs.RedisConfig) *redis.Client {
 type Cache interface {
 	Set(key string, value interface{}, duration time.Duration) error
 	Get(key string) string
-	Del(key string) error
+	Lookup(key string) (string, error)
+	Del(keys ...string) error
+	Incr(key string, window time.Duration) (int64, error)
 }
 
 type cache struct {
@@ -51,6 +64,28 @@ func (c *cache) Get(key string) string {
 	return value
 }
 
-func (c *cache) Del(key string) error {
-	return c.client.Del(context.Background(), key).Err()
+// Lookup is Get for callers that must not mistake a failed read for a
+// missing key. A missing key is returned as the empty string without error.
+func (c *cache) Lookup(key string) (string, error) {
+	value, err := c.client.Get(context.Background(), key).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+
+	return value, err
+}
+
+// Del removes all the given keys in a single round trip.
+func (c *cache) Del(keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return c.client.Del(context.Background(), keys...).Err()
+}
+
+// Incr atomically increments the counter at key and returns the new value. A
+// new counter expires after window, later increments leave its expiry alone.
+func (c *cache) Incr(key string, window time.Duration) (int64, error) {
+	return incrScript.Run(context.Background(), c.client, []string{key}, window.Milliseconds()).Int64()
 }
@@ -0,0 +1,377 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLineLength is the longest content line allowed before folding, in octets.
+const maxLineLength = 75
+
+const (
+	dateFormat     = "20060102"
+	dateTimeFormat = "20060102T150405"
+)
+
+// Property is a single content line, such as DUE;TZID=Europe/Paris:20241220T170000.
+// Values are kept as written; use Text and ParseTime to read them.
+type Property struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// Param returns the named parameter, or an empty string.
+func (p *Property) Param(name string) string {
+	return p.Params[strings.ToUpper(name)]
+}
+
+// Text returns the value with TEXT escaping removed.
+func (p *Property) Text() string {
+	return UnescapeText(p.Value)
+}
+
+// ParseTime reads a DATE or DATE-TIME value. UTC times end in Z, times with a
+// TZID parameter are read in that zone, and floating times are read as UTC.
+func (p *Property) ParseTime() (time.Time, error) {
+	if strings.EqualFold(p.Param("VALUE"), "DATE") || len(p.Value) == len(dateFormat) {
+		return time.Parse(dateFormat, p.Value)
+	}
+
+	if strings.HasSuffix(p.Value, "Z") {
+		return time.Parse(dateTimeFormat+"Z", p.Value)
+	}
+
+	location := time.UTC
+	if tzid := p.Param("TZID"); tzid != "" {
+		var err error
+		location, err = time.LoadLocation(strings.TrimPrefix(tzid, "/"))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("unknown time zone %q", tzid)
+		}
+	}
+
+	return time.ParseInLocation(dateTimeFormat, p.Value, location)
+}
+
+// Component is a BEGIN/END block such as VCALENDAR, VTODO or VALARM.
+type Component struct {
+	Name       string
+	Properties []Property
+	Components []Component
+}
+
+// Get returns the first property with the given name, or nil.
+func (c *Component) Get(name string) *Property {
+	for i := range c.Properties {
+		if c.Properties[i].Name == name {
+			return &c.Properties[i]
+		}
+	}
+	return nil
+}
+
+// Add appends a property with a raw value.
+func (c *Component) Add(name string, value string) {
+	c.Properties = append(c.Properties, Property{Name: name, Value: value})
+}
+
+// AddText appends a property with a TEXT value, escaping it.
+func (c *Component) AddText(name string, value string) {
+	c.Add(name, EscapeText(value))
+}
+
+// AddTime appends a DATE-TIME property in UTC.
+func (c *Component) AddTime(name string, t time.Time) {
+	c.Add(name, FormatTime(t))
+}
+
+// FormatTime formats t as a UTC DATE-TIME value.
+func FormatTime(t time.Time) string {
+	return t.UTC().Format(dateTimeFormat + "Z")
+}
+
+var (
+	textEscaper   = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	textUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+)
+
+func EscapeText(value string) string {
+	return textEscaper.Replace(value)
+}
+
+func UnescapeText(value string) string {
+	return textUnescaper.Replace(value)
+}
+
+// SplitText splits a multi-valued TEXT property, such as CATEGORIES, on the
+// commas that are not escaped, and unescapes each value.
+func SplitText(value string) []string {
+	var values []string
+	start := 0
+
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case ',':
+			values = append(values, UnescapeText(value[start:i]))
+			start = i + 1
+		}
+	}
+
+	return append(values, UnescapeText(value[start:]))
+}
+
+// ParseDuration reads a DURATION value such as -PT15M or P1DT12H. Years and
+// months are not allowed by RFC 5545, so every unit has a fixed length.
+func ParseDuration(value string) (time.Duration, error) {
+	invalid := fmt.Errorf("invalid duration %q", value)
+
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(value, "-"):
+		sign = -1
+		value = value[1:]
+	case strings.HasPrefix(value, "+"):
+		value = value[1:]
+	}
+
+	if !strings.HasPrefix(value, "P") || len(value) < 3 {
+		return 0, invalid
+	}
+
+	units := map[byte]time.Duration{'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour}
+	var duration time.Duration
+	number := ""
+
+	for i := 1; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c >= '0' && c <= '9':
+			number += string(c)
+		case c == 'T' && number == "":
+			units = map[byte]time.Duration{'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+		default:
+			unit, ok := units[c]
+			n, err := strconv.Atoi(number)
+			if !ok || err != nil {
+				return 0, invalid
+			}
+			duration += time.Duration(n) * unit
+			number = ""
+		}
+	}
+
+	if number != "" {
+		return 0, invalid
+	}
+
+	return sign * duration, nil
+}
+
+// Encoder writes components as content lines ending in CRLF, folding lines
+// longer than 75 octets.
+type Encoder struct {
+	w   io.Writer
+	err error
+}
+
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+func (e *Encoder) writeLine(line string) {
+	if e.err != nil {
+		return
+	}
+
+	var b strings.Builder
+	limit := maxLineLength
+	for len(line) > limit {
+		// Never split a multi-byte character across lines.
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// The leading space of a continuation line counts towards its length.
+		limit = maxLineLength - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+
+	_, e.err = io.WriteString(e.w, b.String())
+}
+
+// Begin opens a component. It is used with End to write a component whose
+// children are streamed, such as a VCALENDAR holding many VTODOs.
+func (e *Encoder) Begin(name string) error {
+	e.writeLine("BEGIN:" + name)
+	return e.err
+}
+
+func (e *Encoder) End(name string) error {
+	e.writeLine("END:" + name)
+	return e.err
+}
+
+func (e *Encoder) WriteProperty(p Property) error {
+	var b strings.Builder
+	b.WriteString(p.Name)
+
+	names := make([]string, 0, len(p.Params))
+	for name := range p.Params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := p.Params[name]
+		b.WriteString(";" + name + "=")
+		if strings.ContainsAny(value, ";:,") {
+			value = `"` + value + `"`
+		}
+		b.WriteString(value)
+	}
+	b.WriteString(":" + p.Value)
+
+	e.writeLine(b.String())
+	return e.err
+}
+
+// Encode writes a whole component, including its children.
+func (e *Encoder) Encode(c *Component) error {
+	e.Begin(c.Name)
+	for _, p := range c.Properties {
+		e.WriteProperty(p)
+	}
+	for i := range c.Components {
+		e.Encode(&c.Components[i])
+	}
+	return e.End(c.Name)
+}
+
+// parseLine splits a content line into its name, parameters and value.
+func parseLine(line string) (Property, error) {
+	p := Property{Params: map[string]string{}}
+
+	end := strings.IndexAny(line, ";:")
+	if end <= 0 {
+		return p, fmt.Errorf("malformed line %q", line)
+	}
+	p.Name = strings.ToUpper(line[:end])
+	line = line[end:]
+
+	for strings.HasPrefix(line, ";") {
+		line = line[1:]
+		eq := strings.IndexByte(line, '=')
+		if eq <= 0 {
+			return p, fmt.Errorf("malformed parameter in %s", p.Name)
+		}
+		name := strings.ToUpper(line[:eq])
+		line = line[eq+1:]
+
+		var value string
+		if strings.HasPrefix(line, `"`) {
+			quote := strings.IndexByte(line[1:], '"')
+			if quote < 0 {
+				return p, fmt.Errorf("unterminated quote in %s", p.Name)
+			}
+			value = line[1 : quote+1]
+			line = line[quote+2:]
+		} else {
+			end := strings.IndexAny(line, ";:")
+			if end < 0 {
+				return p, fmt.Errorf("malformed parameter in %s", p.Name)
+			}
+			value = line[:end]
+			line = line[end:]
+		}
+		p.Params[name] = value
+	}
+
+	if !strings.HasPrefix(line, ":") {
+		return p, fmt.Errorf("missing value in %s", p.Name)
+	}
+	p.Value = line[1:]
+
+	return p, nil
+}
+
+// Decode reads every top-level component in r, unfolding lines as it goes.
+func Decode(r io.Reader) ([]Component, error) {
+	var components []Component
+	var stack []*Component
+	var line string
+
+	flush := func() error {
+		if line == "" {
+			return nil
+		}
+		p, err := parseLine(line)
+		line = ""
+		if err != nil {
+			return err
+		}
+
+		switch p.Name {
+		case "BEGIN":
+			stack = append(stack, &Component{Name: strings.ToUpper(p.Value)})
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(p.Value) {
+				return fmt.Errorf("unexpected END:%s", p.Value)
+			}
+			done := *stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				components = append(components, done)
+			} else {
+				parent := stack[len(stack)-1]
+				parent.Components = append(parent.Components, done)
+			}
+		default:
+			if len(stack) == 0 {
+				return fmt.Errorf("property %s outside of a component", p.Name)
+			}
+			current := stack[len(stack)-1]
+			current.Properties = append(current.Properties, p)
+		}
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		text := strings.TrimSuffix(scanner.Text(), "\r")
+		if strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t") {
+			line += text[1:]
+			continue
+		}
+		if err := flush(); err != nil {
+			return nil, err
+		}
+		line = text
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	if len(stack) > 0 {
+		return nil, errors.New("unterminated " + stack[len(stack)-1].Name)
+	}
+
+	return components, nil
+}
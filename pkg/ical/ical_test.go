@@ -0,0 +1,134 @@
+package ical_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sherwin-77/golang-todos/pkg/ical"
+	"github.com/stretchr/testify/suite"
+)
+
+type ICalTestSuite struct {
+	suite.Suite
+}
+
+func TestICal(t *testing.T) {
+	suite.Run(t, new(ICalTestSuite))
+}
+
+func (s *ICalTestSuite) TestEncode() {
+	s.Run("Escapes and folds lines", func() {
+		todo := ical.Component{Name: "VTODO"}
+		todo.AddText("SUMMARY", "Milk, eggs; bread")
+		todo.AddText("DESCRIPTION", strings.Repeat("é", 40)+"\nDone")
+		todo.Properties = append(todo.Properties, ical.Property{Name: "DUE", Params: map[string]string{"VALUE": "DATE"}, Value: "20241220"})
+
+		var out bytes.Buffer
+		s.Nil(ical.NewEncoder(&out).Encode(&todo))
+
+		lines := strings.Split(out.String(), "\r\n")
+		s.Equal("BEGIN:VTODO", lines[0])
+		s.Equal(`SUMMARY:Milk\, eggs\; bread`, lines[1])
+		for _, line := range lines {
+			s.LessOrEqual(len(line), 75)
+		}
+		s.True(strings.HasPrefix(lines[3], " "))
+		s.Equal("DUE;VALUE=DATE:20241220", lines[len(lines)-3])
+		s.Equal("END:VTODO", lines[len(lines)-2])
+		s.Equal("", lines[len(lines)-1])
+	})
+}
+
+func (s *ICalTestSuite) TestDecode() {
+	s.Run("Round trip", func() {
+		calendar := ical.Component{Name: "VCALENDAR"}
+		calendar.Add("VERSION", "2.0")
+		todo := ical.Component{Name: "VTODO"}
+		todo.AddText("SUMMARY", "Milk, eggs; bread")
+		todo.AddText("DESCRIPTION", strings.Repeat("é", 40)+"\nDone")
+		calendar.Components = append(calendar.Components, todo)
+
+		var out bytes.Buffer
+		s.Nil(ical.NewEncoder(&out).Encode(&calendar))
+
+		components, err := ical.Decode(&out)
+		s.Nil(err)
+		s.Len(components, 1)
+		s.Equal("2.0", components[0].Get("VERSION").Value)
+		s.Equal("Milk, eggs; bread", components[0].Components[0].Get("SUMMARY").Text())
+		s.Equal(strings.Repeat("é", 40)+"\nDone", components[0].Components[0].Get("DESCRIPTION").Text())
+	})
+
+	s.Run("Parameters", func() {
+		components, err := ical.Decode(strings.NewReader("BEGIN:VEVENT\nDTSTART;TZID=\"America/New_York\";X-NOTE=\"a;b:c\":20241220T090000\nEND:VEVENT\n"))
+		s.Nil(err)
+
+		start := components[0].Get("DTSTART")
+		s.Equal("a;b:c", start.Param("x-note"))
+		t, err := start.ParseTime()
+		s.Nil(err)
+		s.Equal(time.Date(2024, 12, 20, 14, 0, 0, 0, time.UTC), t.UTC())
+	})
+
+	s.Run("Malformed files", func() {
+		for _, file := range []string{
+			"BEGIN:VTODO\nSUMMARY:Milk\n",
+			"BEGIN:VTODO\nEND:VEVENT\n",
+			"SUMMARY:Milk\n",
+			"BEGIN:VTODO\nSUMMARY\nEND:VTODO\n",
+			"BEGIN:VTODO\nDUE;TZID=\"UTC:20241220T090000\nEND:VTODO\n",
+		} {
+			_, err := ical.Decode(strings.NewReader(file))
+			s.Error(err, file)
+		}
+	})
+}
+
+func (s *ICalTestSuite) TestParseTime() {
+	cases := []struct {
+		property ical.Property
+		expected time.Time
+	}{
+		{ical.Property{Value: "20241220T170000Z"}, time.Date(2024, 12, 20, 17, 0, 0, 0, time.UTC)},
+		{ical.Property{Value: "20241220T170000"}, time.Date(2024, 12, 20, 17, 0, 0, 0, time.UTC)},
+		{ical.Property{Value: "20241220"}, time.Date(2024, 12, 20, 0, 0, 0, 0, time.UTC)},
+		{ical.Property{Params: map[string]string{"TZID": "Asia/Jakarta"}, Value: "20241220T170000"}, time.Date(2024, 12, 20, 10, 0, 0, 0, time.UTC)},
+	}
+
+	for _, c := range cases {
+		t, err := c.property.ParseTime()
+		s.Nil(err, c.property.Value)
+		s.Equal(c.expected, t.UTC(), c.property.Value)
+	}
+
+	_, err := (&ical.Property{Params: map[string]string{"TZID": "Nowhere/City"}, Value: "20241220T170000"}).ParseTime()
+	s.Error(err)
+}
+
+func (s *ICalTestSuite) TestParseDuration() {
+	cases := map[string]time.Duration{
+		"PT15M":     15 * time.Minute,
+		"-PT15M":    -15 * time.Minute,
+		"+P1D":      24 * time.Hour,
+		"P1DT12H":   36 * time.Hour,
+		"P2W":       14 * 24 * time.Hour,
+		"PT1H30M5S": time.Hour + 30*time.Minute + 5*time.Second,
+	}
+
+	for value, expected := range cases {
+		duration, err := ical.ParseDuration(value)
+		s.Nil(err, value)
+		s.Equal(expected, duration, value)
+	}
+
+	for _, value := range []string{"", "P", "15M", "PT", "P1H", "PT15", "P1Y"} {
+		_, err := ical.ParseDuration(value)
+		s.Error(err, value)
+	}
+}
+
+func (s *ICalTestSuite) TestSplitText() {
+	s.Equal([]string{"home", "a,b", "work"}, ical.SplitText(`home,a\,b,work`))
+}
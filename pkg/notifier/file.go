@@ -0,0 +1,49 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/sherwin-77/golang-todos/configs"
+)
+
+type fileNotifier struct {
+	config configs.FileNotifierConfig
+	mu     sync.Mutex
+}
+
+// NewFileNotifier appends each message as a JSON line to a file, so links sent
+// by mail can be picked up during development without an SMTP server.
+func NewFileNotifier(config configs.FileNotifierConfig) Notifier {
+	return &fileNotifier{config: config}
+}
+
+func (n *fileNotifier) Notify(ctx context.Context, message Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	line, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(n.config.Path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(n.config.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
@@ -0,0 +1,19 @@
+package notifier
+
+import (
+	"context"
+	"log"
+)
+
+type logNotifier struct {
+	logger *log.Logger
+}
+
+func NewLogNotifier() Notifier {
+	return &logNotifier{log.Default()}
+}
+
+func (n *logNotifier) Notify(ctx context.Context, message Message) error {
+	n.logger.Printf("[notifier] to=%s subject=%q body=%q", message.Recipient, message.Subject, message.Body)
+	return nil
+}
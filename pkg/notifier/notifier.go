@@ -0,0 +1,39 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sherwin-77/golang-todos/configs"
+)
+
+type Message struct {
+	Recipient string            `json:"recipient"`
+	Subject   string            `json:"subject"`
+	Body      string            `json:"body"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, message Message) error
+}
+
+// NewNotifier builds the notifier selected by name ("log", "file", "smtp" or
+// "webhook").
+func NewNotifier(name string, config *configs.Config) (Notifier, error) {
+	switch name {
+	case "", "log":
+		return NewLogNotifier(), nil
+	case "file":
+		return NewFileNotifier(config.FileNotifier), nil
+	case "smtp":
+		return NewSMTPNotifier(config.SMTP), nil
+	case "webhook":
+		if config.Webhook.URL == "" {
+			return nil, fmt.Errorf("webhook notifier requires WEBHOOK_URL")
+		}
+		return NewWebhookNotifier(config.Webhook), nil
+	default:
+		return nil, fmt.Errorf("unknown notifier %q", name)
+	}
+}
@@ -0,0 +1,53 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/sherwin-77/golang-todos/configs"
+)
+
+type smtpNotifier struct {
+	config configs.SMTPConfig
+}
+
+// NewSMTPNotifier sends plain text mail. Leaving the username empty skips
+// authentication, which is what local fake SMTP servers such as Mailpit expect.
+func NewSMTPNotifier(config configs.SMTPConfig) Notifier {
+	return &smtpNotifier{config}
+}
+
+func (n *smtpNotifier) Notify(ctx context.Context, message Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if n.config.Username != "" {
+		auth = smtp.PlainAuth("", n.config.Username, n.config.Password, n.config.Host)
+	}
+
+	addr := net.JoinHostPort(n.config.Host, n.config.Port)
+	return smtp.SendMail(addr, auth, n.config.From, []string{message.Recipient}, n.buildMessage(message))
+}
+
+func (n *smtpNotifier) buildMessage(message Message) []byte {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "From: %s\r\n", n.config.From)
+	fmt.Fprintf(&b, "To: %s\r\n", message.Recipient)
+	fmt.Fprintf(&b, "Subject: %s\r\n", sanitizeHeader(message.Subject))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(message.Body, "\n", "\r\n"))
+
+	return []byte(b.String())
+}
+
+func sanitizeHeader(value string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+}
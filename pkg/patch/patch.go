@@ -0,0 +1,92 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"time"
+)
+
+// Field is a member of an RFC 7396 JSON Merge Patch document. Set reports
+// whether the member was present at all and Null whether it was explicitly null,
+// so omitted members can be left untouched and null ones cleared.
+type Field[T any] struct {
+	Value T
+	Set   bool
+	Null  bool
+}
+
+// Value returns a field set to v.
+func Value[T any](v T) Field[T] {
+	return Field[T]{Value: v, Set: true}
+}
+
+// Null returns a field explicitly set to null.
+func Null[T any]() Field[T] {
+	return Field[T]{Set: true, Null: true}
+}
+
+func (f *Field[T]) UnmarshalJSON(data []byte) error {
+	f.Set = true
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		f.Null = true
+		return nil
+	}
+
+	return json.Unmarshal(data, &f.Value)
+}
+
+// Apply writes the patched value into dst. Null resets dst to its zero value.
+func (f Field[T]) Apply(dst *T) {
+	if !f.Set {
+		return
+	}
+
+	if f.Null {
+		var zero T
+		*dst = zero
+		return
+	}
+
+	*dst = f.Value
+}
+
+// ApplyPtr writes the patched value into a nullable dst. Null clears it.
+func (f Field[T]) ApplyPtr(dst **T) {
+	if !f.Set {
+		return
+	}
+
+	if f.Null {
+		*dst = nil
+		return
+	}
+
+	value := f.Value
+	*dst = &value
+}
+
+// ValidationValue exposes the value to the validator. Omitted and null fields
+// yield nil, so "omitempty" rules only run against values that were sent.
+func ValidationValue(field reflect.Value) interface{} {
+	if valuer, ok := field.Interface().(interface{ validationValue() interface{} }); ok {
+		return valuer.validationValue()
+	}
+	return nil
+}
+
+func (f Field[T]) validationValue() interface{} {
+	if !f.Set || f.Null {
+		return nil
+	}
+	return f.Value
+}
+
+// Types lists the field types the validator must unwrap with ValidationValue.
+var Types = []interface{}{
+	Field[string]{},
+	Field[bool]{},
+	Field[int]{},
+	Field[float64]{},
+	Field[time.Time]{},
+}
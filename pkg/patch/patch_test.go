@@ -0,0 +1,78 @@
+package patch_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sherwin-77/golang-todos/pkg/patch"
+	"github.com/stretchr/testify/suite"
+)
+
+type PatchTestSuite struct {
+	suite.Suite
+}
+
+func TestPatch(t *testing.T) {
+	suite.Run(t, new(PatchTestSuite))
+}
+
+type document struct {
+	Title  patch.Field[string]    `json:"title" validate:"omitempty,max=5"`
+	DueAt  patch.Field[time.Time] `json:"due_at"`
+	Points patch.Field[int]       `json:"points"`
+}
+
+func (s *PatchTestSuite) TestUnmarshal() {
+	var doc document
+	s.Require().NoError(json.Unmarshal([]byte(`{"title":"Home","due_at":null}`), &doc))
+
+	s.Equal(patch.Value("Home"), doc.Title)
+	s.Equal(patch.Null[time.Time](), doc.DueAt)
+	s.False(doc.Points.Set)
+}
+
+func (s *PatchTestSuite) TestApply() {
+	s.Run("Omitted field is untouched", func() {
+		title := "Keep"
+		patch.Field[string]{}.Apply(&title)
+		s.Equal("Keep", title)
+	})
+
+	s.Run("Null resets to zero value", func() {
+		title := "Keep"
+		patch.Null[string]().Apply(&title)
+		s.Equal("", title)
+	})
+
+	s.Run("Value replaces", func() {
+		title := "Keep"
+		patch.Value("New").Apply(&title)
+		s.Equal("New", title)
+	})
+
+	s.Run("Null clears pointer", func() {
+		now := time.Now()
+		dueAt := &now
+		patch.Null[time.Time]().ApplyPtr(&dueAt)
+		s.Nil(dueAt)
+	})
+
+	s.Run("Value sets pointer", func() {
+		now := time.Now()
+		var dueAt *time.Time
+		patch.Value(now).ApplyPtr(&dueAt)
+		s.Equal(&now, dueAt)
+	})
+}
+
+func (s *PatchTestSuite) TestValidation() {
+	validate := validator.New()
+	validate.RegisterCustomTypeFunc(patch.ValidationValue, patch.Types...)
+
+	s.Nil(validate.Struct(document{}))
+	s.Nil(validate.Struct(document{Title: patch.Null[string]()}))
+	s.Nil(validate.Struct(document{Title: patch.Value("Home")}))
+	s.Error(validate.Struct(document{Title: patch.Value("Groceries")}))
+}
@@ -22,3 +22,17 @@ func NewResponse(code int, message string, data interface{}, meta *Meta) *Respon
 		Meta:    meta,
 	}
 }
+
+func NewMeta(page int, perPage int, total int) *Meta {
+	lastPage := 1
+	if perPage > 0 && total > 0 {
+		lastPage = (total + perPage - 1) / perPage
+	}
+
+	return &Meta{
+		Page:     page,
+		PerPage:  perPage,
+		LastPage: lastPage,
+		Total:    total,
+	}
+}
@@ -0,0 +1,300 @@
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+// maxIterations bounds the search for rules that may never match, such as a
+// fifth Monday every twelve months.
+const maxIterations = 1000
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Weekday is a BYDAY entry. N selects the nth weekday of the month (negative
+// counts from the end); zero means every such weekday.
+type Weekday struct {
+	N   int
+	Day time.Weekday
+}
+
+// Rule is the subset of an RFC 5545 RRULE supported by todos: FREQ, INTERVAL,
+// BYDAY, COUNT and UNTIL.
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	ByDay    []Weekday
+	Count    int
+	Until    *time.Time
+}
+
+func Parse(value string) (*Rule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return nil, errors.New("empty rule")
+	}
+
+	rule := &Rule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return nil, fmt.Errorf("malformed part %q", part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			switch freq := Frequency(strings.ToUpper(val)); freq {
+			case Daily, Weekly, Monthly:
+				rule.Freq = freq
+			default:
+				return nil, fmt.Errorf("unsupported FREQ %q", val)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("invalid INTERVAL %q", val)
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("invalid COUNT %q", val)
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := parseUntil(val)
+			if err != nil {
+				return nil, err
+			}
+			rule.Until = &until
+		case "BYDAY":
+			for _, day := range strings.Split(val, ",") {
+				weekday, err := parseWeekday(day)
+				if err != nil {
+					return nil, err
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported part %q", key)
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, errors.New("FREQ is required")
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, errors.New("COUNT and UNTIL must not both be set")
+	}
+	for _, day := range rule.ByDay {
+		if day.N != 0 && rule.Freq != Monthly {
+			return nil, errors.New("numbered BYDAY is only supported with FREQ=MONTHLY")
+		}
+	}
+
+	return rule, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("20060102T150405", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		// A date-only UNTIL includes the whole day.
+		return t.Add(24*time.Hour - time.Nanosecond), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid UNTIL %q", value)
+}
+
+func parseWeekday(value string) (Weekday, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if len(value) < 2 {
+		return Weekday{}, fmt.Errorf("invalid BYDAY %q", value)
+	}
+
+	day, ok := weekdays[value[len(value)-2:]]
+	if !ok {
+		return Weekday{}, fmt.Errorf("invalid BYDAY %q", value)
+	}
+
+	var n int
+	if prefix := value[:len(value)-2]; prefix != "" {
+		var err error
+		n, err = strconv.Atoi(prefix)
+		if err != nil || n == 0 || n < -5 || n > 5 {
+			return Weekday{}, fmt.Errorf("invalid BYDAY %q", value)
+		}
+	}
+
+	return Weekday{N: n, Day: day}, nil
+}
+
+// String formats the rule in canonical RRULE form, without the "RRULE:" prefix.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = strings.ToUpper(day.Day.String()[:2])
+			if day.N != 0 {
+				days[i] = strconv.Itoa(day.N) + days[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence after t, where t is occurrence number n of
+// the series (starting at 1). It reports false once COUNT or UNTIL ends the series.
+// Calendar arithmetic happens in t's location, so pass t in the user's time zone.
+func (r *Rule) Next(t time.Time, n int) (time.Time, bool) {
+	if r.Count > 0 && n >= r.Count {
+		return time.Time{}, false
+	}
+
+	var next time.Time
+	var ok bool
+	switch r.Freq {
+	case Daily:
+		next, ok = r.nextDaily(t)
+	case Weekly:
+		next, ok = r.nextWeekly(t)
+	case Monthly:
+		next, ok = r.nextMonthly(t)
+	}
+
+	if !ok || (r.Until != nil && next.After(*r.Until)) {
+		return time.Time{}, false
+	}
+
+	return next, true
+}
+
+func (r *Rule) matchesDay(t time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, day := range r.ByDay {
+		if day.Day == t.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) nextDaily(t time.Time) (time.Time, bool) {
+	for i := 1; i <= maxIterations; i++ {
+		candidate := t.AddDate(0, 0, i*r.Interval)
+		if r.matchesDay(candidate) {
+			return candidate, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (r *Rule) nextWeekly(t time.Time) (time.Time, bool) {
+	if len(r.ByDay) == 0 {
+		return t.AddDate(0, 0, 7*r.Interval), true
+	}
+
+	// Weeks start on Monday (the RFC 5545 default WKST).
+	offset := (int(t.Weekday()) + 6) % 7
+	for day := offset + 1; day < 7; day++ {
+		if candidate := t.AddDate(0, 0, day-offset); r.matchesDay(candidate) {
+			return candidate, true
+		}
+	}
+
+	weekStart := t.AddDate(0, 0, -offset+7*r.Interval)
+	for day := 0; day < 7; day++ {
+		if candidate := weekStart.AddDate(0, 0, day); r.matchesDay(candidate) {
+			return candidate, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func (r *Rule) nextMonthly(t time.Time) (time.Time, bool) {
+	year, month, _ := t.Date()
+	hour, minute, second := t.Clock()
+
+	for i := 0; i <= maxIterations; i++ {
+		first := time.Date(year, month+time.Month(i*r.Interval), 1, hour, minute, second, t.Nanosecond(), t.Location())
+		for _, candidate := range r.monthCandidates(first, t.Day()) {
+			if candidate.After(t) {
+				return candidate, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// monthCandidates lists the occurrences within the month starting at first, in order.
+func (r *Rule) monthCandidates(first time.Time, dayOfMonth int) []time.Time {
+	daysInMonth := first.AddDate(0, 1, -1).Day()
+
+	if len(r.ByDay) == 0 {
+		// Months without the day (e.g. the 31st) are skipped, as in RFC 5545.
+		if dayOfMonth > daysInMonth {
+			return nil
+		}
+		return []time.Time{first.AddDate(0, 0, dayOfMonth-1)}
+	}
+
+	var candidates []time.Time
+	for _, byDay := range r.ByDay {
+		var matches []time.Time
+		for day := 1; day <= daysInMonth; day++ {
+			if candidate := first.AddDate(0, 0, day-1); candidate.Weekday() == byDay.Day {
+				matches = append(matches, candidate)
+			}
+		}
+
+		switch {
+		case byDay.N == 0:
+			candidates = append(candidates, matches...)
+		case byDay.N > 0 && byDay.N <= len(matches):
+			candidates = append(candidates, matches[byDay.N-1])
+		case byDay.N < 0 && -byDay.N <= len(matches):
+			candidates = append(candidates, matches[len(matches)+byDay.N])
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+	return candidates
+}
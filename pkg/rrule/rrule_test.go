@@ -0,0 +1,80 @@
+package rrule_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sherwin-77/golang-todos/pkg/rrule"
+	"github.com/stretchr/testify/suite"
+)
+
+type RRuleTestSuite struct {
+	suite.Suite
+}
+
+func TestRRule(t *testing.T) {
+	suite.Run(t, new(RRuleTestSuite))
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+}
+
+func (s *RRuleTestSuite) TestParse() {
+	s.Run("Invalid rules", func() {
+		for _, value := range []string{
+			"",
+			"INTERVAL=2",
+			"FREQ=YEARLY",
+			"FREQ=DAILY;INTERVAL=0",
+			"FREQ=DAILY;COUNT=2;UNTIL=20241231",
+			"FREQ=WEEKLY;BYDAY=XX",
+			"FREQ=WEEKLY;BYDAY=1MO",
+			"FREQ=DAILY;BYMONTH=1",
+		} {
+			_, err := rrule.Parse(value)
+			s.Error(err, value)
+		}
+	})
+
+	s.Run("Canonical form", func() {
+		rule, err := rrule.Parse("RRULE:freq=monthly;byday=-1fr,1mo;interval=2;until=20241231")
+		s.Nil(err)
+		s.Equal("FREQ=MONTHLY;INTERVAL=2;BYDAY=-1FR,1MO;UNTIL=20241231T235959Z", rule.String())
+	})
+}
+
+func (s *RRuleTestSuite) TestNext() {
+	cases := []struct {
+		rule     string
+		from     time.Time
+		n        int
+		expected time.Time
+		ok       bool
+	}{
+		{"FREQ=DAILY", date(2024, 12, 31), 1, date(2025, 1, 1), true},
+		{"FREQ=DAILY;INTERVAL=3", date(2024, 12, 2), 1, date(2024, 12, 5), true},
+		{"FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR", date(2024, 12, 6), 1, date(2024, 12, 9), true},
+		{"FREQ=WEEKLY", date(2024, 12, 2), 1, date(2024, 12, 9), true},
+		{"FREQ=WEEKLY;BYDAY=MO,TH", date(2024, 12, 2), 1, date(2024, 12, 5), true},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", date(2024, 12, 5), 2, date(2024, 12, 16), true},
+		{"FREQ=WEEKLY;BYDAY=SU", date(2024, 12, 8), 1, date(2024, 12, 15), true},
+		{"FREQ=MONTHLY", date(2024, 1, 15), 1, date(2024, 2, 15), true},
+		{"FREQ=MONTHLY", date(2024, 1, 31), 1, date(2024, 3, 31), true},
+		{"FREQ=MONTHLY;BYDAY=1MO", date(2024, 12, 2), 1, date(2025, 1, 6), true},
+		{"FREQ=MONTHLY;BYDAY=-1FR", date(2024, 11, 29), 1, date(2024, 12, 27), true},
+		{"FREQ=DAILY;COUNT=3", date(2024, 12, 2), 2, date(2024, 12, 3), true},
+		{"FREQ=DAILY;COUNT=3", date(2024, 12, 3), 3, time.Time{}, false},
+		{"FREQ=WEEKLY;UNTIL=20241210", date(2024, 12, 2), 1, date(2024, 12, 9), true},
+		{"FREQ=WEEKLY;UNTIL=20241210", date(2024, 12, 9), 2, time.Time{}, false},
+	}
+
+	for _, c := range cases {
+		rule, err := rrule.Parse(c.rule)
+		s.Require().Nil(err, c.rule)
+
+		next, ok := rule.Next(c.from, c.n)
+		s.Equal(c.ok, ok, c.rule)
+		s.True(c.expected.Equal(next), "%s from %s: got %s", c.rule, c.from, next)
+	}
+}
@@ -0,0 +1,90 @@
+package storage_test
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sherwin-77/golang-todos/configs"
+	"github.com/sherwin-77/golang-todos/pkg/storage"
+	"github.com/stretchr/testify/suite"
+)
+
+type LocalTestSuite struct {
+	suite.Suite
+	store *storage.LocalStore
+}
+
+func TestLocalStore(t *testing.T) {
+	suite.Run(t, new(LocalTestSuite))
+}
+
+func (s *LocalTestSuite) SetupTest() {
+	s.store = storage.NewLocalStore(configs.LocalStorageConfig{
+		Path: s.T().TempDir(),
+		URL:  "http://localhost:8080/api/v1/blobs",
+	}, "secret")
+}
+
+func (s *LocalTestSuite) TestRoundTrip() {
+	ctx := context.Background()
+	key := "todos/1/report"
+
+	s.Nil(s.store.Put(ctx, key, strings.NewReader("hello"), 5, "text/plain"))
+
+	body, err := s.store.Open(ctx, key)
+	s.Nil(err)
+	data, _ := io.ReadAll(body)
+	body.Close()
+	s.Equal("hello", string(data))
+
+	s.Nil(s.store.Delete(ctx, key))
+	s.Nil(s.store.Delete(ctx, key))
+	_, err = s.store.Open(ctx, key)
+	s.ErrorIs(err, storage.ErrNotFound)
+}
+
+func (s *LocalTestSuite) TestInvalidKey() {
+	for _, key := range []string{"../etc/passwd", "todos/../../secret", "/absolute", "todos//1", ""} {
+		_, err := s.store.Open(context.Background(), key)
+		s.Error(err, key)
+		s.NotErrorIs(err, storage.ErrNotFound, key)
+	}
+}
+
+func (s *LocalTestSuite) TestSignedURL() {
+	signed, err := s.store.SignedURL(context.Background(), "todos/1/report", storage.URLOptions{
+		Expires:     time.Minute,
+		FileName:    "report.pdf",
+		ContentType: "application/pdf",
+	})
+	s.Nil(err)
+
+	u, err := url.Parse(signed)
+	s.Nil(err)
+	s.Equal("/api/v1/blobs/todos/1/report", u.Path)
+
+	s.Run("Valid signature", func() {
+		s.Nil(s.store.Verify("todos/1/report", u.Query()))
+	})
+
+	s.Run("Other key", func() {
+		s.ErrorIs(s.store.Verify("todos/2/report", u.Query()), storage.ErrInvalidSignature)
+	})
+
+	s.Run("Tampered file name", func() {
+		query := u.Query()
+		query.Set("name", "evil.html")
+		s.ErrorIs(s.store.Verify("todos/1/report", query), storage.ErrInvalidSignature)
+	})
+
+	s.Run("Expired", func() {
+		signed, err := s.store.SignedURL(context.Background(), "todos/1/report", storage.URLOptions{Expires: -time.Minute})
+		s.Nil(err)
+		expired, _ := url.Parse(signed)
+		s.ErrorIs(s.store.Verify("todos/1/report", expired.Query()), storage.ErrInvalidSignature)
+	})
+}
@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/sherwin-77/golang-todos/configs"
+)
+
+// ErrNotFound is returned when a blob does not exist.
+var ErrNotFound = errors.New("blob not found")
+
+// keyPattern limits keys to slash separated segments that are safe both as
+// S3 object keys and as relative file paths. Segments cannot start with a dot.
+var keyPattern = regexp.MustCompile(`^[\w-][\w.-]*(/[\w-][\w.-]*)*$`)
+
+// URLOptions describes how a signed URL serves a blob.
+type URLOptions struct {
+	Expires     time.Duration
+	FileName    string
+	ContentType string
+}
+
+type BlobStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	SignedURL(ctx context.Context, key string, options URLOptions) (string, error)
+}
+
+// NewBlobStore builds the store selected by config.Driver ("local" or "s3").
+// Local URLs are signed with secret.
+func NewBlobStore(config configs.StorageConfig, secret string) (BlobStore, error) {
+	switch config.Driver {
+	case "", "local":
+		return NewLocalStore(config.Local, secret), nil
+	case "s3":
+		if config.S3.Endpoint == "" || config.S3.Bucket == "" {
+			return nil, fmt.Errorf("s3 storage requires S3_ENDPOINT and S3_BUCKET")
+		}
+		return NewS3Store(config.S3), nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", config.Driver)
+	}
+}
+
+func validateKey(key string) error {
+	if !keyPattern.MatchString(key) {
+		return fmt.Errorf("invalid blob key %q", key)
+	}
+
+	return nil
+}
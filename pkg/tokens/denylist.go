@@ -0,0 +1,53 @@
+package tokens
+
+import (
+	"time"
+
+	"github.com/sherwin-77/golang-todos/pkg/caches"
+)
+
+// Denylist revokes single access tokens by their jti until they would have
+// expired anyway. All tokens of a user are revoked by changing the user's
+// token version, which every token carries in its ver claim and which is
+// stored with the user.
+type Denylist interface {
+	Revoke(claims *JWTCustomClaims) error
+	IsRevoked(claims *JWTCustomClaims) (bool, error)
+}
+
+type denylist struct {
+	cache caches.Cache
+}
+
+func NewDenylist(cache caches.Cache) Denylist {
+	return &denylist{cache}
+}
+
+func (d *denylist) Revoke(claims *JWTCustomClaims) error {
+	if claims.RegisteredClaims.ID == "" || claims.ExpiresAt == nil {
+		return nil
+	}
+
+	ttl := time.Until(claims.ExpiresAt.Time)
+	if ttl <= 0 {
+		return nil
+	}
+
+	return d.cache.Set("tokens:revoked:"+claims.RegisteredClaims.ID, "1", ttl)
+}
+
+// IsRevoked reports whether the token was revoked by its jti. It returns an
+// error when the denylist cannot be read, so callers can refuse the token
+// instead of letting it through.
+func (d *denylist) IsRevoked(claims *JWTCustomClaims) (bool, error) {
+	if claims.RegisteredClaims.ID == "" {
+		return false, nil
+	}
+
+	value, err := d.cache.Lookup("tokens:revoked:" + claims.RegisteredClaims.ID)
+	if err != nil {
+		return false, err
+	}
+
+	return value != "", nil
+}
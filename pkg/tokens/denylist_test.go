@@ -0,0 +1,89 @@
+package tokens_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/sherwin-77/golang-todos/pkg/tokens"
+	mock_caches "github.com/sherwin-77/golang-todos/test/mock/pkg/caches"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type DenylistTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	cache    *mock_caches.MockCache
+	denylist tokens.Denylist
+}
+
+func TestDenylist(t *testing.T) {
+	suite.Run(t, new(DenylistTestSuite))
+}
+
+func (s *DenylistTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.cache = mock_caches.NewMockCache(s.ctrl)
+	s.denylist = tokens.NewDenylist(s.cache)
+}
+
+func (s *DenylistTestSuite) newClaims() *tokens.JWTCustomClaims {
+	return &tokens.JWTCustomClaims{
+		ID: uuid.NewString(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(10 * time.Minute)),
+		},
+	}
+}
+
+func (s *DenylistTestSuite) TestRevoke() {
+	s.Run("Token is denied until it expires", func() {
+		claims := s.newClaims()
+		s.cache.EXPECT().Set("tokens:revoked:"+claims.RegisteredClaims.ID, "1", gomock.Any()).
+			DoAndReturn(func(_ string, _ interface{}, ttl time.Duration) error {
+				s.InDelta(10*time.Minute, ttl, float64(time.Minute))
+				return nil
+			})
+
+		s.Nil(s.denylist.Revoke(claims))
+	})
+
+	s.Run("Expired token is not stored", func() {
+		claims := s.newClaims()
+		claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+
+		s.Nil(s.denylist.Revoke(claims))
+	})
+}
+
+func (s *DenylistTestSuite) TestIsRevoked() {
+	s.Run("Revoked token", func() {
+		claims := s.newClaims()
+		s.cache.EXPECT().Lookup("tokens:revoked:"+claims.RegisteredClaims.ID).Return("1", nil)
+
+		revoked, err := s.denylist.IsRevoked(claims)
+		s.Nil(err)
+		s.True(revoked)
+	})
+
+	s.Run("Valid token", func() {
+		claims := s.newClaims()
+		s.cache.EXPECT().Lookup("tokens:revoked:"+claims.RegisteredClaims.ID).Return("", nil)
+
+		revoked, err := s.denylist.IsRevoked(claims)
+		s.Nil(err)
+		s.False(revoked)
+	})
+
+	s.Run("Denylist cannot be read", func() {
+		claims := s.newClaims()
+		s.cache.EXPECT().Lookup("tokens:revoked:"+claims.RegisteredClaims.ID).Return("", errors.New("connection refused"))
+
+		_, err := s.denylist.IsRevoked(claims)
+		s.NotNil(err)
+	})
+}
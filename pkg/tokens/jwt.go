@@ -2,9 +2,20 @@ package tokens
 
 import "github.com/golang-jwt/jwt/v5"
 
+// PurposeMFAPending marks the token returned by a login that still needs a
+// second factor. It only proves the password and is not an access token.
+const PurposeMFAPending = "mfa_pending"
+
+// JWTCustomClaims identifies the user by ID. The token itself is identified by
+// the jti in RegisteredClaims, SessionID is the refresh token family it was
+// issued with and Version the user's token version at the time. Access tokens
+// have no Purpose.
 type JWTCustomClaims struct {
-	ID       string `json:"id"`
-	Username string `json:"username"`
+	ID        string `json:"id"`
+	Username  string `json:"username"`
+	SessionID string `json:"sid,omitempty"`
+	Version   string `json:"ver,omitempty"`
+	Purpose   string `json:"purpose,omitempty"`
 	jwt.RegisteredClaims
 }
 
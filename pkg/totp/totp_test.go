@@ -0,0 +1,97 @@
+package totp_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/sherwin-77/golang-todos/pkg/totp"
+	"github.com/stretchr/testify/suite"
+)
+
+// rfcSecret is the SHA-1 key of the RFC 6238 test vectors, "12345678901234567890".
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+type TOTPTestSuite struct {
+	suite.Suite
+}
+
+func TestTOTP(t *testing.T) {
+	suite.Run(t, new(TOTPTestSuite))
+}
+
+func (s *TOTPTestSuite) TestCode() {
+	s.Run("RFC 6238 test vectors", func() {
+		// The RFC lists 8 digit codes; 6 digit codes are their last six digits.
+		for unix, expected := range map[int64]string{
+			59:          "287082",
+			1111111109:  "081804",
+			1111111111:  "050471",
+			1234567890:  "005924",
+			2000000000:  "279037",
+			20000000000: "353130",
+		} {
+			code, err := totp.Code(rfcSecret, time.Unix(unix, 0))
+
+			s.Nil(err)
+			s.Equal(expected, code, unix)
+		}
+	})
+
+	s.Run("Invalid secret", func() {
+		_, err := totp.Code("not base32!", time.Now())
+
+		s.ErrorIs(err, totp.ErrInvalidSecret)
+	})
+}
+
+func (s *TOTPTestSuite) TestValidate() {
+	now := time.Unix(1111111111, 0)
+
+	s.Run("Accepts adjacent periods", func() {
+		for _, offset := range []time.Duration{-totp.Period, 0, totp.Period} {
+			code, _ := totp.Code(rfcSecret, now.Add(offset))
+			counter, ok := totp.Validate(rfcSecret, code, now)
+
+			s.True(ok)
+			s.Equal(totp.Counter(now.Add(offset)), counter)
+		}
+	})
+
+	s.Run("Rejects codes outside the skew", func() {
+		code, _ := totp.Code(rfcSecret, now.Add(-2*totp.Period))
+		_, ok := totp.Validate(rfcSecret, code, now)
+
+		s.False(ok)
+	})
+
+	s.Run("Rejects malformed codes", func() {
+		for _, code := range []string{"", "05047", "0504711", "abcdef"} {
+			_, ok := totp.Validate(rfcSecret, code, now)
+
+			s.False(ok, code)
+		}
+	})
+}
+
+func (s *TOTPTestSuite) TestGenerateSecret() {
+	secret, err := totp.GenerateSecret()
+
+	s.Nil(err)
+	s.Len(secret, 32)
+	_, err = totp.Code(secret, time.Now())
+	s.Nil(err)
+}
+
+func (s *TOTPTestSuite) TestURI() {
+	u, err := url.Parse(totp.URI("Golang Todos", "alice@example.com", rfcSecret))
+
+	s.Nil(err)
+	s.Equal("otpauth", u.Scheme)
+	s.Equal("totp", u.Host)
+	s.Equal("/Golang Todos:alice@example.com", u.Path)
+	s.Equal(rfcSecret, u.Query().Get("secret"))
+	s.Equal("Golang Todos", u.Query().Get("issuer"))
+	s.Equal("6", u.Query().Get("digits"))
+	s.Equal("30", u.Query().Get("period"))
+}
@@ -16,6 +16,56 @@ import (
 	gorm "gorm.io/gorm"
 )
 
+// Mockversioned is a mock of versioned interface.
+type Mockversioned struct {
+	ctrl     *gomock.Controller
+	recorder *MockversionedMockRecorder
+	isgomock struct{}
+}
+
+// MockversionedMockRecorder is the mock recorder for Mockversioned.
+type MockversionedMockRecorder struct {
+	mock *Mockversioned
+}
+
+// NewMockversioned creates a new mock instance.
+func NewMockversioned(ctrl *gomock.Controller) *Mockversioned {
+	mock := &Mockversioned{ctrl: ctrl}
+	mock.recorder = &MockversionedMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockversioned) EXPECT() *MockversionedMockRecorder {
+	return m.recorder
+}
+
+// CurrentVersion mocks base method.
+func (m *Mockversioned) CurrentVersion() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CurrentVersion")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// CurrentVersion indicates an expected call of CurrentVersion.
+func (mr *MockversionedMockRecorder) CurrentVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CurrentVersion", reflect.TypeOf((*Mockversioned)(nil).CurrentVersion))
+}
+
+// SetVersion mocks base method.
+func (m *Mockversioned) SetVersion(version int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetVersion", version)
+}
+
+// SetVersion indicates an expected call of SetVersion.
+func (mr *MockversionedMockRecorder) SetVersion(version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVersion", reflect.TypeOf((*Mockversioned)(nil).SetVersion), version)
+}
+
 // MockBaseRepository is a mock of BaseRepository interface.
 type MockBaseRepository struct {
 	ctrl     *gomock.Controller
@@ -94,6 +144,20 @@ func (mr *MockBaseRepositoryMockRecorder) SingleTransaction() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SingleTransaction", reflect.TypeOf((*MockBaseRepository)(nil).SingleTransaction))
 }
 
+// WithSavepoint mocks base method.
+func (m *MockBaseRepository) WithSavepoint(tx *gorm.DB, fn func(*gorm.DB) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithSavepoint", tx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithSavepoint indicates an expected call of WithSavepoint.
+func (mr *MockBaseRepositoryMockRecorder) WithSavepoint(tx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithSavepoint", reflect.TypeOf((*MockBaseRepository)(nil).WithSavepoint), tx, fn)
+}
+
 // WithTransaction mocks base method.
 func (m *MockBaseRepository) WithTransaction(fn func(*gorm.DB) error) error {
 	m.ctrl.T.Helper()
@@ -188,6 +188,20 @@ func (mr *MockRoleRepositoryMockRecorder) UpdateRole(ctx, tx, role any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockRoleRepository)(nil).UpdateRole), ctx, tx, role)
 }
 
+// WithSavepoint mocks base method.
+func (m *MockRoleRepository) WithSavepoint(tx *gorm.DB, fn func(*gorm.DB) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithSavepoint", tx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithSavepoint indicates an expected call of WithSavepoint.
+func (mr *MockRoleRepositoryMockRecorder) WithSavepoint(tx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithSavepoint", reflect.TypeOf((*MockRoleRepository)(nil).WithSavepoint), tx, fn)
+}
+
 // WithTransaction mocks base method.
 func (m *MockRoleRepository) WithTransaction(fn func(*gorm.DB) error) error {
 	m.ctrl.T.Helper()
@@ -12,8 +12,10 @@ package mock_repository
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/sherwin-77/golang-todos/internal/entity"
+	repository "github.com/sherwin-77/golang-todos/internal/repository"
 	gomock "go.uber.org/mock/gomock"
 	gorm "gorm.io/gorm"
 )
@@ -42,6 +44,20 @@ func (m *MockTodoRepository) EXPECT() *MockTodoRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddTags mocks base method.
+func (m *MockTodoRepository) AddTags(ctx context.Context, tx *gorm.DB, todo *entity.Todo, tags []*entity.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTags", ctx, tx, todo, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTags indicates an expected call of AddTags.
+func (mr *MockTodoRepositoryMockRecorder) AddTags(ctx, tx, todo, tags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTags", reflect.TypeOf((*MockTodoRepository)(nil).AddTags), ctx, tx, todo, tags)
+}
+
 // BeginTransaction mocks base method.
 func (m *MockTodoRepository) BeginTransaction() *gorm.DB {
 	m.ctrl.T.Helper()
@@ -70,6 +86,70 @@ func (mr *MockTodoRepositoryMockRecorder) Commit(tx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockTodoRepository)(nil).Commit), tx)
 }
 
+// CompleteSubtasks mocks base method.
+func (m *MockTodoRepository) CompleteSubtasks(ctx context.Context, tx *gorm.DB, parent *entity.Todo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteSubtasks", ctx, tx, parent)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteSubtasks indicates an expected call of CompleteSubtasks.
+func (mr *MockTodoRepositoryMockRecorder) CompleteSubtasks(ctx, tx, parent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteSubtasks", reflect.TypeOf((*MockTodoRepository)(nil).CompleteSubtasks), ctx, tx, parent)
+}
+
+// CountDeletedTodos mocks base method.
+func (m *MockTodoRepository) CountDeletedTodos(ctx context.Context, tx *gorm.DB, userID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDeletedTodos", ctx, tx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDeletedTodos indicates an expected call of CountDeletedTodos.
+func (mr *MockTodoRepositoryMockRecorder) CountDeletedTodos(ctx, tx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDeletedTodos", reflect.TypeOf((*MockTodoRepository)(nil).CountDeletedTodos), ctx, tx, userID)
+}
+
+// CountSearchTodos mocks base method.
+func (m *MockTodoRepository) CountSearchTodos(ctx context.Context, tx *gorm.DB, userID, query string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSearchTodos", ctx, tx, userID, query)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSearchTodos indicates an expected call of CountSearchTodos.
+func (mr *MockTodoRepositoryMockRecorder) CountSearchTodos(ctx, tx, userID, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSearchTodos", reflect.TypeOf((*MockTodoRepository)(nil).CountSearchTodos), ctx, tx, userID, query)
+}
+
+// CountTodosFiltered mocks base method.
+func (m *MockTodoRepository) CountTodosFiltered(ctx context.Context, tx *gorm.DB, query any, args ...any) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, tx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CountTodosFiltered", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTodosFiltered indicates an expected call of CountTodosFiltered.
+func (mr *MockTodoRepositoryMockRecorder) CountTodosFiltered(ctx, tx, query any, args ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, tx, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTodosFiltered", reflect.TypeOf((*MockTodoRepository)(nil).CountTodosFiltered), varargs...)
+}
+
 // CreateTodo mocks base method.
 func (m *MockTodoRepository) CreateTodo(ctx context.Context, tx *gorm.DB, todo *entity.Todo) error {
 	m.ctrl.T.Helper()
@@ -98,6 +178,126 @@ func (mr *MockTodoRepositoryMockRecorder) DeleteTodo(ctx, tx, todo any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTodo", reflect.TypeOf((*MockTodoRepository)(nil).DeleteTodo), ctx, tx, todo)
 }
 
+// GetDeletedTodoByID mocks base method.
+func (m *MockTodoRepository) GetDeletedTodoByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedTodoByID", ctx, tx, id)
+	ret0, _ := ret[0].(*entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedTodoByID indicates an expected call of GetDeletedTodoByID.
+func (mr *MockTodoRepositoryMockRecorder) GetDeletedTodoByID(ctx, tx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedTodoByID", reflect.TypeOf((*MockTodoRepository)(nil).GetDeletedTodoByID), ctx, tx, id)
+}
+
+// GetDeletedTodos mocks base method.
+func (m *MockTodoRepository) GetDeletedTodos(ctx context.Context, tx *gorm.DB, userID string, limit, offset int) ([]entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedTodos", ctx, tx, userID, limit, offset)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedTodos indicates an expected call of GetDeletedTodos.
+func (mr *MockTodoRepositoryMockRecorder) GetDeletedTodos(ctx, tx, userID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedTodos", reflect.TypeOf((*MockTodoRepository)(nil).GetDeletedTodos), ctx, tx, userID, limit, offset)
+}
+
+// GetDueReminders mocks base method.
+func (m *MockTodoRepository) GetDueReminders(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueReminders", ctx, tx, now, limit)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueReminders indicates an expected call of GetDueReminders.
+func (mr *MockTodoRepositoryMockRecorder) GetDueReminders(ctx, tx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueReminders", reflect.TypeOf((*MockTodoRepository)(nil).GetDueReminders), ctx, tx, now, limit)
+}
+
+// GetOccurrences mocks base method.
+func (m *MockTodoRepository) GetOccurrences(ctx context.Context, tx *gorm.DB, recurrenceID string) ([]entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOccurrences", ctx, tx, recurrenceID)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOccurrences indicates an expected call of GetOccurrences.
+func (mr *MockTodoRepositoryMockRecorder) GetOccurrences(ctx, tx, recurrenceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOccurrences", reflect.TypeOf((*MockTodoRepository)(nil).GetOccurrences), ctx, tx, recurrenceID)
+}
+
+// GetSiblingAfter mocks base method.
+func (m *MockTodoRepository) GetSiblingAfter(ctx context.Context, tx *gorm.DB, todo, neighbour *entity.Todo) (*entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiblingAfter", ctx, tx, todo, neighbour)
+	ret0, _ := ret[0].(*entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiblingAfter indicates an expected call of GetSiblingAfter.
+func (mr *MockTodoRepositoryMockRecorder) GetSiblingAfter(ctx, tx, todo, neighbour any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiblingAfter", reflect.TypeOf((*MockTodoRepository)(nil).GetSiblingAfter), ctx, tx, todo, neighbour)
+}
+
+// GetSiblingBefore mocks base method.
+func (m *MockTodoRepository) GetSiblingBefore(ctx context.Context, tx *gorm.DB, todo, neighbour *entity.Todo) (*entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSiblingBefore", ctx, tx, todo, neighbour)
+	ret0, _ := ret[0].(*entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSiblingBefore indicates an expected call of GetSiblingBefore.
+func (mr *MockTodoRepositoryMockRecorder) GetSiblingBefore(ctx, tx, todo, neighbour any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSiblingBefore", reflect.TypeOf((*MockTodoRepository)(nil).GetSiblingBefore), ctx, tx, todo, neighbour)
+}
+
+// GetSubtaskProgress mocks base method.
+func (m *MockTodoRepository) GetSubtaskProgress(ctx context.Context, tx *gorm.DB, parentIDs []string) (map[string]entity.TodoProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubtaskProgress", ctx, tx, parentIDs)
+	ret0, _ := ret[0].(map[string]entity.TodoProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubtaskProgress indicates an expected call of GetSubtaskProgress.
+func (mr *MockTodoRepositoryMockRecorder) GetSubtaskProgress(ctx, tx, parentIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubtaskProgress", reflect.TypeOf((*MockTodoRepository)(nil).GetSubtaskProgress), ctx, tx, parentIDs)
+}
+
+// GetSubtasks mocks base method.
+func (m *MockTodoRepository) GetSubtasks(ctx context.Context, tx *gorm.DB, parentID string) ([]entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubtasks", ctx, tx, parentID)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubtasks indicates an expected call of GetSubtasks.
+func (mr *MockTodoRepositoryMockRecorder) GetSubtasks(ctx, tx, parentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubtasks", reflect.TypeOf((*MockTodoRepository)(nil).GetSubtasks), ctx, tx, parentID)
+}
+
 // GetTodoByID mocks base method.
 func (m *MockTodoRepository) GetTodoByID(ctx context.Context, tx *gorm.DB, id string) (*entity.Todo, error) {
 	m.ctrl.T.Helper()
@@ -113,6 +313,36 @@ func (mr *MockTodoRepositoryMockRecorder) GetTodoByID(ctx, tx, id any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTodoByID", reflect.TypeOf((*MockTodoRepository)(nil).GetTodoByID), ctx, tx, id)
 }
 
+// GetTodoChanges mocks base method.
+func (m *MockTodoRepository) GetTodoChanges(ctx context.Context, tx *gorm.DB, userID string, since repository.ChangeCursor, limit int) ([]entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTodoChanges", ctx, tx, userID, since, limit)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTodoChanges indicates an expected call of GetTodoChanges.
+func (mr *MockTodoRepositoryMockRecorder) GetTodoChanges(ctx, tx, userID, since, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTodoChanges", reflect.TypeOf((*MockTodoRepository)(nil).GetTodoChanges), ctx, tx, userID, since, limit)
+}
+
+// GetTodosByIDs mocks base method.
+func (m *MockTodoRepository) GetTodosByIDs(ctx context.Context, tx *gorm.DB, ids []string) ([]entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTodosByIDs", ctx, tx, ids)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTodosByIDs indicates an expected call of GetTodosByIDs.
+func (mr *MockTodoRepositoryMockRecorder) GetTodosByIDs(ctx, tx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTodosByIDs", reflect.TypeOf((*MockTodoRepository)(nil).GetTodosByIDs), ctx, tx, ids)
+}
+
 // GetTodosByUserID mocks base method.
 func (m *MockTodoRepository) GetTodosByUserID(ctx context.Context, tx *gorm.DB, userID string) ([]entity.Todo, error) {
 	m.ctrl.T.Helper()
@@ -148,6 +378,128 @@ func (mr *MockTodoRepositoryMockRecorder) GetTodosFiltered(ctx, tx, limit, offse
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTodosFiltered", reflect.TypeOf((*MockTodoRepository)(nil).GetTodosFiltered), varargs...)
 }
 
+// IsReminderPending mocks base method.
+func (m *MockTodoRepository) IsReminderPending(ctx context.Context, tx *gorm.DB, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsReminderPending", ctx, tx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsReminderPending indicates an expected call of IsReminderPending.
+func (mr *MockTodoRepositoryMockRecorder) IsReminderPending(ctx, tx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsReminderPending", reflect.TypeOf((*MockTodoRepository)(nil).IsReminderPending), ctx, tx, id)
+}
+
+// MarkReminderSent mocks base method.
+func (m *MockTodoRepository) MarkReminderSent(ctx context.Context, tx *gorm.DB, todo *entity.Todo, sentAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkReminderSent", ctx, tx, todo, sentAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkReminderSent indicates an expected call of MarkReminderSent.
+func (mr *MockTodoRepositoryMockRecorder) MarkReminderSent(ctx, tx, todo, sentAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkReminderSent", reflect.TypeOf((*MockTodoRepository)(nil).MarkReminderSent), ctx, tx, todo, sentAt)
+}
+
+// NextPosition mocks base method.
+func (m *MockTodoRepository) NextPosition(ctx context.Context, tx *gorm.DB, todo *entity.Todo) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextPosition", ctx, tx, todo)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextPosition indicates an expected call of NextPosition.
+func (mr *MockTodoRepositoryMockRecorder) NextPosition(ctx, tx, todo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextPosition", reflect.TypeOf((*MockTodoRepository)(nil).NextPosition), ctx, tx, todo)
+}
+
+// PurgeDeletedTodos mocks base method.
+func (m *MockTodoRepository) PurgeDeletedTodos(ctx context.Context, tx *gorm.DB, query any, args ...any) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, tx, query}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PurgeDeletedTodos", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedTodos indicates an expected call of PurgeDeletedTodos.
+func (mr *MockTodoRepositoryMockRecorder) PurgeDeletedTodos(ctx, tx, query any, args ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, tx, query}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedTodos", reflect.TypeOf((*MockTodoRepository)(nil).PurgeDeletedTodos), varargs...)
+}
+
+// PurgeTodo mocks base method.
+func (m *MockTodoRepository) PurgeTodo(ctx context.Context, tx *gorm.DB, todo *entity.Todo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTodo", ctx, tx, todo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeTodo indicates an expected call of PurgeTodo.
+func (mr *MockTodoRepositoryMockRecorder) PurgeTodo(ctx, tx, todo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTodo", reflect.TypeOf((*MockTodoRepository)(nil).PurgeTodo), ctx, tx, todo)
+}
+
+// RebalancePositions mocks base method.
+func (m *MockTodoRepository) RebalancePositions(ctx context.Context, tx *gorm.DB, todo *entity.Todo) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebalancePositions", ctx, tx, todo)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RebalancePositions indicates an expected call of RebalancePositions.
+func (mr *MockTodoRepositoryMockRecorder) RebalancePositions(ctx, tx, todo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebalancePositions", reflect.TypeOf((*MockTodoRepository)(nil).RebalancePositions), ctx, tx, todo)
+}
+
+// RemoveTags mocks base method.
+func (m *MockTodoRepository) RemoveTags(ctx context.Context, tx *gorm.DB, todo *entity.Todo, tags []*entity.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTags", ctx, tx, todo, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTags indicates an expected call of RemoveTags.
+func (mr *MockTodoRepositoryMockRecorder) RemoveTags(ctx, tx, todo, tags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTags", reflect.TypeOf((*MockTodoRepository)(nil).RemoveTags), ctx, tx, todo, tags)
+}
+
+// RestoreTodo mocks base method.
+func (m *MockTodoRepository) RestoreTodo(ctx context.Context, tx *gorm.DB, todo *entity.Todo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreTodo", ctx, tx, todo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreTodo indicates an expected call of RestoreTodo.
+func (mr *MockTodoRepositoryMockRecorder) RestoreTodo(ctx, tx, todo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTodo", reflect.TypeOf((*MockTodoRepository)(nil).RestoreTodo), ctx, tx, todo)
+}
+
 // Rollback mocks base method.
 func (m *MockTodoRepository) Rollback(tx *gorm.DB) {
 	m.ctrl.T.Helper()
@@ -160,6 +512,21 @@ func (mr *MockTodoRepositoryMockRecorder) Rollback(tx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockTodoRepository)(nil).Rollback), tx)
 }
 
+// SearchTodos mocks base method.
+func (m *MockTodoRepository) SearchTodos(ctx context.Context, tx *gorm.DB, userID, query string, limit, offset int) ([]entity.TodoMatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTodos", ctx, tx, userID, query, limit, offset)
+	ret0, _ := ret[0].([]entity.TodoMatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTodos indicates an expected call of SearchTodos.
+func (mr *MockTodoRepositoryMockRecorder) SearchTodos(ctx, tx, userID, query, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTodos", reflect.TypeOf((*MockTodoRepository)(nil).SearchTodos), ctx, tx, userID, query, limit, offset)
+}
+
 // SingleTransaction mocks base method.
 func (m *MockTodoRepository) SingleTransaction() *gorm.DB {
 	m.ctrl.T.Helper()
@@ -188,6 +555,20 @@ func (mr *MockTodoRepositoryMockRecorder) UpdateTodo(ctx, tx, todo any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTodo", reflect.TypeOf((*MockTodoRepository)(nil).UpdateTodo), ctx, tx, todo)
 }
 
+// WithSavepoint mocks base method.
+func (m *MockTodoRepository) WithSavepoint(tx *gorm.DB, fn func(*gorm.DB) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithSavepoint", tx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithSavepoint indicates an expected call of WithSavepoint.
+func (mr *MockTodoRepositoryMockRecorder) WithSavepoint(tx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithSavepoint", reflect.TypeOf((*MockTodoRepository)(nil).WithSavepoint), tx, fn)
+}
+
 // WithTransaction mocks base method.
 func (m *MockTodoRepository) WithTransaction(fn func(*gorm.DB) error) error {
 	m.ctrl.T.Helper()
@@ -70,6 +70,20 @@ func (mr *MockUserRepositoryMockRecorder) BeginTransaction() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTransaction", reflect.TypeOf((*MockUserRepository)(nil).BeginTransaction))
 }
 
+// ChangeTokenVersion mocks base method.
+func (m *MockUserRepository) ChangeTokenVersion(ctx context.Context, tx *gorm.DB, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeTokenVersion", ctx, tx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeTokenVersion indicates an expected call of ChangeTokenVersion.
+func (mr *MockUserRepositoryMockRecorder) ChangeTokenVersion(ctx, tx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeTokenVersion", reflect.TypeOf((*MockUserRepository)(nil).ChangeTokenVersion), ctx, tx, userID)
+}
+
 // Commit mocks base method.
 func (m *MockUserRepository) Commit(tx *gorm.DB) error {
 	m.ctrl.T.Helper()
@@ -112,6 +126,36 @@ func (mr *MockUserRepositoryMockRecorder) DeleteUser(ctx, tx, user any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserRepository)(nil).DeleteUser), ctx, tx, user)
 }
 
+// GetAuthLevel mocks base method.
+func (m *MockUserRepository) GetAuthLevel(ctx context.Context, tx *gorm.DB, userID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthLevel", ctx, tx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthLevel indicates an expected call of GetAuthLevel.
+func (mr *MockUserRepositoryMockRecorder) GetAuthLevel(ctx, tx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthLevel", reflect.TypeOf((*MockUserRepository)(nil).GetAuthLevel), ctx, tx, userID)
+}
+
+// GetUserByCalendarToken mocks base method.
+func (m *MockUserRepository) GetUserByCalendarToken(ctx context.Context, tx *gorm.DB, tokenHash string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByCalendarToken", ctx, tx, tokenHash)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByCalendarToken indicates an expected call of GetUserByCalendarToken.
+func (mr *MockUserRepositoryMockRecorder) GetUserByCalendarToken(ctx, tx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByCalendarToken", reflect.TypeOf((*MockUserRepository)(nil).GetUserByCalendarToken), ctx, tx, tokenHash)
+}
+
 // GetUserByEmail mocks base method.
 func (m *MockUserRepository) GetUserByEmail(ctx context.Context, tx *gorm.DB, email string) (*entity.User, error) {
 	m.ctrl.T.Helper()
@@ -157,6 +201,21 @@ func (mr *MockUserRepositoryMockRecorder) GetUsers(ctx, tx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockUserRepository)(nil).GetUsers), ctx, tx)
 }
 
+// GetUsersByUsernames mocks base method.
+func (m *MockUserRepository) GetUsersByUsernames(ctx context.Context, tx *gorm.DB, usernames []string) ([]entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByUsernames", ctx, tx, usernames)
+	ret0, _ := ret[0].([]entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByUsernames indicates an expected call of GetUsersByUsernames.
+func (mr *MockUserRepositoryMockRecorder) GetUsersByUsernames(ctx, tx, usernames any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByUsernames", reflect.TypeOf((*MockUserRepository)(nil).GetUsersByUsernames), ctx, tx, usernames)
+}
+
 // GetUsersFiltered mocks base method.
 func (m *MockUserRepository) GetUsersFiltered(ctx context.Context, tx *gorm.DB, limit, offset int, order, query any, args ...any) ([]entity.User, error) {
 	m.ctrl.T.Helper()
@@ -177,6 +236,35 @@ func (mr *MockUserRepositoryMockRecorder) GetUsersFiltered(ctx, tx, limit, offse
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersFiltered", reflect.TypeOf((*MockUserRepository)(nil).GetUsersFiltered), varargs...)
 }
 
+// HasAdmin mocks base method.
+func (m *MockUserRepository) HasAdmin(ctx context.Context, tx *gorm.DB) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasAdmin", ctx, tx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasAdmin indicates an expected call of HasAdmin.
+func (mr *MockUserRepositoryMockRecorder) HasAdmin(ctx, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasAdmin", reflect.TypeOf((*MockUserRepository)(nil).HasAdmin), ctx, tx)
+}
+
+// LockAdminGrant mocks base method.
+func (m *MockUserRepository) LockAdminGrant(ctx context.Context, tx *gorm.DB) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockAdminGrant", ctx, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockAdminGrant indicates an expected call of LockAdminGrant.
+func (mr *MockUserRepositoryMockRecorder) LockAdminGrant(ctx, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockAdminGrant", reflect.TypeOf((*MockUserRepository)(nil).LockAdminGrant), ctx, tx)
+}
+
 // RemoveRoles mocks base method.
 func (m *MockUserRepository) RemoveRoles(ctx context.Context, tx *gorm.DB, user *entity.User, roles []*entity.Role) error {
 	m.ctrl.T.Helper()
@@ -231,6 +319,35 @@ func (mr *MockUserRepositoryMockRecorder) UpdateUser(ctx, tx, user any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserRepository)(nil).UpdateUser), ctx, tx, user)
 }
 
+// UseTOTPCounter mocks base method.
+func (m *MockUserRepository) UseTOTPCounter(ctx context.Context, tx *gorm.DB, userID string, counter int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTOTPCounter", ctx, tx, userID, counter)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseTOTPCounter indicates an expected call of UseTOTPCounter.
+func (mr *MockUserRepositoryMockRecorder) UseTOTPCounter(ctx, tx, userID, counter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTOTPCounter", reflect.TypeOf((*MockUserRepository)(nil).UseTOTPCounter), ctx, tx, userID, counter)
+}
+
+// WithSavepoint mocks base method.
+func (m *MockUserRepository) WithSavepoint(tx *gorm.DB, fn func(*gorm.DB) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithSavepoint", tx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithSavepoint indicates an expected call of WithSavepoint.
+func (mr *MockUserRepositoryMockRecorder) WithSavepoint(tx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithSavepoint", reflect.TypeOf((*MockUserRepository)(nil).WithSavepoint), tx, fn)
+}
+
 // WithTransaction mocks base method.
 func (m *MockUserRepository) WithTransaction(fn func(*gorm.DB) error) error {
 	m.ctrl.T.Helper()
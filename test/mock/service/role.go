@@ -58,17 +58,17 @@ func (mr *MockRoleServiceMockRecorder) CreateRole(ctx, request any) *gomock.Call
 }
 
 // DeleteRole mocks base method.
-func (m *MockRoleService) DeleteRole(ctx context.Context, id string) error {
+func (m *MockRoleService) DeleteRole(ctx context.Context, id string, version int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteRole", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteRole", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteRole indicates an expected call of DeleteRole.
-func (mr *MockRoleServiceMockRecorder) DeleteRole(ctx, id any) *gomock.Call {
+func (mr *MockRoleServiceMockRecorder) DeleteRole(ctx, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockRoleService)(nil).DeleteRole), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockRoleService)(nil).DeleteRole), ctx, id, version)
 }
 
 // GetRoleByID mocks base method.
@@ -11,10 +11,12 @@ package mock_service
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
 	entity "github.com/sherwin-77/golang-todos/internal/entity"
 	dto "github.com/sherwin-77/golang-todos/internal/http/dto"
+	response "github.com/sherwin-77/golang-todos/pkg/response"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -42,6 +44,80 @@ func (m *MockTodoService) EXPECT() *MockTodoServiceMockRecorder {
 	return m.recorder
 }
 
+// AssignTodo mocks base method.
+func (m *MockTodoService) AssignTodo(ctx context.Context, request dto.AssignTodoRequest, userID string) (*entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignTodo", ctx, request, userID)
+	ret0, _ := ret[0].(*entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignTodo indicates an expected call of AssignTodo.
+func (mr *MockTodoServiceMockRecorder) AssignTodo(ctx, request, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignTodo", reflect.TypeOf((*MockTodoService)(nil).AssignTodo), ctx, request, userID)
+}
+
+// BulkTodos mocks base method.
+func (m *MockTodoService) BulkTodos(ctx context.Context, request dto.BulkTodoRequest, userID string) ([]dto.BulkTodoResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkTodos", ctx, request, userID)
+	ret0, _ := ret[0].([]dto.BulkTodoResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkTodos indicates an expected call of BulkTodos.
+func (mr *MockTodoServiceMockRecorder) BulkTodos(ctx, request, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkTodos", reflect.TypeOf((*MockTodoService)(nil).BulkTodos), ctx, request, userID)
+}
+
+// ChangeTags mocks base method.
+func (m *MockTodoService) ChangeTags(ctx context.Context, request dto.ChangeTagRequest, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeTags", ctx, request, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeTags indicates an expected call of ChangeTags.
+func (mr *MockTodoServiceMockRecorder) ChangeTags(ctx, request, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeTags", reflect.TypeOf((*MockTodoService)(nil).ChangeTags), ctx, request, userID)
+}
+
+// CreateCalendarToken mocks base method.
+func (m *MockTodoService) CreateCalendarToken(ctx context.Context, userID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCalendarToken", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCalendarToken indicates an expected call of CreateCalendarToken.
+func (mr *MockTodoServiceMockRecorder) CreateCalendarToken(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCalendarToken", reflect.TypeOf((*MockTodoService)(nil).CreateCalendarToken), ctx, userID)
+}
+
+// CreateSubtask mocks base method.
+func (m *MockTodoService) CreateSubtask(ctx context.Context, request dto.SubtaskRequest, userID string) (*entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubtask", ctx, request, userID)
+	ret0, _ := ret[0].(*entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubtask indicates an expected call of CreateSubtask.
+func (mr *MockTodoServiceMockRecorder) CreateSubtask(ctx, request, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubtask", reflect.TypeOf((*MockTodoService)(nil).CreateSubtask), ctx, request, userID)
+}
+
 // CreateTodo mocks base method.
 func (m *MockTodoService) CreateTodo(ctx context.Context, request dto.TodoRequest, userID string) (*entity.Todo, error) {
 	m.ctrl.T.Helper()
@@ -57,18 +133,167 @@ func (mr *MockTodoServiceMockRecorder) CreateTodo(ctx, request, userID any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTodo", reflect.TypeOf((*MockTodoService)(nil).CreateTodo), ctx, request, userID)
 }
 
+// DeleteCalendarToken mocks base method.
+func (m *MockTodoService) DeleteCalendarToken(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCalendarToken", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCalendarToken indicates an expected call of DeleteCalendarToken.
+func (mr *MockTodoServiceMockRecorder) DeleteCalendarToken(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCalendarToken", reflect.TypeOf((*MockTodoService)(nil).DeleteCalendarToken), ctx, userID)
+}
+
+// DeleteSubtask mocks base method.
+func (m *MockTodoService) DeleteSubtask(ctx context.Context, todoID, subtaskID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubtask", ctx, todoID, subtaskID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubtask indicates an expected call of DeleteSubtask.
+func (mr *MockTodoServiceMockRecorder) DeleteSubtask(ctx, todoID, subtaskID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubtask", reflect.TypeOf((*MockTodoService)(nil).DeleteSubtask), ctx, todoID, subtaskID, userID)
+}
+
 // DeleteTodo mocks base method.
-func (m *MockTodoService) DeleteTodo(ctx context.Context, id, userID string) error {
+func (m *MockTodoService) DeleteTodo(ctx context.Context, id, userID string, version int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTodo", ctx, id, userID)
+	ret := m.ctrl.Call(m, "DeleteTodo", ctx, id, userID, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTodo indicates an expected call of DeleteTodo.
-func (mr *MockTodoServiceMockRecorder) DeleteTodo(ctx, id, userID any) *gomock.Call {
+func (mr *MockTodoServiceMockRecorder) DeleteTodo(ctx, id, userID, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTodo", reflect.TypeOf((*MockTodoService)(nil).DeleteTodo), ctx, id, userID, version)
+}
+
+// EmptyTrash mocks base method.
+func (m *MockTodoService) EmptyTrash(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EmptyTrash", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EmptyTrash indicates an expected call of EmptyTrash.
+func (mr *MockTodoServiceMockRecorder) EmptyTrash(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmptyTrash", reflect.TypeOf((*MockTodoService)(nil).EmptyTrash), ctx, userID)
+}
+
+// ExportTodos mocks base method.
+func (m *MockTodoService) ExportTodos(ctx context.Context, userID, format string, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportTodos", ctx, userID, format, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportTodos indicates an expected call of ExportTodos.
+func (mr *MockTodoServiceMockRecorder) ExportTodos(ctx, userID, format, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportTodos", reflect.TypeOf((*MockTodoService)(nil).ExportTodos), ctx, userID, format, w)
+}
+
+// GetAssignedTodos mocks base method.
+func (m *MockTodoService) GetAssignedTodos(ctx context.Context, userID string, query dto.AssignedTodoQuery) ([]entity.Todo, *response.Meta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignedTodos", ctx, userID, query)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(*response.Meta)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAssignedTodos indicates an expected call of GetAssignedTodos.
+func (mr *MockTodoServiceMockRecorder) GetAssignedTodos(ctx, userID, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignedTodos", reflect.TypeOf((*MockTodoService)(nil).GetAssignedTodos), ctx, userID, query)
+}
+
+// GetCalendarFeed mocks base method.
+func (m *MockTodoService) GetCalendarFeed(ctx context.Context, token string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCalendarFeed", ctx, token)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCalendarFeed indicates an expected call of GetCalendarFeed.
+func (mr *MockTodoServiceMockRecorder) GetCalendarFeed(ctx, token any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTodo", reflect.TypeOf((*MockTodoService)(nil).DeleteTodo), ctx, id, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendarFeed", reflect.TypeOf((*MockTodoService)(nil).GetCalendarFeed), ctx, token)
+}
+
+// GetOccurrences mocks base method.
+func (m *MockTodoService) GetOccurrences(ctx context.Context, todoID, userID string) ([]entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOccurrences", ctx, todoID, userID)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOccurrences indicates an expected call of GetOccurrences.
+func (mr *MockTodoServiceMockRecorder) GetOccurrences(ctx, todoID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOccurrences", reflect.TypeOf((*MockTodoService)(nil).GetOccurrences), ctx, todoID, userID)
+}
+
+// GetOverdueTodos mocks base method.
+func (m *MockTodoService) GetOverdueTodos(ctx context.Context, userID string, query dto.TodoDueQuery) ([]entity.Todo, *response.Meta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdueTodos", ctx, userID, query)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(*response.Meta)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetOverdueTodos indicates an expected call of GetOverdueTodos.
+func (mr *MockTodoServiceMockRecorder) GetOverdueTodos(ctx, userID, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdueTodos", reflect.TypeOf((*MockTodoService)(nil).GetOverdueTodos), ctx, userID, query)
+}
+
+// GetSubtasks mocks base method.
+func (m *MockTodoService) GetSubtasks(ctx context.Context, todoID, userID string) ([]entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubtasks", ctx, todoID, userID)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubtasks indicates an expected call of GetSubtasks.
+func (mr *MockTodoServiceMockRecorder) GetSubtasks(ctx, todoID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubtasks", reflect.TypeOf((*MockTodoService)(nil).GetSubtasks), ctx, todoID, userID)
+}
+
+// GetTodayTodos mocks base method.
+func (m *MockTodoService) GetTodayTodos(ctx context.Context, userID string, query dto.TodoDueQuery) ([]entity.Todo, *response.Meta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTodayTodos", ctx, userID, query)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(*response.Meta)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTodayTodos indicates an expected call of GetTodayTodos.
+func (mr *MockTodoServiceMockRecorder) GetTodayTodos(ctx, userID, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTodayTodos", reflect.TypeOf((*MockTodoService)(nil).GetTodayTodos), ctx, userID, query)
 }
 
 // GetTodoByID mocks base method.
@@ -86,19 +311,172 @@ func (mr *MockTodoServiceMockRecorder) GetTodoByID(ctx, id, userID any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTodoByID", reflect.TypeOf((*MockTodoService)(nil).GetTodoByID), ctx, id, userID)
 }
 
+// GetTodoChanges mocks base method.
+func (m *MockTodoService) GetTodoChanges(ctx context.Context, userID string, query dto.TodoChangesQuery) (*dto.TodoChanges, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTodoChanges", ctx, userID, query)
+	ret0, _ := ret[0].(*dto.TodoChanges)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTodoChanges indicates an expected call of GetTodoChanges.
+func (mr *MockTodoServiceMockRecorder) GetTodoChanges(ctx, userID, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTodoChanges", reflect.TypeOf((*MockTodoService)(nil).GetTodoChanges), ctx, userID, query)
+}
+
 // GetTodosByUserID mocks base method.
-func (m *MockTodoService) GetTodosByUserID(ctx context.Context, userID string) ([]entity.Todo, error) {
+func (m *MockTodoService) GetTodosByUserID(ctx context.Context, userID string, query dto.TodoQuery) ([]entity.Todo, *response.Meta, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTodosByUserID", ctx, userID)
+	ret := m.ctrl.Call(m, "GetTodosByUserID", ctx, userID, query)
 	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(*response.Meta)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTodosByUserID indicates an expected call of GetTodosByUserID.
+func (mr *MockTodoServiceMockRecorder) GetTodosByUserID(ctx, userID, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTodosByUserID", reflect.TypeOf((*MockTodoService)(nil).GetTodosByUserID), ctx, userID, query)
+}
+
+// GetTrash mocks base method.
+func (m *MockTodoService) GetTrash(ctx context.Context, userID string, query dto.TrashQuery) ([]entity.Todo, *response.Meta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrash", ctx, userID, query)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(*response.Meta)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTrash indicates an expected call of GetTrash.
+func (mr *MockTodoServiceMockRecorder) GetTrash(ctx, userID, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrash", reflect.TypeOf((*MockTodoService)(nil).GetTrash), ctx, userID, query)
+}
+
+// GetUpcomingTodos mocks base method.
+func (m *MockTodoService) GetUpcomingTodos(ctx context.Context, userID string, query dto.TodoDueQuery) ([]entity.Todo, *response.Meta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpcomingTodos", ctx, userID, query)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(*response.Meta)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUpcomingTodos indicates an expected call of GetUpcomingTodos.
+func (mr *MockTodoServiceMockRecorder) GetUpcomingTodos(ctx, userID, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpcomingTodos", reflect.TypeOf((*MockTodoService)(nil).GetUpcomingTodos), ctx, userID, query)
+}
+
+// ImportTodos mocks base method.
+func (m *MockTodoService) ImportTodos(ctx context.Context, userID, format string, r io.Reader) (*dto.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportTodos", ctx, userID, format, r)
+	ret0, _ := ret[0].(*dto.ImportResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTodosByUserID indicates an expected call of GetTodosByUserID.
-func (mr *MockTodoServiceMockRecorder) GetTodosByUserID(ctx, userID any) *gomock.Call {
+// ImportTodos indicates an expected call of ImportTodos.
+func (mr *MockTodoServiceMockRecorder) ImportTodos(ctx, userID, format, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportTodos", reflect.TypeOf((*MockTodoService)(nil).ImportTodos), ctx, userID, format, r)
+}
+
+// MoveTodo mocks base method.
+func (m *MockTodoService) MoveTodo(ctx context.Context, request dto.MoveTodoRequest, userID string) (*entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTodo", ctx, request, userID)
+	ret0, _ := ret[0].(*entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveTodo indicates an expected call of MoveTodo.
+func (mr *MockTodoServiceMockRecorder) MoveTodo(ctx, request, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTodo", reflect.TypeOf((*MockTodoService)(nil).MoveTodo), ctx, request, userID)
+}
+
+// PurgeTodo mocks base method.
+func (m *MockTodoService) PurgeTodo(ctx context.Context, id, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTodo", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeTodo indicates an expected call of PurgeTodo.
+func (mr *MockTodoServiceMockRecorder) PurgeTodo(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTodo", reflect.TypeOf((*MockTodoService)(nil).PurgeTodo), ctx, id, userID)
+}
+
+// RestoreTodo mocks base method.
+func (m *MockTodoService) RestoreTodo(ctx context.Context, id, userID string) (*entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreTodo", ctx, id, userID)
+	ret0, _ := ret[0].(*entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreTodo indicates an expected call of RestoreTodo.
+func (mr *MockTodoServiceMockRecorder) RestoreTodo(ctx, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTodo", reflect.TypeOf((*MockTodoService)(nil).RestoreTodo), ctx, id, userID)
+}
+
+// SearchTodos mocks base method.
+func (m *MockTodoService) SearchTodos(ctx context.Context, userID string, query dto.TodoSearchQuery) ([]entity.Todo, *response.Meta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTodos", ctx, userID, query)
+	ret0, _ := ret[0].([]entity.Todo)
+	ret1, _ := ret[1].(*response.Meta)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchTodos indicates an expected call of SearchTodos.
+func (mr *MockTodoServiceMockRecorder) SearchTodos(ctx, userID, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTodos", reflect.TypeOf((*MockTodoService)(nil).SearchTodos), ctx, userID, query)
+}
+
+// SyncTodos mocks base method.
+func (m *MockTodoService) SyncTodos(ctx context.Context, request dto.SyncTodosRequest, userID string) ([]dto.SyncResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncTodos", ctx, request, userID)
+	ret0, _ := ret[0].([]dto.SyncResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncTodos indicates an expected call of SyncTodos.
+func (mr *MockTodoServiceMockRecorder) SyncTodos(ctx, request, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncTodos", reflect.TypeOf((*MockTodoService)(nil).SyncTodos), ctx, request, userID)
+}
+
+// UpdateSubtask mocks base method.
+func (m *MockTodoService) UpdateSubtask(ctx context.Context, request dto.UpdateSubtaskRequest, userID string) (*entity.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubtask", ctx, request, userID)
+	ret0, _ := ret[0].(*entity.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSubtask indicates an expected call of UpdateSubtask.
+func (mr *MockTodoServiceMockRecorder) UpdateSubtask(ctx, request, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTodosByUserID", reflect.TypeOf((*MockTodoService)(nil).GetTodosByUserID), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubtask", reflect.TypeOf((*MockTodoService)(nil).UpdateSubtask), ctx, request, userID)
 }
 
 // UpdateTodo mocks base method.
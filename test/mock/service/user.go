@@ -15,6 +15,7 @@ import (
 
 	entity "github.com/sherwin-77/golang-todos/internal/entity"
 	dto "github.com/sherwin-77/golang-todos/internal/http/dto"
+	tokens "github.com/sherwin-77/golang-todos/pkg/tokens"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -72,17 +73,17 @@ func (mr *MockUserServiceMockRecorder) CreateUser(ctx, request any) *gomock.Call
 }
 
 // DeleteUser mocks base method.
-func (m *MockUserService) DeleteUser(ctx context.Context, id string) error {
+func (m *MockUserService) DeleteUser(ctx context.Context, id string, version int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", ctx, id)
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockUserServiceMockRecorder) DeleteUser(ctx, id any) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) DeleteUser(ctx, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserService)(nil).DeleteUser), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserService)(nil).DeleteUser), ctx, id, version)
 }
 
 // GetUserByID mocks base method.
@@ -116,10 +117,10 @@ func (mr *MockUserServiceMockRecorder) GetUsers(ctx any) *gomock.Call {
 }
 
 // Login mocks base method.
-func (m *MockUserService) Login(ctx context.Context, request dto.LoginRequest) (string, error) {
+func (m *MockUserService) Login(ctx context.Context, request dto.LoginRequest) (*dto.LoginResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Login", ctx, request)
-	ret0, _ := ret[0].(string)
+	ret0, _ := ret[0].(*dto.LoginResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -130,6 +131,64 @@ func (mr *MockUserServiceMockRecorder) Login(ctx, request any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserService)(nil).Login), ctx, request)
 }
 
+// LoginMFA mocks base method.
+func (m *MockUserService) LoginMFA(ctx context.Context, request dto.MFALoginRequest) (*dto.TokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginMFA", ctx, request)
+	ret0, _ := ret[0].(*dto.TokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginMFA indicates an expected call of LoginMFA.
+func (mr *MockUserServiceMockRecorder) LoginMFA(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginMFA", reflect.TypeOf((*MockUserService)(nil).LoginMFA), ctx, request)
+}
+
+// Logout mocks base method.
+func (m *MockUserService) Logout(ctx context.Context, claims *tokens.JWTCustomClaims) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, claims)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockUserServiceMockRecorder) Logout(ctx, claims any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockUserService)(nil).Logout), ctx, claims)
+}
+
+// LogoutAll mocks base method.
+func (m *MockUserService) LogoutAll(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutAll", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutAll indicates an expected call of LogoutAll.
+func (mr *MockUserServiceMockRecorder) LogoutAll(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutAll", reflect.TypeOf((*MockUserService)(nil).LogoutAll), ctx, userID)
+}
+
+// Refresh mocks base method.
+func (m *MockUserService) Refresh(ctx context.Context, request dto.RefreshRequest) (*dto.TokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, request)
+	ret0, _ := ret[0].(*dto.TokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockUserServiceMockRecorder) Refresh(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockUserService)(nil).Refresh), ctx, request)
+}
+
 // Register mocks base method.
 func (m *MockUserService) Register(ctx context.Context, request dto.UserRequest) (*entity.User, bool, error) {
 	m.ctrl.T.Helper()